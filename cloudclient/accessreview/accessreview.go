@@ -0,0 +1,374 @@
+// Package accessreview builds the effective-permission matrix of a Temporal Cloud account: for every user, user
+// group and service account, the permission it has on every namespace, and whether it was granted directly,
+// inherited from its account role or inherited from a user group. The matrix is exported as CSV or HTML for
+// periodic access reviews.
+//
+// WARNING: The package is currently experimental.
+//
+// The namespace assignments returned by the cloud operations API report the permissions granted directly to a
+// principal or inherited from its account role, but not those inherited by the users of a group. The matrix
+// expands the assignments of every group to its members, so that a user appears with every way it is granted
+// access to a namespace.
+package accessreview
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	"go.temporal.io/cloud-sdk/internal/paging"
+)
+
+// The types of principals.
+const (
+	PrincipalUser           PrincipalType = "user"
+	PrincipalUserGroup      PrincipalType = "user_group"
+	PrincipalServiceAccount PrincipalType = "service_account"
+)
+
+// The sources of a grant.
+const (
+	// SourceDirect is a permission assigned to the principal on the namespace.
+	SourceDirect Source = "direct"
+	// SourceAccountRole is a permission inherited from the account role of the principal.
+	SourceAccountRole Source = "account_role"
+	// SourceGroup is a permission inherited from a user group the user is a member of.
+	SourceGroup Source = "group"
+)
+
+type (
+	// PrincipalType is a type of principal.
+	PrincipalType string
+
+	// Source tells how a permission is granted.
+	Source string
+
+	// Principal is a user, a user group or a service account.
+	Principal struct {
+		Type PrincipalType
+		ID   string
+		// The email of a user, the display name of a group or the name of a service account.
+		Name string
+		// The account role, such as ADMIN or DEVELOPER, empty if none.
+		AccountRole string
+	}
+
+	// Grant is a way a principal is granted a permission on a namespace.
+	Grant struct {
+		// The namespace permission, such as ADMIN, WRITE or READ.
+		Permission string
+		Source     Source
+		// The user group the permission is inherited from, for SourceGroup.
+		Via *Principal
+	}
+
+	// Cell is the access of a principal to a namespace.
+	Cell struct {
+		Principal *Principal
+		Namespace string
+		// The highest permission of the grants.
+		Permission string
+		Grants     []Grant
+	}
+
+	// Matrix is the access of the principals of an account to its namespaces.
+	Matrix struct {
+		// The namespaces, sorted.
+		Namespaces []string
+		// The principals, sorted by type and name, including those without access to any namespace.
+		Principals []*Principal
+		// The principals with access to a namespace, in the order of Principals then Namespaces.
+		Cells []*Cell
+	}
+
+	// Options to configure the building of a matrix.
+	Options struct {
+		// The namespaces to review.
+		// If not provided, all the namespaces of the account are reviewed.
+		Namespaces []string
+	}
+)
+
+// permissionRank orders the namespace permissions, from the lowest to the highest.
+var permissionRank = map[string]int{
+	trimPermission(identityv1.NamespaceAccess_PERMISSION_READ):  1,
+	trimPermission(identityv1.NamespaceAccess_PERMISSION_WRITE): 2,
+	trimPermission(identityv1.NamespaceAccess_PERMISSION_ADMIN): 3,
+}
+
+// Build lists the principals of the account, the members of its user groups and the namespace assignments of
+// every namespace, and returns the matrix of their effective permissions.
+func Build(ctx context.Context, client cloudservicev1.CloudServiceClient, options Options) (*Matrix, error) {
+	b := builder{
+		matrix:     &Matrix{},
+		principals: map[PrincipalType]map[string]*Principal{},
+		cells:      map[*Principal]map[string]*Cell{},
+	}
+	if err := b.listPrincipals(ctx, client); err != nil {
+		return nil, err
+	}
+
+	namespaces := options.Namespaces
+	if namespaces == nil {
+		var err error
+		if namespaces, err = listNamespaces(ctx, client); err != nil {
+			return nil, err
+		}
+	}
+	members := map[string][]string{}
+	for _, ns := range namespaces {
+		if err := b.addAssignments(ctx, client, ns, members); err != nil {
+			return nil, err
+		}
+	}
+	b.matrix.Namespaces = slices.Sorted(slices.Values(namespaces))
+	b.sort()
+	return b.matrix, nil
+}
+
+// Lookup returns the access of a principal to a namespace, nil if it has none.
+func (m *Matrix) Lookup(principalType PrincipalType, id, namespace string) *Cell {
+	for _, c := range m.Cells {
+		if c.Principal.Type == principalType && c.Principal.ID == id && c.Namespace == namespace {
+			return c
+		}
+	}
+	return nil
+}
+
+// String returns the type and name of the principal.
+func (p *Principal) String() string {
+	if p.Name == "" {
+		return fmt.Sprintf("%s %s", p.Type, p.ID)
+	}
+	return fmt.Sprintf("%s %s", p.Type, p.Name)
+}
+
+// String describes the grant, such as "WRITE via group sre".
+func (g Grant) String() string {
+	switch g.Source {
+	case SourceGroup:
+		return fmt.Sprintf("%s via group %s", g.Permission, g.Via.Name)
+	case SourceAccountRole:
+		return g.Permission + " via account role"
+	}
+	return g.Permission
+}
+
+type builder struct {
+	matrix     *Matrix
+	principals map[PrincipalType]map[string]*Principal
+	cells      map[*Principal]map[string]*Cell
+}
+
+func (b *builder) listPrincipals(ctx context.Context, client cloudservicev1.CloudServiceClient) error {
+	users, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.User, string, error) {
+		resp, err := client.GetUsers(ctx, &cloudservicev1.GetUsersRequest{PageToken: pageToken})
+		return resp.GetUsers(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+	for _, u := range users {
+		b.principal(PrincipalUser, u.GetId(), u.GetSpec().GetEmail()).AccountRole = accountRole(u.GetSpec().GetAccess())
+	}
+
+	groups, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.UserGroup, string, error) {
+		resp, err := client.GetUserGroups(ctx, &cloudservicev1.GetUserGroupsRequest{PageToken: pageToken})
+		return resp.GetGroups(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list user groups: %w", err)
+	}
+	for _, g := range groups {
+		b.principal(PrincipalUserGroup, g.GetId(), g.GetSpec().GetDisplayName()).AccountRole = accountRole(g.GetSpec().GetAccess())
+	}
+
+	serviceAccounts, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.ServiceAccount, string, error) {
+		resp, err := client.GetServiceAccounts(ctx, &cloudservicev1.GetServiceAccountsRequest{PageToken: pageToken})
+		return resp.GetServiceAccount(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	for _, sa := range serviceAccounts {
+		b.principal(PrincipalServiceAccount, sa.GetId(), sa.GetSpec().GetName()).AccountRole = accountRole(sa.GetSpec().GetAccess())
+	}
+	return nil
+}
+
+// addAssignments adds the namespace assignments of the namespace, expanding those of the user groups to their
+// members, which are listed once and cached in members.
+func (b *builder) addAssignments(
+	ctx context.Context,
+	client cloudservicev1.CloudServiceClient,
+	namespace string,
+	members map[string][]string,
+) error {
+	users, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.UserNamespaceAssignment, string, error) {
+		resp, err := client.GetUserNamespaceAssignments(ctx, &cloudservicev1.GetUserNamespaceAssignmentsRequest{
+			Namespace: namespace,
+			PageToken: pageToken,
+		})
+		return resp.GetUsers(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the user assignments of namespace %q: %w", namespace, err)
+	}
+	for _, a := range users {
+		b.grant(b.principal(PrincipalUser, a.GetId(), a.GetEmail()), namespace, a.GetNamespaceAccess(), a.GetInheritedAccess(), nil)
+	}
+
+	serviceAccounts, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.ServiceAccountNamespaceAssignment, string, error) {
+		resp, err := client.GetServiceAccountNamespaceAssignments(ctx, &cloudservicev1.GetServiceAccountNamespaceAssignmentsRequest{
+			Namespace: namespace,
+			PageToken: pageToken,
+		})
+		return resp.GetServiceAccounts(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the service account assignments of namespace %q: %w", namespace, err)
+	}
+	for _, a := range serviceAccounts {
+		b.grant(b.principal(PrincipalServiceAccount, a.GetId(), a.GetName()), namespace, a.GetNamespaceAccess(), a.GetInheritedAccess(), nil)
+	}
+
+	groups, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.UserGroupNamespaceAssignment, string, error) {
+		resp, err := client.GetUserGroupNamespaceAssignments(ctx, &cloudservicev1.GetUserGroupNamespaceAssignmentsRequest{
+			Namespace: namespace,
+			PageToken: pageToken,
+		})
+		return resp.GetGroups(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the user group assignments of namespace %q: %w", namespace, err)
+	}
+	for _, a := range groups {
+		group := b.principal(PrincipalUserGroup, a.GetId(), a.GetDisplayName())
+		b.grant(group, namespace, a.GetNamespaceAccess(), a.GetInheritedAccess(), nil)
+
+		userIDs, ok := members[group.ID]
+		if !ok {
+			if userIDs, err = listMembers(ctx, client, group.ID); err != nil {
+				return err
+			}
+			members[group.ID] = userIDs
+		}
+		for _, id := range userIDs {
+			b.grant(b.principal(PrincipalUser, id, ""), namespace, a.GetNamespaceAccess(), false, group)
+		}
+	}
+	return nil
+}
+
+// principal returns the principal with the given type and id, adding it if it is new.
+func (b *builder) principal(principalType PrincipalType, id, name string) *Principal {
+	if b.principals[principalType] == nil {
+		b.principals[principalType] = map[string]*Principal{}
+	}
+	p, ok := b.principals[principalType][id]
+	if !ok {
+		p = &Principal{Type: principalType, ID: id}
+		b.principals[principalType][id] = p
+		b.matrix.Principals = append(b.matrix.Principals, p)
+	}
+	if p.Name == "" {
+		p.Name = name
+	}
+	return p
+}
+
+func (b *builder) grant(p *Principal, namespace string, access *identityv1.NamespaceAccess, inherited bool, via *Principal) {
+	permission := trimPermission(access.GetPermission())
+	if _, ok := permissionRank[permission]; !ok {
+		return
+	}
+	grant := Grant{Permission: permission, Source: SourceDirect, Via: via}
+	switch {
+	case via != nil:
+		grant.Source = SourceGroup
+	case inherited:
+		grant.Source = SourceAccountRole
+	}
+
+	if b.cells[p] == nil {
+		b.cells[p] = map[string]*Cell{}
+	}
+	c, ok := b.cells[p][namespace]
+	if !ok {
+		c = &Cell{Principal: p, Namespace: namespace}
+		b.cells[p][namespace] = c
+		b.matrix.Cells = append(b.matrix.Cells, c)
+	}
+	c.Grants = append(c.Grants, grant)
+	if permissionRank[permission] > permissionRank[c.Permission] {
+		c.Permission = permission
+	}
+}
+
+func (b *builder) sort() {
+	index := map[*Principal]int{}
+	slices.SortFunc(b.matrix.Principals, func(p1, p2 *Principal) int {
+		return strings.Compare(string(p1.Type)+"\x00"+p1.Name+"\x00"+p1.ID, string(p2.Type)+"\x00"+p2.Name+"\x00"+p2.ID)
+	})
+	for i, p := range b.matrix.Principals {
+		index[p] = i
+	}
+	slices.SortFunc(b.matrix.Cells, func(c1, c2 *Cell) int {
+		if d := index[c1.Principal] - index[c2.Principal]; d != 0 {
+			return d
+		}
+		return strings.Compare(c1.Namespace, c2.Namespace)
+	})
+}
+
+func listNamespaces(ctx context.Context, client cloudservicev1.CloudServiceClient) ([]string, error) {
+	namespaces, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*namespacev1.Namespace, string, error) {
+		resp, err := client.GetNamespaces(ctx, &cloudservicev1.GetNamespacesRequest{PageToken: pageToken})
+		return resp.GetNamespaces(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	names := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		names = append(names, ns.GetNamespace())
+	}
+	return names, nil
+}
+
+func listMembers(ctx context.Context, client cloudservicev1.CloudServiceClient, groupID string) ([]string, error) {
+	members, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.UserGroupMember, string, error) {
+		resp, err := client.GetUserGroupMembers(ctx, &cloudservicev1.GetUserGroupMembersRequest{
+			GroupId:   groupID,
+			PageToken: pageToken,
+		})
+		return resp.GetMembers(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the members of user group %q: %w", groupID, err)
+	}
+	var ids []string
+	for _, m := range members {
+		if id := m.GetMemberId().GetUserId(); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func accountRole(access *identityv1.Access) string {
+	role := access.GetAccountAccess().GetRole()
+	if role == identityv1.AccountAccess_ROLE_UNSPECIFIED {
+		return ""
+	}
+	return strings.TrimPrefix(role.String(), "ROLE_")
+}
+
+func trimPermission(p identityv1.NamespaceAccess_Permission) string {
+	return strings.TrimPrefix(p.String(), "PERMISSION_")
+}
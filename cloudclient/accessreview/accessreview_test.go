@@ -0,0 +1,160 @@
+package accessreview
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/cloudservicemock"
+	"google.golang.org/grpc"
+)
+
+type fakeCloudService struct {
+}
+
+func access(p identityv1.NamespaceAccess_Permission) *identityv1.NamespaceAccess {
+	return &identityv1.NamespaceAccess{Permission: p}
+}
+
+func accountAccess(r identityv1.AccountAccess_Role) *identityv1.Access {
+	return &identityv1.Access{AccountAccess: &identityv1.AccountAccess{Role: r}}
+}
+
+func (s *fakeCloudService) GetUsers(ctx context.Context, req *cloudservicev1.GetUsersRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUsersResponse, error) {
+	return &cloudservicev1.GetUsersResponse{Users: []*identityv1.User{
+		{Id: "u1", Spec: &identityv1.UserSpec{Email: "alice@example.com", Access: accountAccess(identityv1.AccountAccess_ROLE_ADMIN)}},
+		{Id: "u2", Spec: &identityv1.UserSpec{Email: "bob@example.com", Access: accountAccess(identityv1.AccountAccess_ROLE_DEVELOPER)}},
+		{Id: "u3", Spec: &identityv1.UserSpec{Email: "carol@example.com", Access: accountAccess(identityv1.AccountAccess_ROLE_READ)}},
+	}}, nil
+}
+
+func (s *fakeCloudService) GetUserGroups(ctx context.Context, req *cloudservicev1.GetUserGroupsRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUserGroupsResponse, error) {
+	return &cloudservicev1.GetUserGroupsResponse{Groups: []*identityv1.UserGroup{
+		{Id: "g1", Spec: &identityv1.UserGroupSpec{DisplayName: "sre"}},
+	}}, nil
+}
+
+func (s *fakeCloudService) GetServiceAccounts(ctx context.Context, req *cloudservicev1.GetServiceAccountsRequest, opts ...grpc.CallOption) (*cloudservicev1.GetServiceAccountsResponse, error) {
+	return &cloudservicev1.GetServiceAccountsResponse{ServiceAccount: []*identityv1.ServiceAccount{
+		{Id: "sa1", Spec: &identityv1.ServiceAccountSpec{Name: "ci"}},
+	}}, nil
+}
+
+func (s *fakeCloudService) GetUserGroupMembers(ctx context.Context, req *cloudservicev1.GetUserGroupMembersRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUserGroupMembersResponse, error) {
+	return &cloudservicev1.GetUserGroupMembersResponse{Members: []*identityv1.UserGroupMember{
+		{MemberId: &identityv1.UserGroupMemberId{MemberType: &identityv1.UserGroupMemberId_UserId{UserId: "u2"}}},
+	}}, nil
+}
+
+func (s *fakeCloudService) GetNamespaces(ctx context.Context, req *cloudservicev1.GetNamespacesRequest, opts ...grpc.CallOption) (*cloudservicev1.GetNamespacesResponse, error) {
+	return &cloudservicev1.GetNamespacesResponse{Namespaces: []*namespacev1.Namespace{
+		{Namespace: "prod.a1b2c"}, {Namespace: "dev.a1b2c"},
+	}}, nil
+}
+
+func (s *fakeCloudService) GetUserNamespaceAssignments(ctx context.Context, req *cloudservicev1.GetUserNamespaceAssignmentsRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUserNamespaceAssignmentsResponse, error) {
+	resp := &cloudservicev1.GetUserNamespaceAssignmentsResponse{Users: []*identityv1.UserNamespaceAssignment{
+		{Id: "u1", Email: "alice@example.com", NamespaceAccess: access(identityv1.NamespaceAccess_PERMISSION_ADMIN), InheritedAccess: true},
+	}}
+	if req.GetNamespace() == "dev.a1b2c" {
+		resp.Users = append(resp.Users, &identityv1.UserNamespaceAssignment{
+			Id: "u2", Email: "bob@example.com", NamespaceAccess: access(identityv1.NamespaceAccess_PERMISSION_READ),
+		})
+	}
+	return resp, nil
+}
+
+func (s *fakeCloudService) GetServiceAccountNamespaceAssignments(ctx context.Context, req *cloudservicev1.GetServiceAccountNamespaceAssignmentsRequest, opts ...grpc.CallOption) (*cloudservicev1.GetServiceAccountNamespaceAssignmentsResponse, error) {
+	if req.GetNamespace() != "dev.a1b2c" {
+		return &cloudservicev1.GetServiceAccountNamespaceAssignmentsResponse{}, nil
+	}
+	return &cloudservicev1.GetServiceAccountNamespaceAssignmentsResponse{ServiceAccounts: []*identityv1.ServiceAccountNamespaceAssignment{
+		{Id: "sa1", Name: "ci", NamespaceAccess: access(identityv1.NamespaceAccess_PERMISSION_WRITE)},
+	}}, nil
+}
+
+func (s *fakeCloudService) GetUserGroupNamespaceAssignments(ctx context.Context, req *cloudservicev1.GetUserGroupNamespaceAssignmentsRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUserGroupNamespaceAssignmentsResponse, error) {
+	return &cloudservicev1.GetUserGroupNamespaceAssignmentsResponse{Groups: []*identityv1.UserGroupNamespaceAssignment{
+		{Id: "g1", DisplayName: "sre", NamespaceAccess: access(identityv1.NamespaceAccess_PERMISSION_WRITE)},
+	}}, nil
+}
+
+func TestBuild(t *testing.T) {
+	m, err := Build(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, &fakeCloudService{}), Options{})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if got := strings.Join(m.Namespaces, ","); got != "dev.a1b2c,prod.a1b2c" {
+		t.Errorf("Build() namespaces = %s", got)
+	}
+	if len(m.Principals) != 5 {
+		t.Errorf("Build() principals = %v, want every user, group and service account", m.Principals)
+	}
+
+	bob := m.Lookup(PrincipalUser, "u2", "dev.a1b2c")
+	if bob == nil || bob.Permission != "WRITE" || len(bob.Grants) != 2 ||
+		bob.Grants[0].String() != "READ" || bob.Grants[1].String() != "WRITE via group sre" {
+		t.Errorf("Lookup(bob, dev) = %+v, want READ directly and WRITE via sre", bob)
+	}
+	if alice := m.Lookup(PrincipalUser, "u1", "prod.a1b2c"); alice == nil || alice.Grants[0].Source != SourceAccountRole {
+		t.Errorf("Lookup(alice, prod) = %+v, want ADMIN via the account role", alice)
+	}
+	if carol := m.Lookup(PrincipalUser, "u3", "prod.a1b2c"); carol != nil {
+		t.Errorf("Lookup(carol, prod) = %+v, want no access", carol)
+	}
+
+	var csv bytes.Buffer
+	if err := m.WriteCSV(&csv); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	for _, line := range []string{
+		"principal_type,principal_id,principal,account_role,namespace,effective_permission,permission,source,via_group",
+		"user,u2,bob@example.com,DEVELOPER,dev.a1b2c,WRITE,WRITE,group,sre",
+		"user,u3,carol@example.com,READ,,,,,",
+		"service_account,sa1,ci,,dev.a1b2c,WRITE,WRITE,direct,",
+	} {
+		if !strings.Contains(csv.String(), line+"\n") {
+			t.Errorf("WriteCSV() = %s, want the line %s", csv.String(), line)
+		}
+	}
+
+	var html bytes.Buffer
+	if err := m.WriteHTML(&html, "Q3 <review>"); err != nil {
+		t.Fatalf("WriteHTML() error = %v", err)
+	}
+	for _, s := range []string{"<title>Q3 &lt;review&gt;</title>", `<td class="WRITE">WRITE<div class="grants">READ<br>WRITE via group sre<br>`} {
+		if !strings.Contains(html.String(), s) {
+			t.Errorf("WriteHTML() = %s, want %s", html.String(), s)
+		}
+	}
+}
+
+func TestWriteCSVNeutralizesFormulas(t *testing.T) {
+	alice := &Principal{Type: PrincipalUser, ID: "u1", Name: "=HYPERLINK(\"https://example.com\")", AccountRole: "READ"}
+	group := &Principal{Type: PrincipalUserGroup, ID: "g1", Name: "@sre"}
+	m := &Matrix{
+		Principals: []*Principal{alice, group},
+		Cells: []*Cell{{
+			Principal:  alice,
+			Namespace:  "prod.a1b2c",
+			Permission: "WRITE",
+			Grants:     []Grant{{Permission: "WRITE", Source: SourceGroup, Via: group}},
+		}},
+	}
+	var csv bytes.Buffer
+	if err := m.WriteCSV(&csv); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	for _, line := range []string{
+		`user,u1,"'=HYPERLINK(""https://example.com"")",READ,prod.a1b2c,WRITE,WRITE,group,'@sre`,
+		"user_group,g1,'@sre,,,,,,",
+	} {
+		if !strings.Contains(csv.String(), line+"\n") {
+			t.Errorf("WriteCSV() = %s, want the line %s", csv.String(), line)
+		}
+	}
+}
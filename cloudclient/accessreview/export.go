@@ -0,0 +1,123 @@
+package accessreview
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+
+	"go.temporal.io/cloud-sdk/internal/csvsafe"
+)
+
+// csvHeader is the header of the CSV export, one row per grant.
+var csvHeader = []string{
+	"principal_type", "principal_id", "principal", "account_role",
+	"namespace", "effective_permission", "permission", "source", "via_group",
+}
+
+// WriteCSV writes the matrix as CSV, with a header line and one row per grant, so that every way a principal
+// is granted access to a namespace can be reviewed. Principals without access to any namespace are written
+// once, with empty namespace columns. The names starting with =, +, -, @, a tab or a carriage return are
+// prefixed with a quote, for spreadsheets to not evaluate them as formulas.
+func (m *Matrix) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("failed to write the CSV header: %w", err)
+	}
+	cells := m.cellsByPrincipal()
+	for _, p := range m.Principals {
+		principal := []string{string(p.Type), p.ID, csvsafe.Value(p.Name), p.AccountRole}
+		if len(cells[p]) == 0 {
+			if err := cw.Write(append(principal, "", "", "", "", "")); err != nil {
+				return fmt.Errorf("failed to write the CSV row: %w", err)
+			}
+			continue
+		}
+		for _, c := range cells[p] {
+			for _, g := range c.Grants {
+				var via string
+				if g.Via != nil {
+					via = csvsafe.Value(g.Via.Name)
+				}
+				row := append(principal[:4:4], c.Namespace, c.Permission, g.Permission, string(g.Source), via)
+				if err := cw.Write(row); err != nil {
+					return fmt.Errorf("failed to write the CSV row: %w", err)
+				}
+			}
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write the CSV rows: %w", err)
+	}
+	return nil
+}
+
+var htmlTemplate = template.Must(template.New("matrix").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; font-size: 13px; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f4f4f4; }
+.ADMIN { background: #f8d7da; }
+.WRITE { background: #fff3cd; }
+.READ { background: #d1e7dd; }
+.grants { color: #555; font-size: 11px; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<table>
+<tr><th>Type</th><th>Principal</th><th>Account role</th>{{range .Namespaces}}<th>{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr><td>{{.Principal.Type}}</td><td>{{if .Principal.Name}}{{.Principal.Name}}{{else}}{{.Principal.ID}}{{end}}</td><td>{{.Principal.AccountRole}}</td>
+{{- range .Cells}}{{if .}}<td class="{{.Permission}}">{{.Permission}}<div class="grants">{{range .Grants}}{{.}}<br>{{end}}</div></td>{{else}}<td></td>{{end}}{{end}}</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// WriteHTML writes the matrix as a standalone HTML page, with one row per principal and one column per
+// namespace, each cell showing the effective permission and the grants it comes from.
+func (m *Matrix) WriteHTML(w io.Writer, title string) error {
+	type row struct {
+		Principal *Principal
+		Cells     []*Cell
+	}
+	data := struct {
+		Title      string
+		Namespaces []string
+		Rows       []row
+	}{Title: title, Namespaces: m.Namespaces}
+	if data.Title == "" {
+		data.Title = "Access review"
+	}
+
+	cells := m.cellsByPrincipal()
+	for _, p := range m.Principals {
+		r := row{Principal: p, Cells: make([]*Cell, len(m.Namespaces))}
+		for _, c := range cells[p] {
+			for i, ns := range m.Namespaces {
+				if ns == c.Namespace {
+					r.Cells[i] = c
+				}
+			}
+		}
+		data.Rows = append(data.Rows, r)
+	}
+	if err := htmlTemplate.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to write the HTML matrix: %w", err)
+	}
+	return nil
+}
+
+func (m *Matrix) cellsByPrincipal() map[*Principal][]*Cell {
+	cells := map[*Principal][]*Cell{}
+	for _, c := range m.Cells {
+		cells[c.Principal] = append(cells[c.Principal], c)
+	}
+	return cells
+}
@@ -0,0 +1,132 @@
+package cloudclient
+
+import (
+	"context"
+	"fmt"
+
+	cloudservice "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identity "go.temporal.io/cloud-sdk/api/identity/v1"
+	"go.temporal.io/cloud-sdk/internal/paging"
+	"google.golang.org/protobuf/proto"
+)
+
+type (
+	// APIKeyClient to manage the API keys of the account.
+	APIKeyClient interface {
+		// Get returns the API key with the given id.
+		Get(ctx context.Context, id string) (*identity.ApiKey, error)
+		// List returns every API key of the account.
+		List(ctx context.Context) ([]*identity.ApiKey, error)
+		// ListByOwner returns the API keys owned by a user or a service account.
+		ListByOwner(ctx context.Context, ownerID string, ownerType identity.OwnerType) ([]*identity.ApiKey, error)
+		// Create starts the creation of an API key and returns its id and its token.
+		// The token is the secret to authenticate with, it cannot be retrieved later on.
+		Create(ctx context.Context, spec *identity.ApiKeySpec) (id string, token string, op *AsyncOperation, err error)
+		// Update replaces the spec of an API key with key.Spec.
+		// The update is rejected if the key changed since it was retrieved, see key.ResourceVersion.
+		Update(ctx context.Context, key *identity.ApiKey) (*AsyncOperation, error)
+		// Disable disables the latest version of an API key, the key can no longer be used to authenticate.
+		Disable(ctx context.Context, id string) (*AsyncOperation, error)
+		// Enable enables the latest version of a disabled API key.
+		Enable(ctx context.Context, id string) (*AsyncOperation, error)
+		// Delete deletes an API key.
+		// If resourceVersion is empty, the latest version of the key is deleted.
+		Delete(ctx context.Context, id string, resourceVersion string) (*AsyncOperation, error)
+	}
+
+	apiKeyClient struct {
+		client cloudservice.CloudServiceClient
+	}
+)
+
+func (c apiKeyClient) Get(ctx context.Context, id string) (*identity.ApiKey, error) {
+	resp, err := c.client.GetApiKey(ctx, &cloudservice.GetApiKeyRequest{
+		KeyId: id,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return resp.GetApiKey(), nil
+}
+
+func (c apiKeyClient) List(ctx context.Context) ([]*identity.ApiKey, error) {
+	return c.ListByOwner(ctx, "", identity.OwnerType_OWNER_TYPE_UNSPECIFIED)
+}
+
+func (c apiKeyClient) ListByOwner(ctx context.Context, ownerID string, ownerType identity.OwnerType) ([]*identity.ApiKey, error) {
+	keys, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identity.ApiKey, string, error) {
+		resp, err := c.client.GetApiKeys(ctx, &cloudservice.GetApiKeysRequest{
+			PageToken: pageToken,
+			OwnerId:   ownerID,
+			OwnerType: ownerType,
+		})
+		return resp.GetApiKeys(), resp.GetNextPageToken(), err
+	})
+	return keys, toAPIError(err)
+}
+
+func (c apiKeyClient) Create(ctx context.Context, spec *identity.ApiKeySpec) (string, string, *AsyncOperation, error) {
+	resp, err := c.client.CreateApiKey(ctx, &cloudservice.CreateApiKeyRequest{
+		Spec: spec,
+	})
+	if err != nil {
+		return "", "", nil, toAPIError(err)
+	}
+	return resp.GetKeyId(), resp.GetToken(), newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c apiKeyClient) Update(ctx context.Context, key *identity.ApiKey) (*AsyncOperation, error) {
+	resp, err := c.client.UpdateApiKey(ctx, &cloudservice.UpdateApiKeyRequest{
+		KeyId:           key.GetId(),
+		Spec:            key.GetSpec(),
+		ResourceVersion: key.GetResourceVersion(),
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c apiKeyClient) Disable(ctx context.Context, id string) (*AsyncOperation, error) {
+	return c.setDisabled(ctx, id, true)
+}
+
+func (c apiKeyClient) Enable(ctx context.Context, id string) (*AsyncOperation, error) {
+	return c.setDisabled(ctx, id, false)
+}
+
+func (c apiKeyClient) setDisabled(ctx context.Context, id string, disabled bool) (*AsyncOperation, error) {
+	key, err := c.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// The spec is replaced as a whole, a key without spec cannot be updated without clearing its other fields.
+	if key.GetSpec() == nil {
+		return nil, fmt.Errorf("api key %q has no spec", id)
+	}
+	spec := proto.Clone(key.GetSpec()).(*identity.ApiKeySpec)
+	spec.Disabled = disabled
+	return c.Update(ctx, &identity.ApiKey{
+		Id:              key.GetId(),
+		Spec:            spec,
+		ResourceVersion: key.GetResourceVersion(),
+	})
+}
+
+func (c apiKeyClient) Delete(ctx context.Context, id string, resourceVersion string) (*AsyncOperation, error) {
+	if resourceVersion == "" {
+		key, err := c.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		resourceVersion = key.GetResourceVersion()
+	}
+	resp, err := c.client.DeleteApiKey(ctx, &cloudservice.DeleteApiKeyRequest{
+		KeyId:           id,
+		ResourceVersion: resourceVersion,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
@@ -0,0 +1,93 @@
+package cloudclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// EnvAPIKeyReader reads the API key from an environment variable, every time a request is made.
+	EnvAPIKeyReader struct {
+		// The name of the environment variable holding the API key.
+		Name string
+	}
+
+	// FileAPIKeyReader reads the API key from a file, every time a request is made, so that the key can be rotated.
+	// Leading and trailing white space is trimmed.
+	FileAPIKeyReader struct {
+		// The path of the file holding the API key.
+		Path string
+	}
+
+	// CommandAPIKeyReader runs a command, such as a password manager CLI, and reads the API key from its standard output.
+	// Leading and trailing white space is trimmed.
+	CommandAPIKeyReader struct {
+		// The command to run and its arguments, the command is not run through a shell.
+		Command []string
+
+		// How long to reuse the API key for before running the command again.
+		// If not provided, the command is run every time a request is made.
+		CacheDuration time.Duration
+
+		mu        sync.Mutex
+		apiKey    string
+		expiresAt time.Time
+	}
+)
+
+func (r EnvAPIKeyReader) GetAPIKey(ctx context.Context) (string, error) {
+	apiKey := os.Getenv(r.Name)
+	if apiKey == "" {
+		return "", fmt.Errorf("environment variable %s is not set", r.Name)
+	}
+	return apiKey, nil
+}
+
+func (r FileAPIKeyReader) GetAPIKey(ctx context.Context) (string, error) {
+	data, err := os.ReadFile(r.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the API key file: %w", err)
+	}
+	apiKey := strings.TrimSpace(string(data))
+	if apiKey == "" {
+		return "", fmt.Errorf("API key file %q is empty", r.Path)
+	}
+	return apiKey, nil
+}
+
+func (r *CommandAPIKeyReader) GetAPIKey(ctx context.Context) (string, error) {
+	if len(r.Command) == 0 {
+		return "", errors.New("no API key command provided")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.apiKey != "" && time.Now().Before(r.expiresAt) {
+		return r.apiKey, nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.Command[0], r.Command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to run the API key command %q: %w: %s", r.Command[0], err, strings.TrimSpace(stderr.String()))
+	}
+	apiKey := strings.TrimSpace(stdout.String())
+	if apiKey == "" {
+		return "", fmt.Errorf("API key command %q returned an empty key", r.Command[0])
+	}
+
+	if r.CacheDuration > 0 {
+		r.apiKey = apiKey
+		r.expiresAt = time.Now().Add(r.CacheDuration)
+	}
+	return apiKey, nil
+}
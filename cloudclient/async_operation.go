@@ -0,0 +1,81 @@
+package cloudclient
+
+import (
+	"context"
+	"time"
+
+	cloudservice "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	operation "go.temporal.io/cloud-sdk/api/operation/v1"
+	"go.temporal.io/cloud-sdk/internal/asyncop"
+)
+
+type (
+	// AsyncOperationClient to track the async operations started by mutations.
+	AsyncOperationClient interface {
+		// Get returns the current state of an async operation.
+		Get(ctx context.Context, id string) (*operation.AsyncOperation, error)
+		// Wait blocks until the async operation reaches a terminal state.
+		// An AsyncOperationError is returned if the operation did not fulfill.
+		Wait(ctx context.Context, id string) (*operation.AsyncOperation, error)
+	}
+
+	// AsyncOperation is a handle on the async operation started by a mutation.
+	AsyncOperation struct {
+		client    cloudservice.CloudServiceClient
+		operation *operation.AsyncOperation
+	}
+
+	asyncOperationClient struct {
+		client cloudservice.CloudServiceClient
+	}
+)
+
+func newAsyncOperation(client cloudservice.CloudServiceClient, op *operation.AsyncOperation) *AsyncOperation {
+	return &AsyncOperation{
+		client:    client,
+		operation: op,
+	}
+}
+
+// ID returns the id of the async operation, empty if the mutation completed synchronously.
+func (o *AsyncOperation) ID() string {
+	return o.operation.GetId()
+}
+
+// Operation returns the async operation as returned by the mutation, or by the last Wait.
+func (o *AsyncOperation) Operation() *operation.AsyncOperation {
+	return o.operation
+}
+
+// Wait blocks until the async operation reaches a terminal state.
+// An AsyncOperationError is returned if the operation did not fulfill.
+func (o *AsyncOperation) Wait(ctx context.Context) error {
+	op, err := waitAsyncOperation(ctx, o.client, o.ID())
+	if op != nil {
+		o.operation = op
+	}
+	return err
+}
+
+func (c asyncOperationClient) Get(ctx context.Context, id string) (*operation.AsyncOperation, error) {
+	resp, err := c.client.GetAsyncOperation(ctx, &cloudservice.GetAsyncOperationRequest{
+		AsyncOperationId: id,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return resp.GetAsyncOperation(), nil
+}
+
+func (c asyncOperationClient) Wait(ctx context.Context, id string) (*operation.AsyncOperation, error) {
+	return waitAsyncOperation(ctx, c.client, id)
+}
+
+func waitAsyncOperation(ctx context.Context, client cloudservice.CloudServiceClient, id string) (*operation.AsyncOperation, error) {
+	var pollInterval time.Duration // use the interval suggested by the server
+	op, err := asyncop.Wait(ctx, client, id, pollInterval)
+	if err == nil || op == nil || ctx.Err() != nil {
+		return op, toAPIError(err)
+	}
+	return op, &AsyncOperationError{Operation: op}
+}
@@ -0,0 +1,21 @@
+package cloudclient
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	auditlog "go.temporal.io/cloud-sdk/api/auditlog/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/auditlogs"
+)
+
+// TailAuditLogs returns an iterator over the audit log records emitted since the given time, polling for new log
+// records until ctx is done or the iteration stops. Every log record is delivered once, and the log records of a poll
+// are delivered in emit time order: a late log record, picked up by a later poll, comes after the ones already
+// delivered even if it was emitted before them.
+// A failed poll yields an error with a nil log record, and the tail carries on unless the iteration stops.
+//
+// Use auditlogs.Tail to tune the polling or to persist a checkpoint to resume from.
+func (c *Client) TailAuditLogs(ctx context.Context, since time.Time) iter.Seq2[*auditlog.LogRecord, error] {
+	return auditlogs.Tail(ctx, c.cloudServiceClient, auditlogs.TailOptions{Since: since})
+}
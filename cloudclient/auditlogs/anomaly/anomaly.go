@@ -0,0 +1,165 @@
+// Package anomaly runs rules over the audit log records of a Temporal Cloud account, to flag suspicious
+// administrative activity as it happens rather than after an incident.
+//
+// WARNING: The package is currently experimental.
+//
+// A detector feeds every log record to its rules, in order, and sends the alerts they raise to a notifier.
+// Rules keep their own state, such as the recent deletions, and are evaluated one log record at a time,
+// so they do not need to be safe for concurrent use. Time based rules use the emit time of the log records,
+// so that replaying the audit logs raises the same alerts.
+//
+// A detector is typically fed by a tail:
+//
+//	detector := anomaly.NewDetector(anomaly.Options{Notifier: &notify.WebhookNotifier{URL: url}})
+//	err := detector.Run(ctx, auditlogs.Tail(ctx, client, auditlogs.TailOptions{Checkpoints: store}))
+package anomaly
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"maps"
+	"sync"
+	"time"
+
+	auditlogv1 "go.temporal.io/cloud-sdk/api/auditlog/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/notify"
+)
+
+type (
+	// Rule flags suspicious patterns in log records.
+	Rule interface {
+		// Name identifies the rule in the alerts it raises.
+		Name() string
+		// Evaluate is called for every log record and returns the alerts it raises, if any.
+		Evaluate(r *auditlogv1.LogRecord) []Alert
+	}
+
+	// Alert is raised by a rule.
+	Alert struct {
+		// The name of the rule that raised the alert, set by the detector.
+		Rule     string
+		Severity notify.Severity
+		// A one line summary.
+		Summary string
+		// Structured details, such as the principal and the source address.
+		Fields map[string]string
+		// The log records that raised the alert, the last one being the one that was evaluated.
+		Records []*auditlogv1.LogRecord
+	}
+
+	// Options to configure a detector.
+	Options struct {
+		// The rules to evaluate.
+		// If not provided, DefaultRules are used.
+		Rules []Rule
+
+		// Where to send the alerts.
+		// If not provided, the alerts are only returned by Detector.Process.
+		Notifier notify.Notifier
+
+		// Called when a log record cannot be read or an alert cannot be sent. Detector.Run carries on.
+		// If not provided, errors are ignored.
+		OnError func(error)
+	}
+
+	// Detector evaluates rules over log records.
+	Detector struct {
+		options Options
+		mu      sync.Mutex
+	}
+)
+
+// DefaultRules returns a new instance of every rule of this package, with their default configuration.
+func DefaultRules() []Rule {
+	return []Rule{
+		&BurstRule{},
+		&NewSourceRule{},
+		&OrphanedAPIKeyRule{},
+		&OffHoursRoleChangeRule{},
+	}
+}
+
+// NewDetector creates a detector.
+func NewDetector(options Options) *Detector {
+	if options.Rules == nil {
+		options.Rules = DefaultRules()
+	}
+	return &Detector{options: options}
+}
+
+// Process evaluates every rule over the log record, sends the alerts they raise to the notifier and returns them.
+// The error reports the alerts that could not be sent.
+func (d *Detector) Process(ctx context.Context, r *auditlogv1.LogRecord) ([]Alert, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var alerts []Alert
+	for _, rule := range d.options.Rules {
+		for _, alert := range rule.Evaluate(r) {
+			alert.Rule = rule.Name()
+			alerts = append(alerts, alert)
+		}
+	}
+	if d.options.Notifier == nil {
+		return alerts, nil
+	}
+	var errs []error
+	for _, alert := range alerts {
+		if err := d.options.Notifier.Notify(ctx, alert.Notification()); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send the %s alert: %w", alert.Rule, err))
+		}
+	}
+	if len(errs) > 0 {
+		return alerts, fmt.Errorf("failed to send %d alerts: %w", len(errs), errs[0])
+	}
+	return alerts, nil
+}
+
+// Run processes the log records of seq until it ends or ctx is done.
+func (d *Detector) Run(ctx context.Context, seq iter.Seq2[*auditlogv1.LogRecord, error]) error {
+	for r, err := range seq {
+		if err == nil {
+			_, err = d.Process(ctx, r)
+		}
+		if err != nil && d.options.OnError != nil {
+			d.options.OnError(err)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Notification returns the notification of the alert.
+func (a Alert) Notification() notify.Notification {
+	n := notify.Notification{
+		Source:   a.Rule,
+		Title:    a.Summary,
+		Severity: a.Severity,
+		Fields:   maps.Clone(a.Fields),
+	}
+	if len(a.Records) > 0 {
+		last := a.Records[len(a.Records)-1]
+		n.Time = last.GetEmitTime().AsTime()
+		if n.Fields == nil {
+			n.Fields = map[string]string{}
+		}
+		n.Fields["log_id"] = last.GetLogId()
+	}
+	for _, r := range a.Records {
+		n.Message += fmt.Sprintf("%s %s by %s %s (%s)\n",
+			r.GetEmitTime().AsTime().UTC().Format(time.RFC3339), r.GetOperation(),
+			r.GetPrincipal().GetType(), principal(r), r.GetStatus())
+	}
+	return n
+}
+
+// principal returns the name of the principal of a log record, or its id.
+func principal(r *auditlogv1.LogRecord) string {
+	if name := r.GetPrincipal().GetName(); name != "" {
+		return name
+	}
+	return r.GetPrincipal().GetId()
+}
@@ -0,0 +1,185 @@
+package anomaly
+
+import (
+	"cmp"
+	"context"
+	"testing"
+	"time"
+
+	auditlogv1 "go.temporal.io/cloud-sdk/api/auditlog/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/notify"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// monday is a Monday at noon UTC.
+var monday = time.Date(2026, 10, 5, 12, 0, 0, 0, time.UTC)
+
+type record struct {
+	at        time.Duration
+	operation string
+	principal string
+	apiKeyID  string
+	address   string
+	details   map[string]any
+	// the status of the record, OK if not provided
+	status string
+}
+
+func (r record) build(t *testing.T) *auditlogv1.LogRecord {
+	t.Helper()
+	details, err := structpb.NewStruct(r.details)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &auditlogv1.LogRecord{
+		EmitTime:      timestamppb.New(monday.Add(r.at)),
+		Operation:     r.operation,
+		Status:        cmp.Or(r.status, "OK"),
+		LogId:         r.operation + "@" + r.at.String(),
+		Principal:     &auditlogv1.Principal{Type: "user", Id: r.principal, ApiKeyId: r.apiKeyID},
+		XForwardedFor: r.address,
+		RawDetails:    details,
+	}
+}
+
+func TestRules(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		rule    Rule
+		records []record
+		// the indexes of the records raising an alert
+		want []int
+	}{
+		{
+			name: "Burst",
+			rule: &BurstRule{Threshold: 3, Window: time.Minute},
+			records: []record{
+				{at: 0, operation: "DeleteNamespace"},
+				{at: 10 * time.Second, operation: "CreateNamespace"},
+				{at: 20 * time.Second, operation: "DeleteApiKey"},
+				// the first deletion left the window
+				{at: 65 * time.Second, operation: "DeleteApiKey"},
+				{at: 70 * time.Second, operation: "DeleteApiKey"},
+				// suppressed for a window after the alert
+				{at: 80 * time.Second, operation: "DeleteApiKey"},
+			},
+			want: []int{4},
+		},
+		{
+			name: "New Source",
+			rule: &NewSourceRule{Known: map[string][]string{"u2": {"198.51.100.1"}}},
+			records: []record{
+				// learnt
+				{operation: "DeleteApiKey", principal: "u1", address: "203.0.113.1"},
+				{operation: "DeleteApiKey", principal: "u1", address: "203.0.113.1, 10.0.0.1"},
+				// not a high privilege operation, not learnt
+				{operation: "GetNamespaces", principal: "u1", address: "203.0.113.2"},
+				{operation: "UpdateUser", principal: "u1", address: "203.0.113.2"},
+				{operation: "UpdateUser", principal: "u1", address: "203.0.113.3"},
+				{operation: "CreateApiKey", principal: "u2", address: "203.0.113.4"},
+			},
+			want: []int{3, 4, 5},
+		},
+		{
+			name: "Orphaned API Key",
+			rule: &OrphanedAPIKeyRule{},
+			records: []record{
+				{at: 0, operation: "GetNamespaces", principal: "sa1", apiKeyID: "k1"},
+				{at: time.Minute, operation: "DeleteServiceAccount", principal: "u1", details: map[string]any{"service_account_id": "sa1"}},
+				{at: 2 * time.Minute, operation: "GetNamespaces", principal: "sa1", apiKeyID: "k1"},
+				// flagged once
+				{at: 3 * time.Minute, operation: "GetNamespaces", principal: "sa1", apiKeyID: "k1"},
+				// the deletion was denied
+				{at: 4 * time.Minute, operation: "DeleteUser", principal: "u1", status: "PermissionDenied", details: map[string]any{"user_id": "u2"}},
+				{at: 5 * time.Minute, operation: "GetNamespaces", principal: "u2", apiKeyID: "k2"},
+			},
+			want: []int{2},
+		},
+		{
+			name: "Off Hours Role Change",
+			rule: &OffHoursRoleChangeRule{Roles: map[string]string{"u3": "admin"}},
+			records: []record{
+				{at: 0, operation: "UpdateUser", details: map[string]any{"spec": map[string]any{"access": map[string]any{"account_access": map[string]any{"role": "admin"}}}}},
+				{at: 10 * time.Hour, operation: "UpdateUser", details: map[string]any{"spec": map[string]any{"access": map[string]any{"account_access": map[string]any{"role": "ROLE_OWNER"}}}}},
+				{at: 10 * time.Hour, operation: "UpdateUser", details: map[string]any{"spec": map[string]any{"access": map[string]any{"account_access": map[string]any{"role": "developer"}}}}},
+				// saturday
+				{at: 5 * 24 * time.Hour, operation: "CreateUser", details: map[string]any{"roles": []any{map[string]any{"role": "owner"}}}},
+				// failed
+				{at: 10 * time.Hour, operation: "UpdateUser", status: "PermissionDenied", details: map[string]any{"user_id": "u1", "role": "owner"}},
+				{at: 10 * time.Hour, operation: "UpdateUser", details: map[string]any{"user_id": "u1", "role": "owner"}},
+				// the role did not change
+				{at: 11 * time.Hour, operation: "UpdateUser", details: map[string]any{"user_id": "u1", "role": "owner"}},
+				{at: 11 * time.Hour, operation: "UpdateUser", details: map[string]any{"user_id": "u3", "role": "admin"}},
+				// the role changed back and forth
+				{at: 12 * time.Hour, operation: "UpdateUser", details: map[string]any{"user_id": "u1", "role": "read"}},
+				{at: 12 * time.Hour, operation: "UpdateUser", details: map[string]any{"user_id": "u1", "role": "owner"}},
+			},
+			want: []int{1, 3, 5, 9},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []int
+			for i, r := range tc.records {
+				if alerts := tc.rule.Evaluate(r.build(t)); len(alerts) > 0 {
+					got = append(got, i)
+				}
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("Evaluate() alerted on %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("Evaluate() alerted on %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDetector(t *testing.T) {
+	var notifications []notify.Notification
+	detector := NewDetector(Options{
+		Notifier: notify.NotifierFunc(func(ctx context.Context, n notify.Notification) error {
+			notifications = append(notifications, n)
+			return nil
+		}),
+	})
+
+	seq := func(yield func(*auditlogv1.LogRecord, error) bool) {
+		for i := range 5 {
+			r := record{at: time.Duration(i) * time.Second, operation: "DeleteNamespace", principal: "u1"}
+			if !yield(r.build(t), nil) {
+				return
+			}
+		}
+	}
+	if err := detector.Run(context.Background(), seq); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(notifications) != 1 {
+		t.Fatalf("Run() notified %v, want a single burst alert", notifications)
+	}
+	n := notifications[0]
+	if n.Source != "burst" || n.Severity != notify.SeverityCritical || !n.Time.Equal(monday.Add(4*time.Second)) || n.Fields["principals"] != "u1" {
+		t.Errorf("Run() notified %+v", n)
+	}
+}
+
+func TestGrantedRole(t *testing.T) {
+	details, err := structpb.NewValue(map[string]any{
+		"a": map[string]any{"role": "developer"},
+		"b": map[string]any{"role": "admin"},
+		"c": []any{map[string]any{"role": "owner"}},
+		"d": map[string]any{"role": "read"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the strongest role, whatever the iteration order of the fields
+	for range 20 {
+		if got := grantedRole(details); got != "ROLE_OWNER" {
+			t.Fatalf("grantedRole() = %q, want ROLE_OWNER", got)
+		}
+	}
+}
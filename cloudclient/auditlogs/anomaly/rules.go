@@ -0,0 +1,324 @@
+package anomaly
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	auditlogv1 "go.temporal.io/cloud-sdk/api/auditlog/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/auditlogs"
+	"go.temporal.io/cloud-sdk/cloudclient/notify"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var (
+	// DefaultBurstQuery matches the destructive operations BurstRule counts by default.
+	DefaultBurstQuery = auditlogs.MustParseQuery(`operation == "DeleteNamespace" or operation == "DeleteApiKey"`)
+
+	// DefaultHighPrivilegeQuery matches the operations NewSourceRule watches by default, the mutations of
+	// the account, of identities and of their credentials.
+	DefaultHighPrivilegeQuery = auditlogs.MustParseQuery(
+		`operation =~ "(Create|Update|Delete|Set).*(Account|User|UserGroup|ServiceAccount|ApiKey|CustomRole|AuditLogSink).*"`)
+)
+
+type (
+	// BurstRule flags a burst of operations, such as deletions, within a short window.
+	BurstRule struct {
+		// The operations to count.
+		// If not provided, DefaultBurstQuery is used.
+		Match *auditlogs.Query
+
+		// The number of operations within Window that raises an alert.
+		// If not provided, 5 is used.
+		Threshold int
+
+		// The sliding window the operations are counted over.
+		// If not provided, 10 minutes is used.
+		Window time.Duration
+
+		recent        []*auditlogv1.LogRecord
+		suppressUntil time.Time
+	}
+
+	// NewSourceRule flags high privilege operations performed by a principal from an address it was not seen
+	// using before, according to the first address of the X-Forwarded-For header.
+	//
+	// The first address a principal is seen performing a high privilege operation from is learnt without
+	// raising an alert. The addresses of the other operations are ignored.
+	NewSourceRule struct {
+		// The high privilege operations.
+		// If not provided, DefaultHighPrivilegeQuery is used.
+		Match *auditlogs.Query
+
+		// The addresses already known, by principal id, such as learnt from the audit logs of the previous weeks.
+		Known map[string][]string
+
+		known map[string]map[string]bool
+	}
+
+	// OrphanedAPIKeyRule flags operations authenticated by an API key after the user or service account owning
+	// it was deleted. The deleted identity is read from the user_id or service_account_id of the raw details of
+	// the successful DeleteUser and DeleteServiceAccount log records.
+	OrphanedAPIKeyRule struct {
+		// the emit time of the deletion, by identity id
+		deleted map[string]time.Time
+		// the API keys already flagged
+		flagged map[string]bool
+	}
+
+	// OffHoursRoleChangeRule flags successful operations granting the ROLE_OWNER or ROLE_ADMIN account role
+	// outside business hours. A role is granted when the raw details of a log record hold a role field with either
+	// value, and the identity, given by the user_id, service_account_id or group_id of the raw details, did not
+	// already have that role.
+	//
+	// The role of an identity is learnt from the log records granting it, an identity whose role is not known is
+	// assumed not to have the granted role.
+	OffHoursRoleChangeRule struct {
+		// The time zone of the business hours.
+		// If not provided, UTC is used.
+		Location *time.Location
+
+		// The business hours, from StartHour inclusive to EndHour exclusive, Monday to Friday.
+		// If not provided, 9 to 18 is used.
+		StartHour, EndHour int
+
+		// The account roles already known, by identity id, such as listed from the account.
+		Roles map[string]string
+
+		// the account roles learnt, by identity id
+		roles map[string]string
+	}
+)
+
+func (b *BurstRule) Name() string {
+	return "burst"
+}
+
+func (b *BurstRule) Evaluate(r *auditlogv1.LogRecord) []Alert {
+	match, threshold, window := b.Match, b.Threshold, b.Window
+	if match == nil {
+		match = DefaultBurstQuery
+	}
+	if threshold <= 0 {
+		threshold = 5
+	}
+	if window <= 0 {
+		window = 10 * time.Minute
+	}
+	if !match.Match(r) {
+		return nil
+	}
+
+	now := r.GetEmitTime().AsTime()
+	b.recent = append(slices.DeleteFunc(b.recent, func(prev *auditlogv1.LogRecord) bool {
+		return !prev.GetEmitTime().AsTime().After(now.Add(-window))
+	}), r)
+	if len(b.recent) < threshold || now.Before(b.suppressUntil) {
+		return nil
+	}
+
+	// alert once per window, rather than on every operation of the burst
+	records := b.recent
+	b.recent, b.suppressUntil = nil, now.Add(window)
+	principals := map[string]bool{}
+	for _, r := range records {
+		principals[principal(r)] = true
+	}
+	return []Alert{{
+		Severity: notify.SeverityCritical,
+		Summary:  fmt.Sprintf("%d operations matching %s within %s", len(records), match, window),
+		Fields:   map[string]string{"principals": strings.Join(slices.Sorted(maps.Keys(principals)), ",")},
+		Records:  records,
+	}}
+}
+
+func (n *NewSourceRule) Name() string {
+	return "new_source"
+}
+
+func (n *NewSourceRule) Evaluate(r *auditlogv1.LogRecord) []Alert {
+	match := n.Match
+	if match == nil {
+		match = DefaultHighPrivilegeQuery
+	}
+	if n.known == nil {
+		n.known = map[string]map[string]bool{}
+		for id, addrs := range n.Known {
+			for _, addr := range addrs {
+				n.learn(id, addr)
+			}
+		}
+	}
+
+	id := r.GetPrincipal().GetId()
+	addr, _, _ := strings.Cut(r.GetXForwardedFor(), ",")
+	addr = strings.TrimSpace(addr)
+	if id == "" || addr == "" || !match.Match(r) {
+		return nil
+	}
+	// addresses are only learnt from high privilege operations, so that any other operation from a new address
+	// does not hide the high privilege operations that follow.
+	known, seen := n.known[id]
+	if known[addr] {
+		return nil
+	}
+	n.learn(id, addr)
+	if !seen {
+		return nil
+	}
+	return []Alert{{
+		Severity: notify.SeverityWarning,
+		Summary:  fmt.Sprintf("%s performed by %s from the new address %s", r.GetOperation(), principal(r), addr),
+		Fields:   map[string]string{"principal_id": id, "address": addr},
+		Records:  []*auditlogv1.LogRecord{r},
+	}}
+}
+
+func (n *NewSourceRule) learn(id, addr string) {
+	if n.known[id] == nil {
+		n.known[id] = map[string]bool{}
+	}
+	n.known[id][addr] = true
+}
+
+func (o *OrphanedAPIKeyRule) Name() string {
+	return "orphaned_api_key"
+}
+
+func (o *OrphanedAPIKeyRule) Evaluate(r *auditlogv1.LogRecord) []Alert {
+	if o.deleted == nil {
+		o.deleted, o.flagged = map[string]time.Time{}, map[string]bool{}
+	}
+
+	var detailsKey string
+	switch r.GetOperation() {
+	case "DeleteUser":
+		detailsKey = "user_id"
+	case "DeleteServiceAccount":
+		detailsKey = "service_account_id"
+	}
+	if detailsKey != "" {
+		if !auditlogs.Succeeded(r) {
+			return nil
+		}
+		if id := r.GetRawDetails().GetFields()[detailsKey].GetStringValue(); id != "" {
+			o.deleted[id] = r.GetEmitTime().AsTime()
+		}
+		return nil
+	}
+
+	keyID, owner := r.GetPrincipal().GetApiKeyId(), r.GetPrincipal().GetId()
+	deletedAt, ok := o.deleted[owner]
+	if keyID == "" || !ok || r.GetEmitTime().AsTime().Before(deletedAt) || o.flagged[keyID] {
+		return nil
+	}
+	o.flagged[keyID] = true
+	return []Alert{{
+		Severity: notify.SeverityCritical,
+		Summary:  fmt.Sprintf("API key %s used after its owner %s was deleted", keyID, owner),
+		Fields:   map[string]string{"api_key_id": keyID, "owner_id": owner},
+		Records:  []*auditlogv1.LogRecord{r},
+	}}
+}
+
+func (o *OffHoursRoleChangeRule) Name() string {
+	return "off_hours_role_change"
+}
+
+func (o *OffHoursRoleChangeRule) Evaluate(r *auditlogv1.LogRecord) []Alert {
+	location, start, end := o.Location, o.StartHour, o.EndHour
+	if location == nil {
+		location = time.UTC
+	}
+	if start == 0 && end == 0 {
+		start, end = 9, 18
+	}
+
+	if o.roles == nil {
+		o.roles = map[string]string{}
+		for id, role := range o.Roles {
+			o.roles[id] = normalizeRole(role)
+		}
+	}
+	if !auditlogs.Succeeded(r) {
+		return nil
+	}
+
+	role := grantedRole(structpb.NewStructValue(r.GetRawDetails()))
+	id := identityID(r.GetRawDetails())
+	previous, known := o.roles[id]
+	if id != "" && role != "" {
+		o.roles[id] = role
+	}
+	if (role != identityv1.AccountAccess_ROLE_OWNER.String() && role != identityv1.AccountAccess_ROLE_ADMIN.String()) ||
+		(known && previous == role) {
+		return nil
+	}
+	t := r.GetEmitTime().AsTime().In(location)
+	if t.Weekday() != time.Saturday && t.Weekday() != time.Sunday && t.Hour() >= start && t.Hour() < end {
+		return nil
+	}
+	return []Alert{{
+		Severity: notify.SeverityCritical,
+		Summary:  fmt.Sprintf("%s granted by %s outside business hours, at %s", role, principal(r), t.Format(time.RFC1123)),
+		Fields:   map[string]string{"role": role, "principal_id": r.GetPrincipal().GetId()},
+		Records:  []*auditlogv1.LogRecord{r},
+	}}
+}
+
+// grantedRole returns the strongest role held by the role fields of v, normalized by normalizeRole:
+// ROLE_OWNER, then ROLE_ADMIN, then the first other role in the order of the field names.
+func grantedRole(v *structpb.Value) string {
+	var granted string
+	grant := func(role string) {
+		if rolePriority(role) > rolePriority(granted) {
+			granted = role
+		}
+	}
+	switch k := v.GetKind().(type) {
+	case *structpb.Value_StructValue:
+		fields := k.StructValue.GetFields()
+		for _, key := range slices.Sorted(maps.Keys(fields)) {
+			if strings.EqualFold(key, "role") && fields[key].GetStringValue() != "" {
+				grant(normalizeRole(fields[key].GetStringValue()))
+			}
+			grant(grantedRole(fields[key]))
+		}
+	case *structpb.Value_ListValue:
+		for _, item := range k.ListValue.GetValues() {
+			grant(grantedRole(item))
+		}
+	}
+	return granted
+}
+
+// rolePriority orders the roles for grantedRole, no role has the lowest priority.
+func rolePriority(role string) int {
+	switch role {
+	case "":
+		return 0
+	case identityv1.AccountAccess_ROLE_OWNER.String():
+		return 3
+	case identityv1.AccountAccess_ROLE_ADMIN.String():
+		return 2
+	}
+	return 1
+}
+
+// normalizeRole returns the role in upper case with the ROLE_ prefix, such as ROLE_ADMIN for admin.
+func normalizeRole(role string) string {
+	return "ROLE_" + strings.TrimPrefix(strings.ToUpper(role), "ROLE_")
+}
+
+// identityID returns the id of the identity the raw details of a log record are about, if any.
+func identityID(details *structpb.Struct) string {
+	for _, key := range []string{"user_id", "service_account_id", "group_id"} {
+		if id := details.GetFields()[key].GetStringValue(); id != "" {
+			return id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,77 @@
+package auditlogs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type (
+	// Checkpoint records where a tail stopped, so that it can resume without gaps nor duplicates.
+	Checkpoint struct {
+		// The time the tail delivers the log records emitted from.
+		Since time.Time `json:"since"`
+		// The end of the last poll.
+		Watermark time.Time `json:"watermark"`
+		// The log records delivered within the overlap before the watermark, by log id, with their emit time.
+		LogIDs map[string]time.Time `json:"log_ids,omitempty"`
+	}
+
+	// CheckpointStore persists the checkpoint of a tail.
+	CheckpointStore interface {
+		// LoadCheckpoint returns the saved checkpoint, or nil if none was saved yet.
+		LoadCheckpoint(ctx context.Context) (*Checkpoint, error)
+		// SaveCheckpoint replaces the saved checkpoint.
+		SaveCheckpoint(ctx context.Context, checkpoint *Checkpoint) error
+	}
+
+	// FileCheckpointStore persists the checkpoint of a tail as a JSON file.
+	// The file is replaced atomically, so that a crash never leaves a partially written checkpoint.
+	FileCheckpointStore struct {
+		// The path of the checkpoint file.
+		Path string
+	}
+)
+
+func (s FileCheckpointStore) LoadCheckpoint(ctx context.Context) (*Checkpoint, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the checkpoint file: %w", err)
+	}
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse the checkpoint file %q: %w", s.Path, err)
+	}
+	return &checkpoint, nil
+}
+
+func (s FileCheckpointStore) SaveCheckpoint(ctx context.Context, checkpoint *Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the checkpoint: %w", err)
+	}
+	f, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create the checkpoint file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write the checkpoint file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write the checkpoint file: %w", err)
+	}
+	if err := os.Rename(f.Name(), s.Path); err != nil {
+		return fmt.Errorf("failed to replace the checkpoint file: %w", err)
+	}
+	return nil
+}
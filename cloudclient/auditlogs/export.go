@@ -0,0 +1,292 @@
+package auditlogs
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	auditlogv1 "go.temporal.io/cloud-sdk/api/auditlog/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// The export formats of log records.
+const (
+	// One protojson object per line.
+	FormatJSONL Format = "jsonl"
+	// CSV with a header line, the raw details are a JSON column.
+	FormatCSV Format = "csv"
+	// ArcSight Common Event Format, one event per line.
+	FormatCEF Format = "cef"
+	// IBM QRadar Log Event Extended Format 2.0, one event per line with tab separated attributes.
+	FormatLEEF Format = "leef"
+)
+
+const (
+	vendor  = "Temporal"
+	product = "Temporal Cloud"
+	// the version of the event format, rather than of the product
+	eventVersion = "1"
+)
+
+type (
+	// Format is an export format of log records.
+	Format string
+
+	// Writer writes log records in an export format.
+	Writer interface {
+		// Write writes a log record.
+		Write(r *auditlogv1.LogRecord) error
+		// Flush writes any buffered data to the underlying writer.
+		Flush() error
+	}
+
+	jsonlWriter struct {
+		w *bufio.Writer
+	}
+
+	csvWriter struct {
+		w           *csv.Writer
+		wroteHeader bool
+	}
+
+	// lineWriter writes one formatted line per log record.
+	lineWriter struct {
+		w      *bufio.Writer
+		format func(r *auditlogv1.LogRecord) string
+	}
+)
+
+// csvHeader is the header of the CSV export.
+var csvHeader = []string{
+	"emit_time", "log_id", "operation", "status", "principal_type", "principal_id", "principal_name",
+	"principal_api_key_id", "x_forwarded_for", "async_operation_id", "raw_details",
+}
+
+// NewWriter returns a writer of log records in the given format.
+func NewWriter(w io.Writer, format Format) (Writer, error) {
+	switch format {
+	case FormatJSONL:
+		return &jsonlWriter{w: bufio.NewWriter(w)}, nil
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatCEF:
+		return &lineWriter{w: bufio.NewWriter(w), format: FormatCEFLine}, nil
+	case FormatLEEF:
+		return &lineWriter{w: bufio.NewWriter(w), format: FormatLEEFLine}, nil
+	}
+	return nil, fmt.Errorf("unknown audit log export format %q", format)
+}
+
+// WriteAll writes the log records in the given format and flushes the writer.
+func WriteAll(w io.Writer, format Format, records []*auditlogv1.LogRecord) error {
+	writer, err := NewWriter(w, format)
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		if err := writer.Write(r); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+func (w *jsonlWriter) Write(r *auditlogv1.LogRecord) error {
+	data, err := protojson.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to marshal log record %q: %w", r.GetLogId(), err)
+	}
+	w.w.Write(data)
+	return w.w.WriteByte('\n')
+}
+
+func (w *jsonlWriter) Flush() error {
+	return w.w.Flush()
+}
+
+func (w *csvWriter) Write(r *auditlogv1.LogRecord) error {
+	if !w.wroteHeader {
+		if err := w.w.Write(csvHeader); err != nil {
+			return fmt.Errorf("failed to write the CSV header: %w", err)
+		}
+		w.wroteHeader = true
+	}
+	details := ""
+	if r.GetRawDetails() != nil {
+		data, err := protojson.Marshal(r.GetRawDetails())
+		if err != nil {
+			return fmt.Errorf("failed to marshal the raw details of log record %q: %w", r.GetLogId(), err)
+		}
+		details = string(data)
+	}
+	return w.w.Write([]string{
+		formatEmitTime(r),
+		r.GetLogId(),
+		r.GetOperation(),
+		r.GetStatus(),
+		r.GetPrincipal().GetType(),
+		r.GetPrincipal().GetId(),
+		r.GetPrincipal().GetName(),
+		r.GetPrincipal().GetApiKeyId(),
+		r.GetXForwardedFor(),
+		r.GetAsyncOperationId(),
+		details,
+	})
+}
+
+func (w *csvWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *lineWriter) Write(r *auditlogv1.LogRecord) error {
+	w.w.WriteString(w.format(r))
+	return w.w.WriteByte('\n')
+}
+
+func (w *lineWriter) Flush() error {
+	return w.w.Flush()
+}
+
+// FormatCEFLine formats a log record as a CEF event, without the trailing new line.
+// The event class id and name are the operation, and the severity is 3 for successful operations, 7 otherwise.
+func FormatCEFLine(r *auditlogv1.LogRecord) string {
+	header := []string{
+		"CEF:0", vendor, product, eventVersion, r.GetOperation(), r.GetOperation(), strconv.Itoa(severity(r)),
+	}
+	for i := 1; i < len(header); i++ {
+		header[i] = cefHeaderEscaper.Replace(header[i])
+	}
+	var ext []string
+	for _, a := range attributes(r, cefKeys) {
+		ext = append(ext, a.key+"="+cefValueEscaper.Replace(a.value))
+	}
+	return strings.Join(header, "|") + "|" + strings.Join(ext, " ")
+}
+
+// FormatLEEFLine formats a log record as a LEEF 2.0 event, without the trailing new line.
+// The event id is the operation, and the attributes are tab separated.
+func FormatLEEFLine(r *auditlogv1.LogRecord) string {
+	header := []string{"LEEF:2.0", vendor, product, eventVersion, r.GetOperation()}
+	for i := 1; i < len(header); i++ {
+		header[i] = leefValueEscaper.Replace(strings.ReplaceAll(header[i], "|", " "))
+	}
+	attrs := []string{"devTimeFormat=yyyy-MM-dd'T'HH:mm:ss.SSSXXX", "sev=" + strconv.Itoa(severity(r))}
+	for _, a := range attributes(r, leefKeys) {
+		attrs = append(attrs, a.key+"="+leefValueEscaper.Replace(a.value))
+	}
+	return strings.Join(header, "|") + "|x09|" + strings.Join(attrs, "\t")
+}
+
+type attribute struct {
+	key, value string
+}
+
+// attributeKeys are the keys of the attributes of a log record in an event format.
+type attributeKeys struct {
+	time, logID, status, principalType, principalID, principalName, apiKeyID, source, asyncOperationID string
+
+	// formats the emit time
+	formatTime func(time.Time) string
+	// the labels of the custom attributes, by key
+	labels map[string]string
+}
+
+var (
+	cefKeys = attributeKeys{
+		time:             "rt",
+		logID:            "externalId",
+		status:           "outcome",
+		principalType:    "cs1",
+		principalID:      "suid",
+		principalName:    "suser",
+		apiKeyID:         "cs2",
+		source:           "src",
+		asyncOperationID: "cs3",
+		formatTime: func(t time.Time) string {
+			return strconv.FormatInt(t.UnixMilli(), 10)
+		},
+		labels: map[string]string{
+			"cs1": "principalType",
+			"cs2": "apiKeyId",
+			"cs3": "asyncOperationId",
+		},
+	}
+	leefKeys = attributeKeys{
+		time:             "devTime",
+		logID:            "externalId",
+		status:           "outcome",
+		principalType:    "principalType",
+		principalID:      "usrId",
+		principalName:    "usrName",
+		apiKeyID:         "apiKeyId",
+		source:           "src",
+		asyncOperationID: "asyncOperationId",
+		formatTime: func(t time.Time) string {
+			return t.UTC().Format("2006-01-02T15:04:05.000Z07:00")
+		},
+	}
+
+	cefHeaderEscaper = strings.NewReplacer(`\`, `\\`, "|", `\|`, "\n", " ", "\r", " ")
+	cefValueEscaper  = strings.NewReplacer(`\`, `\\`, "=", `\=`, "\n", `\n`, "\r", `\r`)
+	leefValueEscaper = strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+)
+
+func attributes(r *auditlogv1.LogRecord, keys attributeKeys) []attribute {
+	var attrs []attribute
+	add := func(key, value string) {
+		if value == "" {
+			return
+		}
+		if label, ok := keys.labels[key]; ok {
+			attrs = append(attrs, attribute{key + "Label", label})
+		}
+		attrs = append(attrs, attribute{key, value})
+	}
+	if r.GetEmitTime() != nil {
+		add(keys.time, keys.formatTime(r.GetEmitTime().AsTime()))
+	}
+	add(keys.logID, r.GetLogId())
+	add(keys.status, r.GetStatus())
+	add(keys.principalID, r.GetPrincipal().GetId())
+	add(keys.principalName, r.GetPrincipal().GetName())
+	add(keys.source, firstAddress(r.GetXForwardedFor()))
+	add(keys.principalType, r.GetPrincipal().GetType())
+	add(keys.apiKeyID, r.GetPrincipal().GetApiKeyId())
+	add(keys.asyncOperationID, r.GetAsyncOperationId())
+	return attrs
+}
+
+// Succeeded reports whether the operation of the log record succeeded, according to its status.
+// A record without a status is considered successful.
+func Succeeded(r *auditlogv1.LogRecord) bool {
+	switch strings.ToLower(r.GetStatus()) {
+	case "", "ok", "success", "succeeded":
+		return true
+	}
+	return false
+}
+
+// severity returns 3 for the operations that succeeded, 7 otherwise.
+func severity(r *auditlogv1.LogRecord) int {
+	if Succeeded(r) {
+		return 3
+	}
+	return 7
+}
+
+func firstAddress(forwardedFor string) string {
+	addr, _, _ := strings.Cut(forwardedFor, ",")
+	return strings.TrimSpace(addr)
+}
+
+func formatEmitTime(r *auditlogv1.LogRecord) string {
+	if r.GetEmitTime() == nil {
+		return ""
+	}
+	return r.GetEmitTime().AsTime().UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,26 @@
+package auditlogs
+
+import (
+	"context"
+	"time"
+
+	auditlogv1 "go.temporal.io/cloud-sdk/api/auditlog/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+)
+
+// Poller runs the polls of a tail one at a time, for the tests to drive the clock between two polls.
+type Poller struct {
+	t *tail
+}
+
+func NewPoller(client cloudservicev1.CloudServiceClient, options TailOptions, now func() time.Time) *Poller {
+	return &Poller{t: &tail{client: client, options: options, now: now, delivered: map[string]time.Time{}}}
+}
+
+func (p *Poller) Resume(ctx context.Context) error {
+	return p.t.resume(ctx)
+}
+
+func (p *Poller) Poll(ctx context.Context, yield func(*auditlogv1.LogRecord, error) bool) bool {
+	return p.t.poll(ctx, yield)
+}
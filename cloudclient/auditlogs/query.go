@@ -0,0 +1,450 @@
+package auditlogs
+
+import (
+	"fmt"
+	"iter"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	auditlogv1 "go.temporal.io/cloud-sdk/api/auditlog/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type (
+	// Query is a filter on log records, parsed from an expression such as:
+	//
+	//	operation =~ "Delete.*" and principal.type == "serviceaccount"
+	//
+	// An expression compares fields with string literals, and combines the comparisons with and, or, not
+	// and parentheses. The operators are:
+	//
+	//	==  equal
+	//	!=  not equal
+	//	=~  matches the regular expression, anchored at both ends
+	//	!~  does not match the regular expression
+	//	in  the IP address is within the CIDR, only for x_forwarded_for
+	//
+	// String literals are either double quoted, with the escapes of Go string literals, or raw between back quotes,
+	// which suits regular expressions: `Delete\..*` is the same as "Delete\\..*".
+	//
+	// The fields are operation, status, log_id, async_operation_id, principal.type, principal.id,
+	// principal.name, principal.api_key_id and x_forwarded_for. A comparison of x_forwarded_for holds if it holds
+	// for any of the addresses of the header, and a != or !~ comparison if it holds for every address.
+	//
+	// The raw details of a log record are reached with a JSONPath like path starting with details, such as
+	// details.spec.regions[0]. Numbers and booleans are compared in their JSON representation, and a missing value
+	// is compared as the empty string.
+	Query struct {
+		source string
+		root   node
+	}
+
+	// node is a node of the syntax tree of a query.
+	node interface {
+		match(r *auditlogv1.LogRecord) bool
+	}
+
+	andNode struct{ left, right node }
+	orNode  struct{ left, right node }
+	notNode struct{ node node }
+
+	comparison struct {
+		field field
+		op    string
+		value string
+		re    *regexp.Regexp
+		cidr  netip.Prefix
+	}
+
+	// field returns the values of a field of a log record, a comparison holds if it holds for any of them.
+	field func(r *auditlogv1.LogRecord) []string
+
+	// pathElement is an element of a path into the raw details, either a key or an index.
+	pathElement struct {
+		key   string
+		index int
+	}
+)
+
+var fields = map[string]field{
+	"operation":          single((*auditlogv1.LogRecord).GetOperation),
+	"status":             single((*auditlogv1.LogRecord).GetStatus),
+	"log_id":             single((*auditlogv1.LogRecord).GetLogId),
+	"async_operation_id": single((*auditlogv1.LogRecord).GetAsyncOperationId),
+	"principal.type":     single(func(r *auditlogv1.LogRecord) string { return r.GetPrincipal().GetType() }),
+	"principal.id":       single(func(r *auditlogv1.LogRecord) string { return r.GetPrincipal().GetId() }),
+	"principal.name":     single(func(r *auditlogv1.LogRecord) string { return r.GetPrincipal().GetName() }),
+	"principal.api_key_id": single(func(r *auditlogv1.LogRecord) string {
+		return r.GetPrincipal().GetApiKeyId()
+	}),
+	"x_forwarded_for": forwardedFor,
+}
+
+// ParseQuery parses a query expression.
+func ParseQuery(expr string) (*Query, error) {
+	p := &parser{tokens: tokenize(expr)}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid query %q: %w", expr, err)
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("invalid query %q: unexpected %s at offset %d", expr, tok, tok.pos)
+	}
+	return &Query{source: expr, root: root}, nil
+}
+
+// MustParseQuery is like ParseQuery but panics if the expression is invalid.
+func MustParseQuery(expr string) *Query {
+	q, err := ParseQuery(expr)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// Match reports whether the log record matches the query.
+func (q *Query) Match(r *auditlogv1.LogRecord) bool {
+	return q.root.match(r)
+}
+
+// String returns the expression the query was parsed from.
+func (q *Query) String() string {
+	return q.source
+}
+
+// Filter returns an iterator over the log records of seq matching the query, errors are passed through.
+// It can be chained with Tail.
+func (q *Query) Filter(seq iter.Seq2[*auditlogv1.LogRecord, error]) iter.Seq2[*auditlogv1.LogRecord, error] {
+	return func(yield func(*auditlogv1.LogRecord, error) bool) {
+		for r, err := range seq {
+			if err == nil && !q.Match(r) {
+				continue
+			}
+			if !yield(r, err) {
+				return
+			}
+		}
+	}
+}
+
+func (n andNode) match(r *auditlogv1.LogRecord) bool { return n.left.match(r) && n.right.match(r) }
+func (n orNode) match(r *auditlogv1.LogRecord) bool  { return n.left.match(r) || n.right.match(r) }
+func (n notNode) match(r *auditlogv1.LogRecord) bool { return !n.node.match(r) }
+
+func (c *comparison) match(r *auditlogv1.LogRecord) bool {
+	values := c.field(r)
+	if len(values) == 0 {
+		values = []string{""}
+	}
+	// a negated comparison holds if it holds for every value, that is if the comparison it negates holds for none.
+	negated := c.op == "!=" || c.op == "!~"
+	for _, v := range values {
+		if c.matchValue(v) {
+			return !negated
+		}
+	}
+	return negated
+}
+
+// matchValue reports whether the value matches the comparison, ignoring the negation of != and !~.
+func (c *comparison) matchValue(v string) bool {
+	switch c.op {
+	case "==", "!=":
+		return v == c.value
+	case "=~", "!~":
+		return c.re.MatchString(v)
+	case "in":
+		addr, err := netip.ParseAddr(v)
+		return err == nil && c.cidr.Contains(addr.Unmap())
+	}
+	return false
+}
+
+func single(get func(r *auditlogv1.LogRecord) string) field {
+	return func(r *auditlogv1.LogRecord) []string {
+		return []string{get(r)}
+	}
+}
+
+func forwardedFor(r *auditlogv1.LogRecord) []string {
+	var addrs []string
+	for _, addr := range strings.Split(r.GetXForwardedFor(), ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// detailsField returns the field reaching the raw details at path, such as spec.regions[0].
+func detailsField(path string) (field, error) {
+	var elements []pathElement
+	for _, part := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(part, "[")
+		if key == "" && len(elements) == 0 {
+			return nil, fmt.Errorf("invalid details path %q", path)
+		}
+		if key != "" {
+			elements = append(elements, pathElement{key: key})
+		}
+		for rest != "" {
+			index, after, ok := strings.Cut(rest, "]")
+			i, err := strconv.Atoi(index)
+			if !ok || err != nil || i < 0 {
+				return nil, fmt.Errorf("invalid index in details path %q", path)
+			}
+			elements = append(elements, pathElement{index: i})
+			rest = strings.TrimPrefix(after, "[")
+			if after != "" && !strings.HasPrefix(after, "[") {
+				return nil, fmt.Errorf("invalid details path %q", path)
+			}
+		}
+	}
+	return func(r *auditlogv1.LogRecord) []string {
+		v := structpb.NewStructValue(r.GetRawDetails())
+		for _, e := range elements {
+			if e.key != "" {
+				v = v.GetStructValue().GetFields()[e.key]
+			} else if list := v.GetListValue().GetValues(); e.index < len(list) {
+				v = list[e.index]
+			} else {
+				v = nil
+			}
+			if v == nil {
+				return nil
+			}
+		}
+		return []string{formatValue(v)}
+	}, nil
+}
+
+func formatValue(v *structpb.Value) string {
+	switch k := v.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		return k.StringValue
+	case *structpb.Value_NullValue:
+		return ""
+	}
+	data, _ := v.MarshalJSON()
+	return string(data)
+}
+
+// The parser is a recursive descent parser of the grammar:
+//
+//	or         = and { "or" and }
+//	and        = unary { "and" unary }
+//	unary      = "not" unary | "(" or ")" | comparison
+//	comparison = identifier operator string
+//	string     = quoted string | raw string
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is(tokenIdentifier, "or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is(tokenIdentifier, "and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	switch tok := p.peek(); {
+	case tok.is(tokenIdentifier, "not"):
+		p.next()
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n}, nil
+	case tok.is(tokenPunct, "("):
+		p.next()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); !tok.is(tokenPunct, ")") {
+			return nil, fmt.Errorf("expected ) at offset %d, got %s", tok.pos, tok)
+		}
+		return n, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	name := p.next()
+	if name.kind != tokenIdentifier {
+		return nil, fmt.Errorf("expected a field at offset %d, got %s", name.pos, name)
+	}
+	c := &comparison{}
+	if path, ok := strings.CutPrefix(name.text, "details."); ok {
+		f, err := detailsField(path)
+		if err != nil {
+			return nil, err
+		}
+		c.field = f
+	} else if c.field = fields[name.text]; c.field == nil {
+		return nil, fmt.Errorf("unknown field %q at offset %d", name.text, name.pos)
+	}
+
+	op := p.next()
+	switch {
+	case op.kind == tokenOperator, op.is(tokenIdentifier, "in"):
+		c.op = op.text
+	default:
+		return nil, fmt.Errorf("expected an operator at offset %d, got %s", op.pos, op)
+	}
+	value := p.next()
+	if value.kind != tokenString {
+		return nil, fmt.Errorf("expected a string at offset %d, got %s", value.pos, value)
+	}
+	c.value = value.text
+
+	switch c.op {
+	case "=~", "!~":
+		re, err := regexp.Compile("^(?:" + c.value + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression at offset %d: %w", value.pos, err)
+		}
+		c.re = re
+	case "in":
+		if name.text != "x_forwarded_for" {
+			return nil, fmt.Errorf("in only applies to x_forwarded_for, at offset %d", op.pos)
+		}
+		cidr, err := netip.ParsePrefix(c.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR at offset %d: %w", value.pos, err)
+		}
+		c.cidr = cidr.Masked()
+	}
+	return c, nil
+}
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdentifier
+	tokenString
+	tokenOperator
+	tokenPunct
+	tokenInvalid
+)
+
+type (
+	tokenKind int
+
+	token struct {
+		kind tokenKind
+		text string
+		pos  int
+	}
+)
+
+func (t token) is(kind tokenKind, text string) bool {
+	return t.kind == kind && t.text == text
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// tokenize splits an expression into tokens, the last token is always tokenEOF.
+func tokenize(expr string) []token {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, token{kind: tokenPunct, text: string(c), pos: i})
+			i++
+		case c == '"':
+			// the string literal ends at the first unescaped quote
+			end := i + 1
+			for end < len(expr) && expr[end] != '"' {
+				if expr[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			text, err := strconv.Unquote(expr[i:min(end+1, len(expr))])
+			if err != nil {
+				return append(tokens, token{kind: tokenInvalid, text: expr[i:], pos: i}, token{kind: tokenEOF, pos: len(expr)})
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, pos: i})
+			i = end + 1
+		case c == '`':
+			// the raw string literal ends at the next back quote, without escapes
+			end := strings.IndexByte(expr[i+1:], '`')
+			if end < 0 {
+				return append(tokens, token{kind: tokenInvalid, text: expr[i:], pos: i}, token{kind: tokenEOF, pos: len(expr)})
+			}
+			tokens = append(tokens, token{kind: tokenString, text: expr[i+1 : i+1+end], pos: i})
+			i += end + 2
+		case strings.HasPrefix(expr[i:], "==") || strings.HasPrefix(expr[i:], "!=") ||
+			strings.HasPrefix(expr[i:], "=~") || strings.HasPrefix(expr[i:], "!~"):
+			tokens = append(tokens, token{kind: tokenOperator, text: expr[i : i+2], pos: i})
+			i += 2
+		case isIdentifierChar(rune(c)):
+			end := i
+			for end < len(expr) && isIdentifierChar(rune(expr[end])) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenIdentifier, text: expr[i:end], pos: i})
+			i = end
+		default:
+			return append(tokens, token{kind: tokenInvalid, text: expr[i:], pos: i}, token{kind: tokenEOF, pos: len(expr)})
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(expr)})
+}
+
+func isIdentifierChar(r rune) bool {
+	return r == '_' || r == '.' || r == '[' || r == ']' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
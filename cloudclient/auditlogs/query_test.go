@@ -0,0 +1,142 @@
+package auditlogs
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	auditlogv1 "go.temporal.io/cloud-sdk/api/auditlog/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var t0 = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func testRecord(t *testing.T) *auditlogv1.LogRecord {
+	t.Helper()
+	details, err := structpb.NewStruct(map[string]any{
+		"namespace": "prod.a1b2c",
+		"spec": map[string]any{
+			"regions":        []any{"aws-us-east-1", "aws-us-west-2"},
+			"retention_days": 30,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &auditlogv1.LogRecord{
+		EmitTime:      timestamppb.New(t0),
+		Operation:     "DeleteNamespace",
+		Status:        "OK",
+		LogId:         "log1",
+		Principal:     &auditlogv1.Principal{Type: "serviceaccount", Id: "sa1", Name: "ci", ApiKeyId: "key1"},
+		RawDetails:    details,
+		XForwardedFor: "203.0.113.7, 10.0.0.1",
+	}
+}
+
+func TestQuery(t *testing.T) {
+	r := testRecord(t)
+	for _, tc := range []struct {
+		query string
+		want  bool
+	}{
+		{`operation =~ "Delete.*" and principal.type == "serviceaccount"`, true},
+		{`operation =~ "Delete"`, false},
+		{`operation !~ "Create.*"`, true},
+		{`status != "OK"`, false},
+		{`principal.id == "sa1" and principal.api_key_id == "key1"`, true},
+		{`principal.type == "user" or principal.name == "ci"`, true},
+		{`not (principal.type == "user" or principal.name == "ci")`, false},
+		{`x_forwarded_for in "10.0.0.0/8"`, true},
+		{`x_forwarded_for in "192.168.0.0/16"`, false},
+		{`x_forwarded_for == "10.0.0.1"`, true},
+		{`x_forwarded_for != "203.0.113.7"`, false},
+		{`x_forwarded_for != "198.51.100.1"`, true},
+		{"x_forwarded_for !~ `10\\..*`", false},
+		{"x_forwarded_for !~ `192\\..*`", true},
+		{"operation =~ `Delete\\w+`", true},
+		{`operation =~ "Delete\\w+"`, true},
+		{`details.namespace == "prod.a1b2c"`, true},
+		{`details.spec.regions[1] == "aws-us-west-2"`, true},
+		{`details.spec.retention_days == "30"`, true},
+		{`details.spec.missing == ""`, true},
+		{`details.spec.regions[5] == ""`, true},
+		{`log_id == "log\"1"`, false},
+	} {
+		q, err := ParseQuery(tc.query)
+		if err != nil {
+			t.Errorf("ParseQuery(%s) error = %v", tc.query, err)
+			continue
+		}
+		if got := q.Match(r); got != tc.want {
+			t.Errorf("ParseQuery(%s).Match() = %v, want %v", tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	for _, query := range []string{
+		``,
+		`operation`,
+		`operation ==`,
+		`operation == "a" and`,
+		`(operation == "a"`,
+		`unknown == "a"`,
+		`operation in "10.0.0.0/8"`,
+		`x_forwarded_for in "not a cidr"`,
+		`operation =~ "("`,
+		`operation == "a" status == "b"`,
+		`operation == "unterminated`,
+		"operation == `unterminated",
+		`operation < "a"`,
+		`details.a[x] == "b"`,
+	} {
+		if _, err := ParseQuery(query); err == nil {
+			t.Errorf("ParseQuery(%s) error = nil, want an error", query)
+		}
+	}
+}
+
+func TestWriteAll(t *testing.T) {
+	r := testRecord(t)
+	for _, tc := range []struct {
+		format Format
+		want   []string
+	}{
+		{FormatJSONL, []string{`"operation":"DeleteNamespace"`, `"logId":"log1"`}},
+		{FormatCSV, []string{
+			"emit_time,log_id,operation,status,principal_type,principal_id,principal_name,principal_api_key_id,x_forwarded_for,async_operation_id,raw_details\n",
+			`2026-10-01T12:00:00Z,log1,DeleteNamespace,OK,serviceaccount,sa1,ci,key1,"203.0.113.7, 10.0.0.1",,"{`,
+		}},
+		{FormatCEF, []string{
+			"CEF:0|Temporal|Temporal Cloud|1|DeleteNamespace|DeleteNamespace|3|rt=1790856000000 externalId=log1 outcome=OK suid=sa1 suser=ci src=203.0.113.7 cs1Label=principalType cs1=serviceaccount cs2Label=apiKeyId cs2=key1\n",
+		}},
+		{FormatLEEF, []string{
+			"LEEF:2.0|Temporal|Temporal Cloud|1|DeleteNamespace|x09|devTimeFormat=yyyy-MM-dd'T'HH:mm:ss.SSSXXX\tsev=3\tdevTime=2026-10-01T12:00:00.000Z\texternalId=log1\t",
+		}},
+	} {
+		var out bytes.Buffer
+		if err := WriteAll(&out, tc.format, []*auditlogv1.LogRecord{r}); err != nil {
+			t.Errorf("WriteAll(%s) error = %v", tc.format, err)
+			continue
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("WriteAll(%s) = %q, want it to contain %q", tc.format, out.String(), want)
+			}
+		}
+	}
+
+	if _, err := NewWriter(&bytes.Buffer{}, "xml"); err == nil {
+		t.Error("NewWriter(xml) error = nil, want an unknown format error")
+	}
+}
+
+func TestFormatCEFLineEscaping(t *testing.T) {
+	line := FormatCEFLine(&auditlogv1.LogRecord{Operation: "a|b", Status: "failed=yes\nreally"})
+	if want := `CEF:0|Temporal|Temporal Cloud|1|a\|b|a\|b|7|outcome=failed\=yes\nreally`; line != want {
+		t.Errorf("FormatCEFLine() = %q, want %q", line, want)
+	}
+}
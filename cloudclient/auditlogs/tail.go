@@ -0,0 +1,197 @@
+// Package auditlogs tails the audit logs of a Temporal Cloud account, delivering every log record once,
+// in near real time, and resuming where it stopped across restarts.
+//
+// WARNING: The package is currently experimental.
+//
+// GetAuditLogs only supports paged time window queries, and log records can be made available some time after
+// they were emitted. A tail polls windows that overlap the previous one by Options.Overlap, to pick up the late
+// log records, and drops the log records it already delivered by their log id.
+package auditlogs
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+
+	auditlogv1 "go.temporal.io/cloud-sdk/api/auditlog/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	"go.temporal.io/cloud-sdk/internal/paging"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// DefaultPollInterval is the interval between two polls, used when none is provided.
+	DefaultPollInterval = 30 * time.Second
+
+	// DefaultOverlap is how far back a poll reaches before the end of the previous one, used when none is provided.
+	DefaultOverlap = 5 * time.Minute
+)
+
+type (
+	// TailOptions to configure a tail.
+	TailOptions struct {
+		// The time to deliver the log records emitted from.
+		// Ignored when the checkpoint store holds a checkpoint, the tail then resumes from the checkpoint.
+		// If not provided, the tail starts from the current time.
+		Since time.Time
+
+		// The interval between two polls.
+		// If not provided, DefaultPollInterval is used.
+		PollInterval time.Duration
+
+		// How far back a poll reaches before the end of the previous one, the longest a log record can take
+		// to be made available after it was emitted.
+		// If not provided, DefaultOverlap is used.
+		Overlap time.Duration
+
+		// Where to persist the checkpoint, after every poll and when the iteration stops.
+		// If not provided, the tail is not resumable.
+		Checkpoints CheckpointStore
+	}
+
+	tail struct {
+		client  cloudservicev1.CloudServiceClient
+		options TailOptions
+		now     func() time.Time
+
+		// the time the tail delivers the log records emitted from, no window reaches before it
+		since time.Time
+		// the end of the last poll
+		watermark time.Time
+		// the log records delivered since watermark - overlap, by log id, with their emit time
+		delivered map[string]time.Time
+	}
+)
+
+// Tail returns an iterator over the log records emitted since options.Since, polling for new log records until ctx
+// is done or the iteration stops. The log records of a poll are delivered in emit time order.
+//
+// A failed poll, or a failure to save the checkpoint, yields an error with a nil log record, and the tail carries on
+// with the next poll unless the iteration stops.
+//
+// The checkpoint is saved after every poll and when the iteration stops, so that a tail resumed from it delivers
+// neither gaps nor duplicates. A process that crashes between two checkpoints gets the log records delivered since
+// the last checkpoint again when it resumes.
+func Tail(ctx context.Context, client cloudservicev1.CloudServiceClient, options TailOptions) iter.Seq2[*auditlogv1.LogRecord, error] {
+	if options.PollInterval <= 0 {
+		options.PollInterval = DefaultPollInterval
+	}
+	if options.Overlap <= 0 {
+		options.Overlap = DefaultOverlap
+	}
+	return func(yield func(*auditlogv1.LogRecord, error) bool) {
+		t := &tail{
+			client:    client,
+			options:   options,
+			now:       time.Now,
+			delivered: map[string]time.Time{},
+		}
+		if err := t.resume(ctx); err != nil {
+			yield(nil, err)
+			return
+		}
+		for {
+			if !t.poll(ctx, yield) {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(options.PollInterval):
+			}
+		}
+	}
+}
+
+func (t *tail) resume(ctx context.Context) error {
+	t.since = t.options.Since
+	if t.since.IsZero() {
+		t.since = t.now()
+	}
+	t.watermark = t.since
+	if t.options.Checkpoints == nil {
+		return nil
+	}
+	checkpoint, err := t.options.Checkpoints.LoadCheckpoint(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load the audit log checkpoint: %w", err)
+	}
+	if checkpoint != nil {
+		t.since = checkpoint.Since
+		t.watermark = checkpoint.Watermark
+		for id, emitTime := range checkpoint.LogIDs {
+			t.delivered[id] = emitTime
+		}
+	}
+	return nil
+}
+
+// poll delivers the log records of the window ending now, it returns false if the iteration stopped.
+func (t *tail) poll(ctx context.Context, yield func(*auditlogv1.LogRecord, error) bool) bool {
+	end := t.now()
+	start := t.watermark.Add(-t.options.Overlap)
+	if start.Before(t.since) {
+		// a fresh tail does not deliver the log records emitted before Since
+		start = t.since
+	}
+	records, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*auditlogv1.LogRecord, string, error) {
+		resp, err := t.client.GetAuditLogs(ctx, &cloudservicev1.GetAuditLogsRequest{
+			StartTimeInclusive: timestamppb.New(start),
+			EndTimeExclusive:   timestamppb.New(end),
+			PageToken:          pageToken,
+		})
+		return resp.GetLogs(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+		return yield(nil, fmt.Errorf("failed to get the audit logs: %w", err))
+	}
+
+	records = slices.DeleteFunc(records, func(r *auditlogv1.LogRecord) bool {
+		_, ok := t.delivered[r.GetLogId()]
+		return ok
+	})
+	slices.SortStableFunc(records, func(a, b *auditlogv1.LogRecord) int {
+		return cmp.Or(a.GetEmitTime().AsTime().Compare(b.GetEmitTime().AsTime()), cmp.Compare(a.GetLogId(), b.GetLogId()))
+	})
+	for _, r := range records {
+		t.delivered[r.GetLogId()] = r.GetEmitTime().AsTime()
+		if !yield(r, nil) {
+			// the watermark is left as is, so that the rest of the window is delivered on resume
+			t.save(ctx)
+			return false
+		}
+	}
+
+	if end.After(t.watermark) {
+		t.watermark = end
+	}
+	for id, emitTime := range t.delivered {
+		if emitTime.Before(t.watermark.Add(-t.options.Overlap)) {
+			delete(t.delivered, id)
+		}
+	}
+	if err := t.save(ctx); err != nil {
+		return yield(nil, err)
+	}
+	return true
+}
+
+func (t *tail) save(ctx context.Context) error {
+	if t.options.Checkpoints == nil {
+		return nil
+	}
+	checkpoint := &Checkpoint{Since: t.since, Watermark: t.watermark, LogIDs: make(map[string]time.Time, len(t.delivered))}
+	for id, emitTime := range t.delivered {
+		checkpoint.LogIDs[id] = emitTime
+	}
+	if err := t.options.Checkpoints.SaveCheckpoint(ctx, checkpoint); err != nil {
+		return fmt.Errorf("failed to save the audit log checkpoint: %w", err)
+	}
+	return nil
+}
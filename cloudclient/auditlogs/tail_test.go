@@ -0,0 +1,160 @@
+package auditlogs_test
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	auditlogv1 "go.temporal.io/cloud-sdk/api/auditlog/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/auditlogs"
+	"go.temporal.io/cloud-sdk/cloudclient/cloudservicemock"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var t0 = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeCloudService returns the log records emitted in the requested window and available at the time of the poll.
+type fakeCloudService struct {
+	records []*auditlogv1.LogRecord
+	// the time each log record becomes available, by log id
+	available map[string]time.Time
+	now       time.Time
+	reqs      []*cloudservicev1.GetAuditLogsRequest
+}
+
+func (s *fakeCloudService) GetAuditLogs(ctx context.Context, req *cloudservicev1.GetAuditLogsRequest, opts ...grpc.CallOption) (*cloudservicev1.GetAuditLogsResponse, error) {
+	s.reqs = append(s.reqs, req)
+	var logs []*auditlogv1.LogRecord
+	for _, r := range s.records {
+		emitTime := r.GetEmitTime().AsTime()
+		if emitTime.Before(req.GetStartTimeInclusive().AsTime()) || !emitTime.Before(req.GetEndTimeExclusive().AsTime()) {
+			continue
+		}
+		if s.now.Before(s.available[r.GetLogId()]) {
+			continue
+		}
+		logs = append(logs, r)
+	}
+	// one log record per page
+	if len(logs) == 0 {
+		return &cloudservicev1.GetAuditLogsResponse{}, nil
+	}
+	i := 0
+	if req.GetPageToken() != "" {
+		i = int(req.GetPageToken()[0] - '0')
+	}
+	resp := &cloudservicev1.GetAuditLogsResponse{Logs: logs[i : i+1]}
+	if i+1 < len(logs) {
+		resp.NextPageToken = string(rune('0' + i + 1))
+	}
+	return resp, nil
+}
+
+func (s *fakeCloudService) emit(id string, emitTime, available time.Time) {
+	s.records = append(s.records, &auditlogv1.LogRecord{LogId: id, EmitTime: timestamppb.New(emitTime)})
+	s.available[id] = available
+}
+
+// collect runs a tail for the given number of polls, advancing the clock by a minute between two polls,
+// and returns the log ids delivered.
+func collect(t *testing.T, fake *fakeCloudService, options auditlogs.TailOptions, polls int, stopAfter int) []string {
+	t.Helper()
+	tl := auditlogs.NewPoller(cloudservicemock.NewFakeCloudServiceClient(t, fake), options, func() time.Time { return fake.now })
+	if err := tl.Resume(context.Background()); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	var ids []string
+	yield := func(r *auditlogv1.LogRecord, err error) bool {
+		if err != nil {
+			t.Fatalf("Poll() error = %v", err)
+		}
+		ids = append(ids, r.GetLogId())
+		return len(ids) != stopAfter
+	}
+	for range polls {
+		if !tl.Poll(context.Background(), yield) {
+			break
+		}
+		fake.now = fake.now.Add(time.Minute)
+	}
+	return ids
+}
+
+func TestTail(t *testing.T) {
+	fake := &fakeCloudService{available: map[string]time.Time{}, now: t0}
+	fake.emit("before", t0.Add(-time.Hour), t0)
+	fake.emit("a", t0.Add(10*time.Second), t0.Add(30*time.Second))
+	// emitted before a, but made available two minutes later
+	fake.emit("late", t0.Add(5*time.Second), t0.Add(2*time.Minute+30*time.Second))
+	fake.emit("b", t0.Add(70*time.Second), t0.Add(80*time.Second))
+	fake.emit("c", t0.Add(75*time.Second), t0.Add(80*time.Second))
+	fake.emit("d", t0.Add(3*time.Minute+10*time.Second), t0.Add(3*time.Minute+20*time.Second))
+
+	store := auditlogs.FileCheckpointStore{Path: filepath.Join(t.TempDir(), "checkpoint.json")}
+	options := auditlogs.TailOptions{Since: t0, Overlap: 5 * time.Minute, Checkpoints: store}
+
+	// stop right after b was delivered, before c
+	got := collect(t, fake, options, 3, 2)
+	if want := []string{"a", "b"}; !slices.Equal(got, want) {
+		t.Errorf("Tail() delivered %v, want %v", got, want)
+	}
+
+	// resume from the checkpoint, the rest of the window is delivered, then the late log record
+	got = collect(t, fake, options, 3, -1)
+	if want := []string{"c", "late", "d"}; !slices.Equal(got, want) {
+		t.Errorf("resumed Tail() delivered %v, want %v", got, want)
+	}
+
+	checkpoint, err := store.LoadCheckpoint(context.Background())
+	if err != nil {
+		t.Fatalf("LoadCheckpoint() error = %v", err)
+	}
+	if !checkpoint.Watermark.Equal(fake.now.Add(-time.Minute)) || len(checkpoint.LogIDs) != 5 {
+		t.Errorf("LoadCheckpoint() = %+v", checkpoint)
+	}
+}
+
+func TestTailSince(t *testing.T) {
+	fake := &fakeCloudService{available: map[string]time.Time{}, now: t0}
+	fake.emit("early", t0.Add(-time.Minute), t0)
+	fake.emit("a", t0.Add(10*time.Second), t0.Add(30*time.Second))
+
+	got := collect(t, fake, auditlogs.TailOptions{Since: t0, Overlap: 5 * time.Minute}, 8, -1)
+	if want := []string{"a"}; !slices.Equal(got, want) {
+		t.Errorf("Tail() delivered %v, want %v", got, want)
+	}
+	for _, req := range fake.reqs {
+		if start := req.GetStartTimeInclusive().AsTime(); start.Before(t0) {
+			t.Errorf("GetAuditLogs() start = %v, want no earlier than Since", start)
+		}
+	}
+	// once past Since, the windows overlap the previous one
+	last := fake.reqs[len(fake.reqs)-1]
+	if start, end := last.GetStartTimeInclusive().AsTime(), last.GetEndTimeExclusive().AsTime(); !start.Equal(end.Add(-6 * time.Minute)) {
+		t.Errorf("GetAuditLogs() start = %v, end = %v, want an overlap of 5 minutes", start, end)
+	}
+}
+
+func TestTailIterator(t *testing.T) {
+	fake := &fakeCloudService{available: map[string]time.Time{}, now: time.Now()}
+	fake.emit("a", fake.now.Add(-time.Second), fake.now.Add(-time.Second))
+	fake.emit("b", fake.now.Add(-2*time.Second), fake.now.Add(-time.Second))
+
+	var ids []string
+	for r, err := range auditlogs.Tail(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), auditlogs.TailOptions{Since: fake.now.Add(-time.Minute)}) {
+		if err != nil {
+			t.Fatalf("Tail() error = %v", err)
+		}
+		ids = append(ids, r.GetLogId())
+		if len(ids) == 2 {
+			break
+		}
+	}
+	if want := []string{"b", "a"}; !slices.Equal(ids, want) {
+		t.Errorf("Tail() delivered %v, want %v", ids, want)
+	}
+}
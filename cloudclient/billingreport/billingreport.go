@@ -0,0 +1,200 @@
+// Package billingreport generates the billing reports of a Temporal Cloud account, downloads them and parses their
+// CSV files into line items, in a single call.
+//
+// WARNING: The package is currently experimental.
+//
+// A billing report is created with CreateBillingReport, then polled with GetBillingReport until it is generated.
+// Its files are then downloaded from URLs that expire, by default 5 minutes after the report was generated,
+// so the downloads should start right after the generation, as Fetch and Stream do.
+package billingreport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	billingv1 "go.temporal.io/cloud-sdk/api/billing/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+)
+
+const (
+	// DefaultPollInterval is the interval between two polls of a billing report being generated,
+	// used when none is provided.
+	DefaultPollInterval = 5 * time.Second
+)
+
+var (
+	// ErrDownloadExpired is returned when the URL of a download expired before it was downloaded.
+	ErrDownloadExpired = errors.New("billing report download URL expired")
+
+	// ErrSizeMismatch is returned when a downloaded file does not have the size announced by the billing report.
+	ErrSizeMismatch = errors.New("billing report download size mismatch")
+)
+
+type (
+	// Options configures how billing reports are generated and downloaded.
+	Options struct {
+		// The interval between two polls of a billing report being generated.
+		// If not provided, DefaultPollInterval is used.
+		PollInterval time.Duration
+
+		// The HTTP client to download the billing report files with.
+		// If not provided, http.DefaultClient is used.
+		HTTPClient *http.Client
+	}
+
+	// Report is a generated billing report and its line items.
+	Report struct {
+		// The billing report, as returned by GetBillingReport.
+		BillingReport *billingv1.BillingReport
+		// The line items of every CSV file of the report, in file order.
+		Items []LineItem
+	}
+)
+
+// Fetch generates a billing report for spec, downloads its CSV files and returns their line items.
+// Use Stream for reports too large to be held in memory.
+func Fetch(ctx context.Context, client cloudservicev1.CloudServiceClient, spec *billingv1.BillingReportSpec, options Options) (*Report, error) {
+	report := &Report{}
+	var err error
+	report.BillingReport, err = Stream(ctx, client, spec, options, func(item LineItem) error {
+		report.Items = append(report.Items, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// Stream generates a billing report for spec, downloads its CSV files and calls fn for every line item,
+// as it is read. Stream stops at the first error returned by fn and returns it.
+func Stream(
+	ctx context.Context,
+	client cloudservicev1.CloudServiceClient,
+	spec *billingv1.BillingReportSpec,
+	options Options,
+	fn func(LineItem) error,
+) (*billingv1.BillingReport, error) {
+	report, err := Generate(ctx, client, spec, options)
+	if err != nil {
+		return nil, err
+	}
+	if err := Download(ctx, report, options, fn); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// Generate creates a billing report for spec and polls it until it is generated.
+// An error is returned if the generation failed.
+func Generate(ctx context.Context, client cloudservicev1.CloudServiceClient, spec *billingv1.BillingReportSpec, options Options) (*billingv1.BillingReport, error) {
+	resp, err := client.CreateBillingReport(ctx, &cloudservicev1.CreateBillingReportRequest{
+		Spec: spec,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the billing report: %w", err)
+	}
+	return Wait(ctx, client, resp.GetBillingReportId(), options)
+}
+
+// Wait polls the billing report with the given id until it is generated.
+// An error is returned if the generation failed.
+func Wait(ctx context.Context, client cloudservicev1.CloudServiceClient, id string, options Options) (*billingv1.BillingReport, error) {
+	interval := options.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	for {
+		resp, err := client.GetBillingReport(ctx, &cloudservicev1.GetBillingReportRequest{
+			BillingReportId: id,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get billing report %q: %w", id, err)
+		}
+		report := resp.GetBillingReport()
+		switch report.GetState() {
+		case billingv1.BillingReport_BILLING_REPORT_STATE_GENERATED:
+			return report, nil
+		case billingv1.BillingReport_BILLING_REPORT_STATE_FAILED:
+			return report, fmt.Errorf("billing report %q failed to generate", id)
+		}
+
+		select {
+		case <-ctx.Done():
+			return report, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Download downloads the CSV files of a generated billing report and calls fn for every line item, as it is read.
+// The files in other formats are skipped. Download stops at the first error returned by fn and returns it.
+func Download(ctx context.Context, report *billingv1.BillingReport, options Options, fn func(LineItem) error) error {
+	httpClient := options.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	for i, d := range report.GetDownloadInfo() {
+		if d.GetFileFormat() != billingv1.BillingReport_Download_FILE_FORMAT_CSV {
+			continue
+		}
+		if err := download(ctx, httpClient, d, fn); err != nil {
+			return fmt.Errorf("failed to download file %d of billing report %q: %w", i, report.GetId(), err)
+		}
+	}
+	return nil
+}
+
+func download(ctx context.Context, httpClient *http.Client, d *billingv1.BillingReport_Download, fn func(LineItem) error) error {
+	if expiration := d.GetUrlExpirationTime(); expiration != nil && !time.Now().Before(expiration.AsTime()) {
+		return fmt.Errorf("%w at %s", ErrDownloadExpired, expiration.AsTime().Format(time.RFC3339))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.GetUrl(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create the download request: %w", err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send the download request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected download response status: %s", resp.Status)
+	}
+
+	size := d.GetFileSizeBytes()
+	if size > 0 && resp.ContentLength >= 0 && resp.ContentLength != size {
+		return fmt.Errorf("%w: announced %d bytes, content length is %d bytes", ErrSizeMismatch, size, resp.ContentLength)
+	}
+	body := &countingReader{r: resp.Body}
+	if err := Parse(body, fn); err != nil {
+		return err
+	}
+	if size > 0 {
+		// read what the CSV reader left, such as a trailing new line, before comparing the sizes
+		if _, err := io.Copy(io.Discard, body); err != nil {
+			return fmt.Errorf("failed to read the download: %w", err)
+		}
+		if body.n != size {
+			return fmt.Errorf("%w: announced %d bytes, downloaded %d bytes", ErrSizeMismatch, size, body.n)
+		}
+	}
+	return nil
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,109 @@
+package billingreport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	billingv1 "go.temporal.io/cloud-sdk/api/billing/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/cloudservicemock"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const testCSV = "Start Time,End Time,Namespace,Product,Quantity,Unit,Unit Price,Amount,Currency,Region\n" +
+	"2026-10-01T00:00:00Z,2026-10-02T00:00:00Z,prod.a1b2c,Actions,1000000,actions,0.000025,25,USD,aws-us-east-1\n" +
+	"2026-10-01,2026-10-02,,Support,,,,100.5,USD,\n"
+
+type fakeCloudService struct {
+	url     string
+	size    int64
+	expired bool
+	failed  bool
+	polls   int
+	created *cloudservicev1.CreateBillingReportRequest
+}
+
+func (s *fakeCloudService) CreateBillingReport(ctx context.Context, req *cloudservicev1.CreateBillingReportRequest, opts ...grpc.CallOption) (*cloudservicev1.CreateBillingReportResponse, error) {
+	s.created = req
+	return &cloudservicev1.CreateBillingReportResponse{BillingReportId: "br1"}, nil
+}
+
+func (s *fakeCloudService) GetBillingReport(ctx context.Context, req *cloudservicev1.GetBillingReportRequest, opts ...grpc.CallOption) (*cloudservicev1.GetBillingReportResponse, error) {
+	s.polls++
+	report := &billingv1.BillingReport{Id: req.GetBillingReportId(), State: billingv1.BillingReport_BILLING_REPORT_STATE_IN_PROGRESS}
+	if s.polls > 1 {
+		report.State = billingv1.BillingReport_BILLING_REPORT_STATE_GENERATED
+		if s.failed {
+			report.State = billingv1.BillingReport_BILLING_REPORT_STATE_FAILED
+		}
+		expiration := time.Now().Add(time.Minute)
+		if s.expired {
+			expiration = time.Now().Add(-time.Minute)
+		}
+		report.DownloadInfo = []*billingv1.BillingReport_Download{{
+			Url:               s.url,
+			UrlExpirationTime: timestamppb.New(expiration),
+			FileFormat:        billingv1.BillingReport_Download_FILE_FORMAT_CSV,
+			FileSizeBytes:     s.size,
+		}}
+	}
+	return &cloudservicev1.GetBillingReportResponse{BillingReport: report}, nil
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testCSV))
+	}))
+	t.Cleanup(server.Close)
+	options := Options{PollInterval: time.Millisecond, HTTPClient: server.Client()}
+	spec := &billingv1.BillingReportSpec{Description: "october"}
+
+	t.Run("Generated", func(t *testing.T) {
+		fake := &fakeCloudService{url: server.URL, size: int64(len(testCSV))}
+		report, err := Fetch(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), spec, options)
+		if err != nil {
+			t.Fatalf("Fetch() error = %v", err)
+		}
+		if fake.created.GetSpec().GetDescription() != "october" || fake.polls != 2 {
+			t.Errorf("Fetch() created %v after %d polls", fake.created, fake.polls)
+		}
+		if report.BillingReport.GetId() != "br1" || len(report.Items) != 2 {
+			t.Fatalf("Fetch() = %+v", report)
+		}
+		item := report.Items[0]
+		if !item.StartTime.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) ||
+			item.Namespace != "prod.a1b2c" || item.Quantity != 1e6 || item.UnitPrice != 0.000025 ||
+			item.Amount != 25 || item.Currency != "USD" || item.Extra["Region"] != "aws-us-east-1" {
+			t.Errorf("Fetch() item = %+v", item)
+		}
+		if item := report.Items[1]; item.Product != "Support" || item.Amount != 100.5 || !item.EndTime.Equal(time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("Fetch() item = %+v", item)
+		}
+	})
+
+	t.Run("Size Mismatch", func(t *testing.T) {
+		fake := &fakeCloudService{url: server.URL, size: 10}
+		if _, err := Fetch(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), spec, options); !errors.Is(err, ErrSizeMismatch) {
+			t.Errorf("Fetch() error = %v, want %v", err, ErrSizeMismatch)
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		fake := &fakeCloudService{url: server.URL, expired: true}
+		if _, err := Fetch(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), spec, options); !errors.Is(err, ErrDownloadExpired) {
+			t.Errorf("Fetch() error = %v, want %v", err, ErrDownloadExpired)
+		}
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		fake := &fakeCloudService{url: server.URL, failed: true}
+		if _, err := Fetch(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), spec, options); err == nil {
+			t.Error("Fetch() error = nil, want a failed generation error")
+		}
+	})
+}
@@ -0,0 +1,150 @@
+package billingreport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LineItem is a row of a billing report CSV file.
+//
+// The columns are matched by their header, case insensitively and ignoring spaces, dashes and underscores.
+// The columns this package does not know about are kept in Extra, so that new columns are not lost.
+type LineItem struct {
+	// The start of the period the line item is billed for, from the "start_time" column.
+	StartTime time.Time
+	// The end of the period the line item is billed for, from the "end_time" column.
+	EndTime time.Time
+	// The namespace, empty for account level line items, from the "namespace" column.
+	Namespace string
+	// The product billed, such as actions or storage, from the "product" column.
+	Product string
+	// A description of the line item, from the "description" column.
+	Description string
+	// The quantity billed, in Unit, from the "quantity" column.
+	Quantity float64
+	// The unit of Quantity, from the "unit" column.
+	Unit string
+	// The price of one Unit, from the "unit_price" column.
+	UnitPrice float64
+	// The amount billed, in Currency, from the "amount" column.
+	Amount float64
+	// The currency of UnitPrice and Amount, from the "currency" column.
+	Currency string
+	// The columns this package does not know about, by header.
+	Extra map[string]string
+}
+
+// column sets the field of a line item read from a column.
+type column func(item *LineItem, value string) error
+
+var columns = map[string]column{
+	"starttime":   timeColumn(func(item *LineItem) *time.Time { return &item.StartTime }),
+	"endtime":     timeColumn(func(item *LineItem) *time.Time { return &item.EndTime }),
+	"namespace":   stringColumn(func(item *LineItem) *string { return &item.Namespace }),
+	"product":     stringColumn(func(item *LineItem) *string { return &item.Product }),
+	"description": stringColumn(func(item *LineItem) *string { return &item.Description }),
+	"quantity":    floatColumn(func(item *LineItem) *float64 { return &item.Quantity }),
+	"unit":        stringColumn(func(item *LineItem) *string { return &item.Unit }),
+	"unitprice":   floatColumn(func(item *LineItem) *float64 { return &item.UnitPrice }),
+	"amount":      floatColumn(func(item *LineItem) *float64 { return &item.Amount }),
+	"currency":    stringColumn(func(item *LineItem) *string { return &item.Currency }),
+}
+
+// timeLayouts are the layouts the time columns are parsed with, in order.
+var timeLayouts = []string{time.RFC3339Nano, time.DateTime, time.DateOnly}
+
+// Parse reads a billing report CSV file, with a header line, and calls fn for every line item, as it is read.
+// Parse stops at the first error returned by fn and returns it.
+func Parse(r io.Reader, fn func(LineItem) error) error {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read the CSV header: %w", err)
+	}
+	header = append([]string(nil), header...)
+	header[0] = strings.TrimPrefix(header[0], "\ufeff") // byte order mark
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read the CSV: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+
+		var item LineItem
+		for i, value := range record {
+			set, ok := columns[normalize(header[i])]
+			if !ok {
+				if item.Extra == nil {
+					item.Extra = map[string]string{}
+				}
+				item.Extra[header[i]] = value
+				continue
+			}
+			if err := set(&item, value); err != nil {
+				return fmt.Errorf("invalid %s on line %d: %w", header[i], line, err)
+			}
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+}
+
+func normalize(header string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(header)))
+}
+
+func stringColumn(field func(*LineItem) *string) column {
+	return func(item *LineItem, value string) error {
+		*field(item) = value
+		return nil
+	}
+}
+
+func floatColumn(field func(*LineItem) *float64) column {
+	return func(item *LineItem, value string) error {
+		if value == "" {
+			return nil
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		*field(item) = f
+		return nil
+	}
+}
+
+func timeColumn(field func(*LineItem) *time.Time) column {
+	return func(item *LineItem, value string) error {
+		if value == "" {
+			return nil
+		}
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				*field(item) = t.UTC()
+				return nil
+			}
+		}
+		return fmt.Errorf("unknown time format %q", value)
+	}
+}
@@ -0,0 +1,353 @@
+package cloudclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	cloudservice "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// The resources whose read operations can be cached.
+const (
+	CacheResourceAccount          CacheResource = "account"
+	CacheResourceNamespace        CacheResource = "namespace"
+	CacheResourceExportSink       CacheResource = "export_sink"
+	CacheResourceUser             CacheResource = "user"
+	CacheResourceUserGroup        CacheResource = "user_group"
+	CacheResourceServiceAccount   CacheResource = "service_account"
+	CacheResourceAPIKey           CacheResource = "api_key"
+	CacheResourceNexusEndpoint    CacheResource = "nexus_endpoint"
+	CacheResourceConnectivityRule CacheResource = "connectivity_rule"
+	CacheResourceCustomRole       CacheResource = "custom_role"
+	CacheResourceAuditLogSink     CacheResource = "audit_log_sink"
+	CacheResourceRegion           CacheResource = "region"
+
+	defaultCacheMaxEntries = 1024
+)
+
+type (
+	// CacheResource is a resource whose read operations can be cached.
+	CacheResource string
+
+	// CacheOptions to configure the read-through cache of the client.
+	//
+	// Responses of the get and list operations are cached, keyed by their request.
+	// The cached responses of a resource are invalidated when the same client performs a mutating operation
+	// on that resource: the responses about the mutated resource, as identified by the request, and every list.
+	// Mutations are asynchronous, reads following a mutation may return and cache the resource in a transient state.
+	// Mutations performed through other clients are only observed once the cached responses expire.
+	CacheOptions struct {
+		// The time to live of the cached responses, per resource.
+		// Resources without a TTL use DefaultTTL.
+		TTLs map[CacheResource]time.Duration
+
+		// The time to live of the cached responses of the resources without an entry in TTLs.
+		// If not provided, only the resources with an entry in TTLs are cached.
+		DefaultTTL time.Duration
+
+		// The maximum number of cached responses.
+		// If not provided, at most 1024 responses are cached.
+		MaxEntries int
+	}
+
+	// CacheStats reports the hits and misses of the read-through cache, per resource.
+	CacheStats map[CacheResource]CacheResourceStats
+
+	// CacheResourceStats reports the hits and misses of the read-through cache for a resource.
+	CacheResourceStats struct {
+		Hits   uint64
+		Misses uint64
+	}
+
+	// cachedMethod describes how a method interacts with the cache.
+	cachedMethod struct {
+		resource CacheResource
+		// the text name of the request field identifying the resource; empty for list operations.
+		idField protoreflect.Name
+		// whether the method mutates the resource, rather than reads it.
+		mutates bool
+		// other resources affected by the mutation, whose cached responses are all invalidated.
+		alsoInvalidates []CacheResource
+	}
+
+	cacheEntry struct {
+		resource  CacheResource
+		id        string
+		response  proto.Message
+		expiresAt time.Time
+	}
+
+	responseCache struct {
+		options CacheOptions
+		now     func() time.Time
+
+		mu      sync.Mutex
+		entries map[string]*cacheEntry
+		stats   CacheStats
+		// the number of invalidations per resource, to discard the responses of reads in flight during a mutation.
+		generations map[CacheResource]uint64
+	}
+)
+
+var cachedMethods = map[string]cachedMethod{
+	cloudservice.CloudService_GetAccount_FullMethodName:    {resource: CacheResourceAccount},
+	cloudservice.CloudService_UpdateAccount_FullMethodName: {resource: CacheResourceAccount, mutates: true},
+
+	cloudservice.CloudService_GetNamespace_FullMethodName:                {resource: CacheResourceNamespace, idField: "namespace"},
+	cloudservice.CloudService_GetNamespaces_FullMethodName:               {resource: CacheResourceNamespace},
+	cloudservice.CloudService_GetNamespaceCapacityInfo_FullMethodName:    {resource: CacheResourceNamespace, idField: "namespace"},
+	cloudservice.CloudService_CreateNamespace_FullMethodName:             {resource: CacheResourceNamespace, mutates: true},
+	cloudservice.CloudService_UpdateNamespace_FullMethodName:             {resource: CacheResourceNamespace, idField: "namespace", mutates: true},
+	cloudservice.CloudService_RenameCustomSearchAttribute_FullMethodName: {resource: CacheResourceNamespace, idField: "namespace", mutates: true},
+	cloudservice.CloudService_FailoverNamespaceRegion_FullMethodName:     {resource: CacheResourceNamespace, idField: "namespace", mutates: true},
+	cloudservice.CloudService_AddNamespaceRegion_FullMethodName:          {resource: CacheResourceNamespace, idField: "namespace", mutates: true},
+	cloudservice.CloudService_DeleteNamespaceRegion_FullMethodName:       {resource: CacheResourceNamespace, idField: "namespace", mutates: true},
+	cloudservice.CloudService_UpdateNamespaceTags_FullMethodName:         {resource: CacheResourceNamespace, idField: "namespace", mutates: true},
+	cloudservice.CloudService_DeleteNamespace_FullMethodName: {
+		resource: CacheResourceNamespace, idField: "namespace", mutates: true,
+		alsoInvalidates: []CacheResource{CacheResourceExportSink, CacheResourceUser, CacheResourceUserGroup, CacheResourceServiceAccount},
+	},
+
+	cloudservice.CloudService_GetNamespaceExportSink_FullMethodName:    {resource: CacheResourceExportSink, idField: "namespace"},
+	cloudservice.CloudService_GetNamespaceExportSinks_FullMethodName:   {resource: CacheResourceExportSink, idField: "namespace"},
+	cloudservice.CloudService_CreateNamespaceExportSink_FullMethodName: {resource: CacheResourceExportSink, idField: "namespace", mutates: true},
+	cloudservice.CloudService_UpdateNamespaceExportSink_FullMethodName: {resource: CacheResourceExportSink, idField: "namespace", mutates: true},
+	cloudservice.CloudService_DeleteNamespaceExportSink_FullMethodName: {resource: CacheResourceExportSink, idField: "namespace", mutates: true},
+
+	cloudservice.CloudService_GetUser_FullMethodName:                     {resource: CacheResourceUser, idField: "user_id"},
+	cloudservice.CloudService_GetUsers_FullMethodName:                    {resource: CacheResourceUser},
+	cloudservice.CloudService_GetUserNamespaceAssignments_FullMethodName: {resource: CacheResourceUser},
+	cloudservice.CloudService_CreateUser_FullMethodName:                  {resource: CacheResourceUser, mutates: true},
+	cloudservice.CloudService_UpdateUser_FullMethodName:                  {resource: CacheResourceUser, idField: "user_id", mutates: true},
+	cloudservice.CloudService_SetUserNamespaceAccess_FullMethodName:      {resource: CacheResourceUser, idField: "user_id", mutates: true},
+	cloudservice.CloudService_DeleteUser_FullMethodName: {
+		resource: CacheResourceUser, idField: "user_id", mutates: true,
+		alsoInvalidates: []CacheResource{CacheResourceUserGroup, CacheResourceAPIKey},
+	},
+
+	cloudservice.CloudService_GetUserGroup_FullMethodName:                     {resource: CacheResourceUserGroup, idField: "group_id"},
+	cloudservice.CloudService_GetUserGroups_FullMethodName:                    {resource: CacheResourceUserGroup},
+	cloudservice.CloudService_GetUserGroupMembers_FullMethodName:              {resource: CacheResourceUserGroup, idField: "group_id"},
+	cloudservice.CloudService_GetUserGroupNamespaceAssignments_FullMethodName: {resource: CacheResourceUserGroup},
+	cloudservice.CloudService_CreateUserGroup_FullMethodName:                  {resource: CacheResourceUserGroup, mutates: true},
+	cloudservice.CloudService_UpdateUserGroup_FullMethodName:                  {resource: CacheResourceUserGroup, idField: "group_id", mutates: true},
+	cloudservice.CloudService_DeleteUserGroup_FullMethodName:                  {resource: CacheResourceUserGroup, idField: "group_id", mutates: true},
+	cloudservice.CloudService_SetUserGroupNamespaceAccess_FullMethodName:      {resource: CacheResourceUserGroup, idField: "group_id", mutates: true},
+	cloudservice.CloudService_AddUserGroupMember_FullMethodName:               {resource: CacheResourceUserGroup, idField: "group_id", mutates: true},
+	cloudservice.CloudService_RemoveUserGroupMember_FullMethodName:            {resource: CacheResourceUserGroup, idField: "group_id", mutates: true},
+
+	cloudservice.CloudService_GetServiceAccount_FullMethodName:                     {resource: CacheResourceServiceAccount, idField: "service_account_id"},
+	cloudservice.CloudService_GetServiceAccounts_FullMethodName:                    {resource: CacheResourceServiceAccount},
+	cloudservice.CloudService_GetServiceAccountNamespaceAssignments_FullMethodName: {resource: CacheResourceServiceAccount},
+	cloudservice.CloudService_CreateServiceAccount_FullMethodName:                  {resource: CacheResourceServiceAccount, mutates: true},
+	cloudservice.CloudService_UpdateServiceAccount_FullMethodName:                  {resource: CacheResourceServiceAccount, idField: "service_account_id", mutates: true},
+	cloudservice.CloudService_SetServiceAccountNamespaceAccess_FullMethodName:      {resource: CacheResourceServiceAccount, idField: "service_account_id", mutates: true},
+	cloudservice.CloudService_DeleteServiceAccount_FullMethodName: {
+		resource: CacheResourceServiceAccount, idField: "service_account_id", mutates: true,
+		alsoInvalidates: []CacheResource{CacheResourceAPIKey},
+	},
+
+	cloudservice.CloudService_GetApiKey_FullMethodName:    {resource: CacheResourceAPIKey, idField: "key_id"},
+	cloudservice.CloudService_GetApiKeys_FullMethodName:   {resource: CacheResourceAPIKey},
+	cloudservice.CloudService_CreateApiKey_FullMethodName: {resource: CacheResourceAPIKey, mutates: true},
+	cloudservice.CloudService_UpdateApiKey_FullMethodName: {resource: CacheResourceAPIKey, idField: "key_id", mutates: true},
+	cloudservice.CloudService_DeleteApiKey_FullMethodName: {resource: CacheResourceAPIKey, idField: "key_id", mutates: true},
+
+	cloudservice.CloudService_GetNexusEndpoint_FullMethodName:    {resource: CacheResourceNexusEndpoint, idField: "endpoint_id"},
+	cloudservice.CloudService_GetNexusEndpoints_FullMethodName:   {resource: CacheResourceNexusEndpoint},
+	cloudservice.CloudService_CreateNexusEndpoint_FullMethodName: {resource: CacheResourceNexusEndpoint, mutates: true},
+	cloudservice.CloudService_UpdateNexusEndpoint_FullMethodName: {resource: CacheResourceNexusEndpoint, idField: "endpoint_id", mutates: true},
+	cloudservice.CloudService_DeleteNexusEndpoint_FullMethodName: {resource: CacheResourceNexusEndpoint, idField: "endpoint_id", mutates: true},
+
+	cloudservice.CloudService_GetConnectivityRule_FullMethodName:    {resource: CacheResourceConnectivityRule, idField: "connectivity_rule_id"},
+	cloudservice.CloudService_GetConnectivityRules_FullMethodName:   {resource: CacheResourceConnectivityRule},
+	cloudservice.CloudService_CreateConnectivityRule_FullMethodName: {resource: CacheResourceConnectivityRule, mutates: true},
+	cloudservice.CloudService_DeleteConnectivityRule_FullMethodName: {resource: CacheResourceConnectivityRule, idField: "connectivity_rule_id", mutates: true},
+
+	cloudservice.CloudService_GetCustomRole_FullMethodName:    {resource: CacheResourceCustomRole, idField: "role_id"},
+	cloudservice.CloudService_GetCustomRoles_FullMethodName:   {resource: CacheResourceCustomRole},
+	cloudservice.CloudService_CreateCustomRole_FullMethodName: {resource: CacheResourceCustomRole, mutates: true},
+	cloudservice.CloudService_UpdateCustomRole_FullMethodName: {resource: CacheResourceCustomRole, idField: "role_id", mutates: true},
+	cloudservice.CloudService_DeleteCustomRole_FullMethodName: {resource: CacheResourceCustomRole, idField: "role_id", mutates: true},
+
+	// the spec of the audit log sink mutations carries the sink name, every cached sink is invalidated instead.
+	cloudservice.CloudService_GetAccountAuditLogSink_FullMethodName:    {resource: CacheResourceAuditLogSink, idField: "name"},
+	cloudservice.CloudService_GetAccountAuditLogSinks_FullMethodName:   {resource: CacheResourceAuditLogSink},
+	cloudservice.CloudService_CreateAccountAuditLogSink_FullMethodName: {resource: CacheResourceAuditLogSink, mutates: true},
+	cloudservice.CloudService_UpdateAccountAuditLogSink_FullMethodName: {resource: CacheResourceAuditLogSink, mutates: true},
+	cloudservice.CloudService_DeleteAccountAuditLogSink_FullMethodName: {resource: CacheResourceAuditLogSink, mutates: true},
+
+	cloudservice.CloudService_GetRegion_FullMethodName:  {resource: CacheResourceRegion, idField: "region"},
+	cloudservice.CloudService_GetRegions_FullMethodName: {resource: CacheResourceRegion},
+}
+
+func newResponseCache(options CacheOptions) *responseCache {
+	if options.MaxEntries <= 0 {
+		options.MaxEntries = defaultCacheMaxEntries
+	}
+	return &responseCache{
+		options:     options,
+		now:         time.Now,
+		entries:     map[string]*cacheEntry{},
+		stats:       CacheStats{},
+		generations: map[CacheResource]uint64{},
+	}
+}
+
+func (c *responseCache) ttl(resource CacheResource) time.Duration {
+	if ttl, ok := c.options.TTLs[resource]; ok {
+		return ttl
+	}
+	return c.options.DefaultTTL
+}
+
+func (c *responseCache) intercept(
+	ctx context.Context,
+	method string,
+	req interface{}, reply interface{},
+	conn *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	m, ok := cachedMethods[method]
+	reqMsg, isReqMsg := req.(proto.Message)
+	replyMsg, isReplyMsg := reply.(proto.Message)
+	if !ok || !isReqMsg || !isReplyMsg {
+		return invoker(ctx, method, req, reply, conn, opts...)
+	}
+	id := requestID(reqMsg, m.idField)
+
+	if m.mutates {
+		err := invoker(ctx, method, req, reply, conn, opts...)
+		// invalidate even on error, the mutation may have been applied before the error was returned.
+		c.invalidate(m.resource, id)
+		for _, resource := range m.alsoInvalidates {
+			c.invalidate(resource, "")
+		}
+		return err
+	}
+
+	ttl := c.ttl(m.resource)
+	if ttl <= 0 {
+		return invoker(ctx, method, req, reply, conn, opts...)
+	}
+	key, err := cacheKey(method, reqMsg)
+	if err != nil {
+		return invoker(ctx, method, req, reply, conn, opts...)
+	}
+
+	cached, generation := c.get(key, m.resource)
+	if cached != nil {
+		proto.Reset(replyMsg)
+		proto.Merge(replyMsg, cached)
+		return nil
+	}
+	if err := invoker(ctx, method, req, reply, conn, opts...); err != nil {
+		return err
+	}
+	c.put(key, generation, &cacheEntry{
+		resource:  m.resource,
+		id:        id,
+		response:  proto.Clone(replyMsg),
+		expiresAt: c.now().Add(ttl),
+	})
+	return nil
+}
+
+// get returns the cached response, if any, and the generation of the resource, to pass to put on a miss.
+func (c *responseCache) get(key string, resource CacheResource) (proto.Message, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats[resource]
+	defer func() { c.stats[resource] = stats }()
+
+	entry, ok := c.entries[key]
+	if ok && c.now().Before(entry.expiresAt) {
+		stats.Hits++
+		return entry.response, c.generations[resource]
+	}
+	if ok {
+		delete(c.entries, key)
+	}
+	stats.Misses++
+	return nil, c.generations[resource]
+}
+
+// put caches the response, unless the resource was invalidated since the given generation: the response
+// may predate the mutation.
+func (c *responseCache) put(key string, generation uint64, entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generations[entry.resource] != generation {
+		return
+	}
+
+	if len(c.entries) >= c.options.MaxEntries {
+		// make room: drop the expired entries, then the entry closest to expire.
+		now := c.now()
+		var (
+			oldestKey string
+			oldest    *cacheEntry
+		)
+		for k, e := range c.entries {
+			if !now.Before(e.expiresAt) {
+				delete(c.entries, k)
+			} else if oldest == nil || e.expiresAt.Before(oldest.expiresAt) {
+				oldestKey, oldest = k, e
+			}
+		}
+		if len(c.entries) >= c.options.MaxEntries && oldest != nil {
+			delete(c.entries, oldestKey)
+		}
+	}
+	c.entries[key] = entry
+}
+
+// invalidate drops the cached responses of a resource: the responses about the resource with the given id,
+// and the responses not about a specific resource, such as lists.
+// An empty id drops every cached response of the resource.
+func (c *responseCache) invalidate(resource CacheResource, id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generations[resource]++
+	for k, e := range c.entries {
+		if e.resource == resource && (id == "" || e.id == "" || e.id == id) {
+			delete(c.entries, k)
+		}
+	}
+}
+
+func (c *responseCache) snapshotStats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make(CacheStats, len(c.stats))
+	for resource, stats := range c.stats {
+		out[resource] = stats
+	}
+	return out
+}
+
+// requestID returns the value of the string field of the request identifying the resource, if any.
+func requestID(req proto.Message, field protoreflect.Name) string {
+	if field == "" {
+		return ""
+	}
+	fd := req.ProtoReflect().Descriptor().Fields().ByName(field)
+	if fd == nil || fd.Kind() != protoreflect.StringKind {
+		return ""
+	}
+	return req.ProtoReflect().Get(fd).String()
+}
+
+func cacheKey(method string, req proto.Message) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	return method + "\x00" + string(b), nil
+}
@@ -0,0 +1,167 @@
+package cloudclient
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	cloudservice "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	namespace "go.temporal.io/cloud-sdk/api/namespace/v1"
+	"google.golang.org/grpc"
+)
+
+func TestResponseCache(t *testing.T) {
+	now := time.Now()
+	cache := newResponseCache(CacheOptions{
+		TTLs: map[CacheResource]time.Duration{CacheResourceNamespace: time.Minute},
+	})
+	cache.now = func() time.Time { return now }
+
+	var calls int
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		if resp, ok := reply.(*cloudservice.GetNamespaceResponse); ok {
+			resp.Namespace = &namespace.Namespace{Namespace: req.(*cloudservice.GetNamespaceRequest).GetNamespace()}
+		}
+		return nil
+	}
+	getNamespace := func(name string) *cloudservice.GetNamespaceResponse {
+		resp := &cloudservice.GetNamespaceResponse{}
+		err := cache.intercept(context.Background(), cloudservice.CloudService_GetNamespace_FullMethodName,
+			&cloudservice.GetNamespaceRequest{Namespace: name}, resp, nil, invoker)
+		if err != nil {
+			t.Fatalf("intercept() error = %v", err)
+		}
+		return resp
+	}
+	mutate := func(method string, req any) {
+		err := cache.intercept(context.Background(), method, req, &cloudservice.UpdateNamespaceResponse{}, nil, invoker)
+		if err != nil {
+			t.Fatalf("intercept() error = %v", err)
+		}
+	}
+
+	t.Run("Hit", func(t *testing.T) {
+		getNamespace("a")
+		resp := getNamespace("a")
+		if calls != 1 {
+			t.Errorf("intercept() invoked %d times, want 1", calls)
+		}
+		if resp.GetNamespace().GetNamespace() != "a" {
+			t.Errorf("intercept() cached response = %v", resp)
+		}
+		stats := cache.snapshotStats()[CacheResourceNamespace]
+		if stats.Hits != 1 || stats.Misses != 1 {
+			t.Errorf("snapshotStats() = %+v, want 1 hit and 1 miss", stats)
+		}
+	})
+
+	t.Run("Keyed By Request", func(t *testing.T) {
+		calls = 0
+		getNamespace("b")
+		if calls != 1 {
+			t.Errorf("intercept() invoked %d times, want 1", calls)
+		}
+	})
+
+	t.Run("Invalidated By Mutation Of The Resource", func(t *testing.T) {
+		calls = 0
+		mutate(cloudservice.CloudService_UpdateNamespace_FullMethodName, &cloudservice.UpdateNamespaceRequest{Namespace: "a"})
+		getNamespace("a")
+		getNamespace("b")
+		if calls != 2 {
+			t.Errorf("intercept() invoked %d times, want 2: the mutation and a's get", calls)
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		calls = 0
+		now = now.Add(2 * time.Minute)
+		getNamespace("b")
+		if calls != 1 {
+			t.Errorf("intercept() invoked %d times, want 1", calls)
+		}
+	})
+
+	t.Run("Resource Without TTL", func(t *testing.T) {
+		calls = 0
+		for range 2 {
+			err := cache.intercept(context.Background(), cloudservice.CloudService_GetUser_FullMethodName,
+				&cloudservice.GetUserRequest{UserId: "u"}, &cloudservice.GetUserResponse{}, nil, invoker)
+			if err != nil {
+				t.Fatalf("intercept() error = %v", err)
+			}
+		}
+		if calls != 2 {
+			t.Errorf("intercept() invoked %d times, want 2", calls)
+		}
+	})
+
+	t.Run("Cached Response Is Not Shared", func(t *testing.T) {
+		getNamespace("c").Namespace.Namespace = "modified"
+		if got := getNamespace("c").GetNamespace().GetNamespace(); got != "c" {
+			t.Errorf("intercept() cached response = %q, want c", got)
+		}
+	})
+}
+
+func TestResponseCacheReadDuringMutation(t *testing.T) {
+	cache := newResponseCache(CacheOptions{DefaultTTL: time.Minute})
+
+	var (
+		mu      sync.Mutex
+		version = "old"
+	)
+	reading, mutated := make(chan struct{}), make(chan struct{})
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		switch reply := reply.(type) {
+		case *cloudservice.GetNamespaceResponse:
+			mu.Lock()
+			v := version
+			mu.Unlock()
+			if reading != nil {
+				// the read has its response, the mutation is applied before it returns.
+				close(reading)
+				<-mutated
+			}
+			reply.Namespace = &namespace.Namespace{Namespace: "a", ResourceVersion: v}
+		case *cloudservice.UpdateNamespaceResponse:
+			mu.Lock()
+			version = "new"
+			mu.Unlock()
+		}
+		return nil
+	}
+	getNamespace := func() string {
+		resp := &cloudservice.GetNamespaceResponse{}
+		err := cache.intercept(context.Background(), cloudservice.CloudService_GetNamespace_FullMethodName,
+			&cloudservice.GetNamespaceRequest{Namespace: "a"}, resp, nil, invoker)
+		if err != nil {
+			t.Errorf("intercept() error = %v", err)
+		}
+		return resp.GetNamespace().GetResourceVersion()
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if got := getNamespace(); got != "old" {
+			t.Errorf("intercept() in flight = %q, want old", got)
+		}
+	}()
+	<-reading
+	err := cache.intercept(context.Background(), cloudservice.CloudService_UpdateNamespace_FullMethodName,
+		&cloudservice.UpdateNamespaceRequest{Namespace: "a"}, &cloudservice.UpdateNamespaceResponse{}, nil, invoker)
+	if err != nil {
+		t.Fatalf("intercept() error = %v", err)
+	}
+	close(mutated)
+	wg.Wait()
+
+	reading = nil
+	if got := getNamespace(); got != "new" {
+		t.Errorf("intercept() after the mutation = %q, want new: the read in flight was cached", got)
+	}
+}
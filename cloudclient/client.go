@@ -18,6 +18,7 @@ type (
 	Client struct {
 		conn               *grpc.ClientConn
 		cloudServiceClient cloudservice.CloudServiceClient
+		cache              *responseCache
 	}
 )
 
@@ -25,7 +26,7 @@ type (
 func New(options Options) (*Client, error) {
 
 	// compute the options provided by the user
-	hostPort, grpcDialOptions, err := options.compute()
+	hostPort, grpcDialOptions, cache, err := options.compute()
 	if err != nil {
 		return nil, fmt.Errorf("failed to compute options: %w", err)
 	}
@@ -43,6 +44,7 @@ func New(options Options) (*Client, error) {
 	return &Client{
 		conn:               conn,
 		cloudServiceClient: cloudservice.NewCloudServiceClient(conn),
+		cache:              cache,
 	}, nil
 }
 
@@ -51,6 +53,60 @@ func (c *Client) CloudService() cloudservice.CloudServiceClient {
 	return c.cloudServiceClient
 }
 
+// Namespaces returns the client to manage the namespaces of the account.
+func (c *Client) Namespaces() NamespaceClient {
+	return namespaceClient{client: c.cloudServiceClient}
+}
+
+// Users returns the client to manage the users of the account.
+func (c *Client) Users() UserClient {
+	return userClient{client: c.cloudServiceClient}
+}
+
+// UserGroups returns the client to manage the user groups of the account.
+func (c *Client) UserGroups() UserGroupClient {
+	return userGroupClient{client: c.cloudServiceClient}
+}
+
+// ServiceAccounts returns the client to manage the service accounts of the account.
+func (c *Client) ServiceAccounts() ServiceAccountClient {
+	return serviceAccountClient{client: c.cloudServiceClient}
+}
+
+// APIKeys returns the client to manage the API keys of the account.
+func (c *Client) APIKeys() APIKeyClient {
+	return apiKeyClient{client: c.cloudServiceClient}
+}
+
+// NexusEndpoints returns the client to manage the Nexus endpoints of the account.
+func (c *Client) NexusEndpoints() NexusEndpointClient {
+	return nexusEndpointClient{client: c.cloudServiceClient}
+}
+
+// ConnectivityRules returns the client to manage the connectivity rules of the account.
+func (c *Client) ConnectivityRules() ConnectivityRuleClient {
+	return connectivityRuleClient{client: c.cloudServiceClient}
+}
+
+// CustomRoles returns the client to manage the custom roles of the account.
+func (c *Client) CustomRoles() CustomRoleClient {
+	return customRoleClient{client: c.cloudServiceClient}
+}
+
+// AsyncOperations returns the client to track the async operations started by mutations.
+func (c *Client) AsyncOperations() AsyncOperationClient {
+	return asyncOperationClient{client: c.cloudServiceClient}
+}
+
+// CacheStats returns the hits and misses of the read-through cache, per resource.
+// Returns nil if the cache is not enabled.
+func (c *Client) CacheStats() CacheStats {
+	if c.cache == nil {
+		return nil
+	}
+	return c.cache.snapshotStats()
+}
+
 // Close closes the client connection to the server.
 // The client must be closed when it is no longer needed to clean up resources.
 func (c *Client) Close() error {
@@ -0,0 +1,120 @@
+package cloudservicemock_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	cloudservice "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	"go.temporal.io/cloud-sdk/cloudclient"
+	"go.temporal.io/cloud-sdk/cloudclient/cloudservicemock"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+)
+
+var (
+	_ cloudservice.CloudServiceClient    = (*cloudservicemock.MockCloudServiceClient)(nil)
+	_ cloudclient.NamespaceClient        = (*cloudservicemock.MockNamespaceClient)(nil)
+	_ cloudclient.UserClient             = (*cloudservicemock.MockUserClient)(nil)
+	_ cloudclient.UserGroupClient        = (*cloudservicemock.MockUserGroupClient)(nil)
+	_ cloudclient.ServiceAccountClient   = (*cloudservicemock.MockServiceAccountClient)(nil)
+	_ cloudclient.APIKeyClient           = (*cloudservicemock.MockAPIKeyClient)(nil)
+	_ cloudclient.NexusEndpointClient    = (*cloudservicemock.MockNexusEndpointClient)(nil)
+	_ cloudclient.ConnectivityRuleClient = (*cloudservicemock.MockConnectivityRuleClient)(nil)
+	_ cloudclient.CustomRoleClient       = (*cloudservicemock.MockCustomRoleClient)(nil)
+	_ cloudclient.AsyncOperationClient   = (*cloudservicemock.MockAsyncOperationClient)(nil)
+)
+
+// TestMocksInSync fails when the mocks have methods the interfaces no longer have,
+// run `go generate ./cloudclient/cloudservicemock` after regenerating the protos.
+func TestMocksInSync(t *testing.T) {
+	for iface, mock := range map[reflect.Type]reflect.Type{
+		reflect.TypeFor[cloudservice.CloudServiceClient]():    reflect.TypeFor[*cloudservicemock.MockCloudServiceClient](),
+		reflect.TypeFor[cloudclient.NamespaceClient]():        reflect.TypeFor[*cloudservicemock.MockNamespaceClient](),
+		reflect.TypeFor[cloudclient.UserClient]():             reflect.TypeFor[*cloudservicemock.MockUserClient](),
+		reflect.TypeFor[cloudclient.UserGroupClient]():        reflect.TypeFor[*cloudservicemock.MockUserGroupClient](),
+		reflect.TypeFor[cloudclient.ServiceAccountClient]():   reflect.TypeFor[*cloudservicemock.MockServiceAccountClient](),
+		reflect.TypeFor[cloudclient.APIKeyClient]():           reflect.TypeFor[*cloudservicemock.MockAPIKeyClient](),
+		reflect.TypeFor[cloudclient.NexusEndpointClient]():    reflect.TypeFor[*cloudservicemock.MockNexusEndpointClient](),
+		reflect.TypeFor[cloudclient.ConnectivityRuleClient](): reflect.TypeFor[*cloudservicemock.MockConnectivityRuleClient](),
+		reflect.TypeFor[cloudclient.CustomRoleClient]():       reflect.TypeFor[*cloudservicemock.MockCustomRoleClient](),
+		reflect.TypeFor[cloudclient.AsyncOperationClient]():   reflect.TypeFor[*cloudservicemock.MockAsyncOperationClient](),
+	} {
+		for i := range mock.NumMethod() {
+			name := mock.Method(i).Name
+			if name == "EXPECT" {
+				continue
+			}
+			if _, ok := iface.MethodByName(name); !ok {
+				t.Errorf("%s has method %s which is not in %s", mock, name, iface)
+			}
+		}
+	}
+}
+
+func TestEqualProto(t *testing.T) {
+	want := &cloudservice.DeleteUserRequest{UserId: "user", ResourceVersion: "rv"}
+
+	t.Run("Ignores Async Operation ID", func(t *testing.T) {
+		got := &cloudservice.DeleteUserRequest{UserId: "user", ResourceVersion: "rv", AsyncOperationId: "random"}
+		if !cloudservicemock.EqualProto(want).Matches(got) {
+			t.Errorf("EqualProto(%v) does not match %v", want, got)
+		}
+		if got.GetAsyncOperationId() != "random" {
+			t.Errorf("EqualProto() modified the request")
+		}
+	})
+
+	t.Run("Compares Fields", func(t *testing.T) {
+		got := &cloudservice.DeleteUserRequest{UserId: "user", ResourceVersion: "other"}
+		if cloudservicemock.EqualProto(want).Matches(got) {
+			t.Errorf("EqualProto(%v) matches %v", want, got)
+		}
+	})
+
+	t.Run("With Mock", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		client := cloudservicemock.NewMockCloudServiceClient(ctrl)
+		client.EXPECT().
+			DeleteUser(gomock.Any(), cloudservicemock.EqualProto(want)).
+			Return(&cloudservice.DeleteUserResponse{}, nil)
+
+		_, err := client.DeleteUser(context.Background(), &cloudservice.DeleteUserRequest{
+			UserId:           "user",
+			ResourceVersion:  "rv",
+			AsyncOperationId: "random",
+		})
+		if err != nil {
+			t.Fatalf("DeleteUser() error = %v", err)
+		}
+	})
+}
+
+type fakeUsers struct {
+	deleted []string
+}
+
+func (f *fakeUsers) DeleteUser(_ context.Context, req *cloudservice.DeleteUserRequest, _ ...grpc.CallOption) (*cloudservice.DeleteUserResponse, error) {
+	f.deleted = append(f.deleted, req.GetUserId())
+	return &cloudservice.DeleteUserResponse{}, nil
+}
+
+func TestNewFakeCloudServiceClient(t *testing.T) {
+	fake := &fakeUsers{}
+	client := cloudservicemock.NewFakeCloudServiceClient(t, fake)
+	client.EXPECT().
+		GetUser(gomock.Any(), cloudservicemock.EqualProto(&cloudservice.GetUserRequest{UserId: "user"})).
+		Return(&cloudservice.GetUserResponse{}, nil)
+
+	for range 2 {
+		if _, err := client.DeleteUser(context.Background(), &cloudservice.DeleteUserRequest{UserId: "user"}); err != nil {
+			t.Fatalf("DeleteUser() error = %v", err)
+		}
+	}
+	if _, err := client.GetUser(context.Background(), &cloudservice.GetUserRequest{UserId: "user"}); err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+	if !reflect.DeepEqual(fake.deleted, []string{"user", "user"}) {
+		t.Errorf("DeleteUser() served by the fake deleted %v, want [user user]", fake.deleted)
+	}
+}
@@ -0,0 +1,11 @@
+// Package cloudservicemock provides generated mocks of the cloud service client and of the resource clients,
+// to unit-test code built on top of the SDK without a Temporal Cloud account.
+//
+// The mocks are generated with mockgen, see [go.uber.org/mock/gomock] for how to set expectations.
+// Use [EqualProto] to match proto requests.
+//
+// WARNING: The package is currently experimental.
+package cloudservicemock
+
+//go:generate mockgen -write_package_comment=false -destination=cloudservice_mock.go -package=cloudservicemock go.temporal.io/cloud-sdk/api/cloudservice/v1 CloudServiceClient
+//go:generate mockgen -write_package_comment=false -destination=resource_clients_mock.go -package=cloudservicemock go.temporal.io/cloud-sdk/cloudclient NamespaceClient,UserClient,UserGroupClient,ServiceAccountClient,APIKeyClient,NexusEndpointClient,ConnectivityRuleClient,CustomRoleClient,AsyncOperationClient
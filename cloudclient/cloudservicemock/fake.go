@@ -0,0 +1,36 @@
+package cloudservicemock
+
+import (
+	"reflect"
+
+	cloudservice "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	"go.uber.org/mock/gomock"
+)
+
+// NewFakeCloudServiceClient returns a mock of the cloud service client serving, any number of times, the calls
+// of the CloudServiceClient methods that fake implements with the same signature.
+// Calls of the other methods fail the test, unless they are expected on the returned mock.
+//
+// Use it to back a mock with a hand-written, stateful fake when the exact calls do not matter to a test,
+// and prefer expectations matched with [EqualProto] otherwise.
+func NewFakeCloudServiceClient(t gomock.TestHelper, fake any) *MockCloudServiceClient {
+	t.Helper()
+	client := NewMockCloudServiceClient(gomock.NewController(t))
+	fakeValue := reflect.ValueOf(fake)
+	recorder := reflect.ValueOf(client.EXPECT())
+	iface := reflect.TypeFor[cloudservice.CloudServiceClient]()
+	for i := range iface.NumMethod() {
+		name := iface.Method(i).Name
+		method := fakeValue.MethodByName(name)
+		if !method.IsValid() {
+			continue
+		}
+		if want := iface.Method(i).Type; method.Type() != want {
+			t.Fatalf("%T.%s has type %s, want %s", fake, name, method.Type(), want)
+		}
+		call := recorder.MethodByName(name).Call([]reflect.Value{reflect.ValueOf(gomock.Any()), reflect.ValueOf(gomock.Any())})[0]
+		call = call.MethodByName("DoAndReturn").Call([]reflect.Value{method})[0]
+		call.MethodByName("AnyTimes").Call(nil)
+	}
+	return client
+}
@@ -0,0 +1,48 @@
+package cloudservicemock
+
+import (
+	"fmt"
+
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// asyncOperationIDField is the request field the client sets to a random value when left empty.
+const asyncOperationIDField protoreflect.Name = "async_operation_id"
+
+type protoMatcher struct {
+	want proto.Message
+}
+
+// EqualProto returns a matcher of the proto messages equal to want, compared with proto.Equal.
+// The async_operation_id field of the requests is ignored, so that expectations do not depend on the generated ids.
+func EqualProto(want proto.Message) gomock.Matcher {
+	return protoMatcher{want: want}
+}
+
+func (m protoMatcher) Matches(x any) bool {
+	got, ok := x.(proto.Message)
+	if !ok {
+		return false
+	}
+	return proto.Equal(withoutAsyncOperationID(m.want), withoutAsyncOperationID(got))
+}
+
+func (m protoMatcher) String() string {
+	return fmt.Sprintf("is equal to %T{%s} ignoring %s", m.want, prototext.MarshalOptions{}.Format(m.want), asyncOperationIDField)
+}
+
+func withoutAsyncOperationID(m proto.Message) proto.Message {
+	if m == nil {
+		return nil
+	}
+	field := m.ProtoReflect().Descriptor().Fields().ByName(asyncOperationIDField)
+	if field == nil || !m.ProtoReflect().Has(field) {
+		return m
+	}
+	m = proto.Clone(m)
+	m.ProtoReflect().Clear(field)
+	return m
+}
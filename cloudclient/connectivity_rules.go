@@ -0,0 +1,74 @@
+package cloudclient
+
+import (
+	"context"
+
+	cloudservice "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	connectivityrule "go.temporal.io/cloud-sdk/api/connectivityrule/v1"
+	"go.temporal.io/cloud-sdk/internal/paging"
+)
+
+type (
+	// ConnectivityRuleClient to manage the connectivity rules of the account.
+	ConnectivityRuleClient interface {
+		// Get returns the connectivity rule with the given id.
+		Get(ctx context.Context, id string) (*connectivityrule.ConnectivityRule, error)
+		// List returns every connectivity rule of the account.
+		List(ctx context.Context) ([]*connectivityrule.ConnectivityRule, error)
+		// Create starts the creation of a connectivity rule and returns its id.
+		Create(ctx context.Context, spec *connectivityrule.ConnectivityRuleSpec) (string, *AsyncOperation, error)
+		// Delete deletes a connectivity rule.
+		// If resourceVersion is empty, the latest version of the rule is deleted.
+		Delete(ctx context.Context, id string, resourceVersion string) (*AsyncOperation, error)
+	}
+
+	connectivityRuleClient struct {
+		client cloudservice.CloudServiceClient
+	}
+)
+
+func (c connectivityRuleClient) Get(ctx context.Context, id string) (*connectivityrule.ConnectivityRule, error) {
+	resp, err := c.client.GetConnectivityRule(ctx, &cloudservice.GetConnectivityRuleRequest{
+		ConnectivityRuleId: id,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return resp.GetConnectivityRule(), nil
+}
+
+func (c connectivityRuleClient) List(ctx context.Context) ([]*connectivityrule.ConnectivityRule, error) {
+	rules, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*connectivityrule.ConnectivityRule, string, error) {
+		resp, err := c.client.GetConnectivityRules(ctx, &cloudservice.GetConnectivityRulesRequest{PageToken: pageToken})
+		return resp.GetConnectivityRules(), resp.GetNextPageToken(), err
+	})
+	return rules, toAPIError(err)
+}
+
+func (c connectivityRuleClient) Create(ctx context.Context, spec *connectivityrule.ConnectivityRuleSpec) (string, *AsyncOperation, error) {
+	resp, err := c.client.CreateConnectivityRule(ctx, &cloudservice.CreateConnectivityRuleRequest{
+		Spec: spec,
+	})
+	if err != nil {
+		return "", nil, toAPIError(err)
+	}
+	return resp.GetConnectivityRuleId(), newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c connectivityRuleClient) Delete(ctx context.Context, id string, resourceVersion string) (*AsyncOperation, error) {
+	if resourceVersion == "" {
+		rule, err := c.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		resourceVersion = rule.GetResourceVersion()
+	}
+	resp, err := c.client.DeleteConnectivityRule(ctx, &cloudservice.DeleteConnectivityRuleRequest{
+		ConnectivityRuleId: id,
+		ResourceVersion:    resourceVersion,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
@@ -0,0 +1,89 @@
+package cloudclient
+
+import (
+	"context"
+
+	cloudservice "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identity "go.temporal.io/cloud-sdk/api/identity/v1"
+	"go.temporal.io/cloud-sdk/internal/paging"
+)
+
+type (
+	// CustomRoleClient to manage the custom roles of the account.
+	CustomRoleClient interface {
+		// Get returns the custom role with the given id.
+		Get(ctx context.Context, id string) (*identity.CustomRole, error)
+		// List returns every custom role of the account.
+		List(ctx context.Context) ([]*identity.CustomRole, error)
+		// Create starts the creation of a custom role and returns its id.
+		Create(ctx context.Context, spec *identity.CustomRoleSpec) (string, *AsyncOperation, error)
+		// Update replaces the spec of a custom role with role.Spec.
+		// The update is rejected if the role changed since it was retrieved, see role.ResourceVersion.
+		Update(ctx context.Context, role *identity.CustomRole) (*AsyncOperation, error)
+		// Delete deletes a custom role.
+		// If resourceVersion is empty, the latest version of the role is deleted.
+		Delete(ctx context.Context, id string, resourceVersion string) (*AsyncOperation, error)
+	}
+
+	customRoleClient struct {
+		client cloudservice.CloudServiceClient
+	}
+)
+
+func (c customRoleClient) Get(ctx context.Context, id string) (*identity.CustomRole, error) {
+	resp, err := c.client.GetCustomRole(ctx, &cloudservice.GetCustomRoleRequest{
+		RoleId: id,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return resp.GetCustomRole(), nil
+}
+
+func (c customRoleClient) List(ctx context.Context) ([]*identity.CustomRole, error) {
+	roles, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identity.CustomRole, string, error) {
+		resp, err := c.client.GetCustomRoles(ctx, &cloudservice.GetCustomRolesRequest{PageToken: pageToken})
+		return resp.GetCustomRoles(), resp.GetNextPageToken(), err
+	})
+	return roles, toAPIError(err)
+}
+
+func (c customRoleClient) Create(ctx context.Context, spec *identity.CustomRoleSpec) (string, *AsyncOperation, error) {
+	resp, err := c.client.CreateCustomRole(ctx, &cloudservice.CreateCustomRoleRequest{
+		Spec: spec,
+	})
+	if err != nil {
+		return "", nil, toAPIError(err)
+	}
+	return resp.GetRoleId(), newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c customRoleClient) Update(ctx context.Context, role *identity.CustomRole) (*AsyncOperation, error) {
+	resp, err := c.client.UpdateCustomRole(ctx, &cloudservice.UpdateCustomRoleRequest{
+		RoleId:          role.GetId(),
+		Spec:            role.GetSpec(),
+		ResourceVersion: role.GetResourceVersion(),
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c customRoleClient) Delete(ctx context.Context, id string, resourceVersion string) (*AsyncOperation, error) {
+	if resourceVersion == "" {
+		role, err := c.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		resourceVersion = role.GetResourceVersion()
+	}
+	resp, err := c.client.DeleteCustomRole(ctx, &cloudservice.DeleteCustomRoleRequest{
+		RoleId:          id,
+		ResourceVersion: resourceVersion,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
@@ -0,0 +1,67 @@
+package customroles
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sync"
+)
+
+//go:embed catalog.json
+var catalogJSON []byte
+
+var defaultCatalog = sync.OnceValue(func() *Catalog {
+	c, err := ParseCatalog(bytes.NewReader(catalogJSON))
+	if err != nil {
+		panic(fmt.Sprintf("invalid bundled catalog: %v", err))
+	}
+	return c
+})
+
+// Catalog lists the resource types of custom role permissions and the actions allowed on each of them.
+type Catalog struct {
+	// The version of the cloud operations API the actions are taken from.
+	APIVersion string `json:"api_version,omitempty"`
+	// The actions allowed on each resource type, by resource type.
+	ResourceTypes map[string][]string `json:"resource_types"`
+}
+
+// DefaultCatalog returns the catalog bundled with this version of the SDK. Its actions are taken from the cloud
+// operations API this SDK targets, cloudclient.DefaultAPIVersion: they are the methods of the CloudService
+// of api/cloudservice/v1, such as GetNamespace, grouped by the type of resource they act on. Temporal Cloud may
+// accept actions this catalog does not know of yet; use ParseCatalog to validate against a catalog of your own.
+// The returned catalog is shared and must not be modified.
+func DefaultCatalog() *Catalog {
+	return defaultCatalog()
+}
+
+// ParseCatalog reads a catalog in the JSON format of the bundled one, for instance to validate roles against
+// the actions of a newer version of Temporal Cloud:
+//
+//	{"api_version": "v0.17.1", "resource_types": {"namespace": ["GetNamespace", "UpdateNamespace"]}}
+func ParseCatalog(r io.Reader) (*Catalog, error) {
+	var c Catalog
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&c); err != nil {
+		return nil, fmt.Errorf("failed to parse the catalog: %w", err)
+	}
+	if len(c.ResourceTypes) == 0 {
+		return nil, fmt.Errorf("failed to parse the catalog: no resource types")
+	}
+	return &c, nil
+}
+
+// Types returns the resource types of the catalog, sorted.
+func (c *Catalog) Types() []string {
+	return slices.Sorted(maps.Keys(c.ResourceTypes))
+}
+
+// Has reports whether the action is allowed on the resource type.
+func (c *Catalog) Has(resourceType, action string) bool {
+	return slices.Contains(c.ResourceTypes[resourceType], action)
+}
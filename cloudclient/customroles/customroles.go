@@ -0,0 +1,172 @@
+// Package customroles evaluates, compares and validates the specs of Temporal Cloud custom roles locally,
+// to review the changes made with CreateCustomRole and UpdateCustomRole before they are applied.
+//
+// WARNING: The package is currently experimental.
+//
+// A custom role is a list of permissions, each allowing actions on resources of a type: either the resources
+// with the given ids, or all the resources of the type. A role allows an action on a resource if any of its
+// permissions does.
+package customroles
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+)
+
+// AllResources stands for all the resources of a type, in the grants of Diff.
+const AllResources = "*"
+
+// The kinds of changes.
+const (
+	Added   ChangeKind = "added"
+	Removed ChangeKind = "removed"
+)
+
+type (
+	// ChangeKind is a kind of change between two role specs.
+	ChangeKind string
+
+	// Grant is an action allowed on a resource, or on all the resources of a type.
+	Grant struct {
+		ResourceType string
+		// The id of the resource, or AllResources.
+		ResourceID string
+		Action     string
+	}
+
+	// Change is a grant added or removed between two role specs.
+	Change struct {
+		Kind ChangeKind
+		Grant
+	}
+)
+
+// Allows reports whether the role allows the action on the resource. An empty resourceID asks whether the
+// action is allowed on all the resources of the type.
+func Allows(spec *identityv1.CustomRoleSpec, resourceType, resourceID, action string) bool {
+	return len(Explain(spec, resourceType, resourceID, action)) > 0
+}
+
+// Explain returns the permissions of the role that allow the action on the resource, nil if none does.
+// An empty resourceID asks whether the action is allowed on all the resources of the type.
+func Explain(spec *identityv1.CustomRoleSpec, resourceType, resourceID, action string) []*identityv1.CustomRoleSpec_Permission {
+	var permissions []*identityv1.CustomRoleSpec_Permission
+	for _, p := range spec.GetPermissions() {
+		resources := p.GetResources()
+		if resources.GetResourceType() != resourceType || !slices.Contains(p.GetActions(), action) {
+			continue
+		}
+		if resources.GetAllowAll() || (resourceID != "" && slices.Contains(resources.GetResourceIds(), resourceID)) {
+			permissions = append(permissions, p)
+		}
+	}
+	return permissions
+}
+
+// Grants returns the grants of the role, sorted and without duplicates.
+func Grants(spec *identityv1.CustomRoleSpec) []Grant {
+	var grants []Grant
+	for _, p := range spec.GetPermissions() {
+		resources := p.GetResources()
+		ids := resources.GetResourceIds()
+		if resources.GetAllowAll() {
+			ids = []string{AllResources}
+		}
+		for _, id := range ids {
+			for _, action := range p.GetActions() {
+				grants = append(grants, Grant{ResourceType: resources.GetResourceType(), ResourceID: id, Action: action})
+			}
+		}
+	}
+	slices.SortFunc(grants, compareGrants)
+	return slices.Compact(grants)
+}
+
+// Diff returns the grants added and removed from the old role to the new one, sorted by grant.
+// A grant on all the resources of a type is compared as is, it does not cover the grants on the same action
+// for resources of that type.
+func Diff(old, new *identityv1.CustomRoleSpec) []Change {
+	oldGrants, newGrants := Grants(old), Grants(new)
+	var changes []Change
+	for _, g := range oldGrants {
+		if _, found := slices.BinarySearchFunc(newGrants, g, compareGrants); !found {
+			changes = append(changes, Change{Kind: Removed, Grant: g})
+		}
+	}
+	for _, g := range newGrants {
+		if _, found := slices.BinarySearchFunc(oldGrants, g, compareGrants); !found {
+			changes = append(changes, Change{Kind: Added, Grant: g})
+		}
+	}
+	slices.SortStableFunc(changes, func(c1, c2 Change) int {
+		return compareGrants(c1.Grant, c2.Grant)
+	})
+	return changes
+}
+
+// Validate checks that the role has a name and that every permission has a resource type and actions of the
+// catalog, and either resource ids or allows all resources. If catalog is nil, DefaultCatalog is used.
+// The error lists every problem found.
+func Validate(spec *identityv1.CustomRoleSpec, catalog *Catalog) error {
+	if catalog == nil {
+		catalog = DefaultCatalog()
+	}
+	var errs []error
+	if spec.GetName() == "" {
+		errs = append(errs, errors.New("missing name"))
+	}
+	if len(spec.GetPermissions()) == 0 {
+		errs = append(errs, errors.New("missing permissions"))
+	}
+	for i, p := range spec.GetPermissions() {
+		resources := p.GetResources()
+		resourceType := resources.GetResourceType()
+		if _, ok := catalog.ResourceTypes[resourceType]; !ok {
+			errs = append(errs, fmt.Errorf("permission %d: unknown resource type %q, expected one of %v", i, resourceType, catalog.Types()))
+			continue
+		}
+		switch {
+		case resources.GetAllowAll() && len(resources.GetResourceIds()) > 0:
+			errs = append(errs, fmt.Errorf("permission %d: resource ids are set but all resources are allowed", i))
+		case !resources.GetAllowAll() && len(resources.GetResourceIds()) == 0:
+			errs = append(errs, fmt.Errorf("permission %d: missing resource ids", i))
+		}
+		if len(p.GetActions()) == 0 {
+			errs = append(errs, fmt.Errorf("permission %d: missing actions", i))
+		}
+		for _, action := range p.GetActions() {
+			if !catalog.Has(resourceType, action) {
+				errs = append(errs, fmt.Errorf("permission %d: unknown action %q for resource type %s", i, action, resourceType))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid custom role %q: %w", spec.GetName(), errors.Join(errs...))
+	}
+	return nil
+}
+
+// String returns the grant, such as "GetNamespace on namespace prod.a1b2c".
+func (g Grant) String() string {
+	return fmt.Sprintf("%s on %s %s", g.Action, g.ResourceType, g.ResourceID)
+}
+
+// String returns the change as a line of a unified diff, such as "+ GetNamespace on namespace prod.a1b2c".
+func (c Change) String() string {
+	if c.Kind == Removed {
+		return "- " + c.Grant.String()
+	}
+	return "+ " + c.Grant.String()
+}
+
+func compareGrants(g1, g2 Grant) int {
+	return cmp.Or(
+		cmp.Compare(g1.ResourceType, g2.ResourceType),
+		cmp.Compare(g1.ResourceID, g2.ResourceID),
+		cmp.Compare(g1.Action, g2.Action),
+	)
+}
@@ -0,0 +1,125 @@
+package customroles
+
+import (
+	"strings"
+	"testing"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	"go.temporal.io/cloud-sdk/cloudclient"
+)
+
+func permission(resourceType string, ids []string, actions ...string) *identityv1.CustomRoleSpec_Permission {
+	return &identityv1.CustomRoleSpec_Permission{
+		Resources: &identityv1.CustomRoleSpec_Resources{ResourceType: resourceType, ResourceIds: ids, AllowAll: ids == nil},
+		Actions:   actions,
+	}
+}
+
+var role = &identityv1.CustomRoleSpec{
+	Name: "namespace-operator",
+	Permissions: []*identityv1.CustomRoleSpec_Permission{
+		permission("namespace", nil, "GetNamespace"),
+		permission("namespace", []string{"prod.a1b2c"}, "UpdateNamespace", "GetNamespace"),
+	},
+}
+
+func TestAllows(t *testing.T) {
+	for _, tc := range []struct {
+		resourceType, resourceID, action string
+		want                             bool
+	}{
+		{"namespace", "dev.a1b2c", "GetNamespace", true},
+		{"namespace", "", "GetNamespace", true},
+		{"namespace", "prod.a1b2c", "UpdateNamespace", true},
+		{"namespace", "dev.a1b2c", "UpdateNamespace", false},
+		{"namespace", "", "UpdateNamespace", false},
+		{"user", "u1", "GetNamespace", false},
+	} {
+		if got := Allows(role, tc.resourceType, tc.resourceID, tc.action); got != tc.want {
+			t.Errorf("Allows(%s, %q, %s) = %v, want %v", tc.resourceType, tc.resourceID, tc.action, got, tc.want)
+		}
+	}
+	if got := Explain(role, "namespace", "prod.a1b2c", "GetNamespace"); len(got) != 2 {
+		t.Errorf("Explain() = %v, want both permissions", got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	updated := &identityv1.CustomRoleSpec{
+		Name: "namespace-operator",
+		Permissions: []*identityv1.CustomRoleSpec_Permission{
+			permission("namespace", nil, "GetNamespace"),
+			permission("namespace", []string{"prod.a1b2c", "staging.a1b2c"}, "GetNamespace", "FailoverNamespaceRegion"),
+		},
+	}
+	var got []string
+	for _, c := range Diff(role, updated) {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"+ FailoverNamespaceRegion on namespace prod.a1b2c",
+		"- UpdateNamespace on namespace prod.a1b2c",
+		"+ FailoverNamespaceRegion on namespace staging.a1b2c",
+		"+ GetNamespace on namespace staging.a1b2c",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Diff() = %q, want %q", got, want)
+	}
+	if changes := Diff(role, role); len(changes) != 0 {
+		t.Errorf("Diff() = %v, want no changes", changes)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(role, nil); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	invalid := &identityv1.CustomRoleSpec{
+		Permissions: []*identityv1.CustomRoleSpec_Permission{
+			permission("namespace", nil, "GetNamespaces"),
+			permission("namespaces", nil, "GetNamespace"),
+			{Resources: &identityv1.CustomRoleSpec_Resources{ResourceType: "user"}, Actions: []string{"GetUser"}},
+		},
+	}
+	err := Validate(invalid, nil)
+	if err == nil {
+		t.Fatal("Validate() error = nil")
+	}
+	for _, want := range []string{
+		"missing name",
+		`permission 0: unknown action "GetNamespaces" for resource type namespace`,
+		`permission 1: unknown resource type "namespaces"`,
+		"permission 2: missing resource ids",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() error = %v, want %s", err, want)
+		}
+	}
+
+	catalog, err := ParseCatalog(strings.NewReader(`{"resource_types": {"namespace": ["GetNamespaces"]}}`))
+	if err != nil {
+		t.Fatalf("ParseCatalog() error = %v", err)
+	}
+	if err := Validate(&identityv1.CustomRoleSpec{Name: "n", Permissions: invalid.Permissions[:1]}, catalog); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestDefaultCatalog(t *testing.T) {
+	catalog := DefaultCatalog()
+	if catalog.APIVersion != cloudclient.DefaultAPIVersion() {
+		t.Errorf("DefaultCatalog().APIVersion = %q, want %q, update the catalog from the cloud service", catalog.APIVersion, cloudclient.DefaultAPIVersion())
+	}
+	methods := map[string]bool{}
+	for _, m := range cloudservicev1.CloudService_ServiceDesc.Methods {
+		methods[m.MethodName] = true
+	}
+	for _, resourceType := range catalog.Types() {
+		for _, action := range catalog.ResourceTypes[resourceType] {
+			if !methods[action] {
+				t.Errorf("DefaultCatalog() action %s/%s is not a method of the cloud service", resourceType, action)
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package cloudclient
+
+import (
+	"errors"
+	"fmt"
+
+	operation "go.temporal.io/cloud-sdk/api/operation/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Errors matched, with errors.Is, by the errors returned by the resource clients.
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrAlreadyExists      = errors.New("already exists")
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrFailedPrecondition = errors.New("failed precondition")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrUnauthenticated    = errors.New("unauthenticated")
+	ErrRateLimited        = errors.New("rate limited")
+	ErrUnavailable        = errors.New("unavailable")
+)
+
+var codeErrors = map[codes.Code]error{
+	codes.NotFound:           ErrNotFound,
+	codes.AlreadyExists:      ErrAlreadyExists,
+	codes.InvalidArgument:    ErrInvalidArgument,
+	codes.FailedPrecondition: ErrFailedPrecondition,
+	codes.PermissionDenied:   ErrPermissionDenied,
+	codes.Unauthenticated:    ErrUnauthenticated,
+	codes.ResourceExhausted:  ErrRateLimited,
+	codes.Unavailable:        ErrUnavailable,
+}
+
+type (
+	// APIError is returned by the resource clients when the cloud operations API rejects a request.
+	// It matches, with errors.Is, the error variable corresponding to its code, such as ErrNotFound.
+	APIError struct {
+		Code    codes.Code
+		Message string
+		status  *status.Status
+	}
+
+	// AsyncOperationError is returned when waiting for an async operation that did not fulfill.
+	AsyncOperationError struct {
+		Operation *operation.AsyncOperation
+	}
+)
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// Is reports whether the target is the error variable corresponding to the code of the error.
+func (e *APIError) Is(target error) bool {
+	err, ok := codeErrors[e.Code]
+	return ok && err == target
+}
+
+// GRPCStatus returns the status the server responded with, so that the status package keeps working with the error.
+func (e *APIError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+func (e *AsyncOperationError) Error() string {
+	return fmt.Sprintf("async operation %q did not fulfill: state=%s reason=%q",
+		e.Operation.GetId(), e.Operation.GetState(), e.Operation.GetFailureReason())
+}
+
+// toAPIError converts the errors returned by the server to an APIError, leaving other errors untouched.
+func toAPIError(err error) error {
+	if err == nil {
+		return nil
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	return &APIError{
+		Code:    s.Code(),
+		Message: s.Message(),
+		status:  s,
+	}
+}
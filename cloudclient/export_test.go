@@ -0,0 +1,10 @@
+package cloudclient
+
+import (
+	cloudservice "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+)
+
+// NewWithCloudServiceClient returns a client making its calls with the cloud service client, such as a mock.
+func NewWithCloudServiceClient(client cloudservice.CloudServiceClient) *Client {
+	return &Client{cloudServiceClient: client}
+}
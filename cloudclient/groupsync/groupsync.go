@@ -0,0 +1,348 @@
+// Package groupsync converges the members of Temporal Cloud user groups to the memberships of an external
+// directory, such as an LDAP server.
+//
+// WARNING: The package is currently experimental.
+//
+// Only the groups managed by Temporal Cloud, with a CloudGroupSpec, can be synchronized: the members of Google and
+// SCIM groups are managed by their identity provider. The memberships are read from a MembershipSource, compared
+// to the members of the groups, and the missing members are added and the extra ones removed. A sync is planned
+// in full before any change is made, so that a plan exceeding the safety limits changes nothing.
+package groupsync
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	"go.temporal.io/cloud-sdk/internal/asyncop"
+	"go.temporal.io/cloud-sdk/internal/paging"
+	"go.temporal.io/cloud-sdk/internal/resources"
+)
+
+// DefaultMaxRemovals is the number of members a sync may remove when Options.MaxRemovals is not set.
+const DefaultMaxRemovals = 10
+
+// The kinds of changes of a sync, in the order they are applied.
+const (
+	CreateUser   ChangeKind = "create_user"
+	AddMember    ChangeKind = "add_member"
+	RemoveMember ChangeKind = "remove_member"
+)
+
+// ErrTooManyRemovals is returned when a sync would remove more members than Options.MaxRemovals.
+var ErrTooManyRemovals = errors.New("too many removals")
+
+type (
+	// MembershipSource provides the desired members of user groups.
+	MembershipSource interface {
+		// Memberships returns the emails of the desired members, by group. A group is identified by its display
+		// name, or by its id. A group without members is synchronized to an empty group, a group that is not
+		// returned is left unchanged.
+		Memberships(ctx context.Context) (map[string][]string, error)
+	}
+
+	// ChangeKind is a kind of change of a sync.
+	ChangeKind string
+
+	// Change is a change of a sync.
+	Change struct {
+		Kind ChangeKind
+		// The id and name of the group, empty for CreateUser.
+		GroupID   string
+		GroupName string
+		Email     string
+		// The id of the user, empty for a user to create until it is created.
+		UserID string
+		// The error that prevented the change, nil if it was applied or not applied yet.
+		Err error
+	}
+
+	// Plan lists the changes of a sync.
+	Plan struct {
+		// The changes to make, users to create first, then members to add, then members to remove.
+		Changes []*Change
+		// The emails of the desired members that are not users of the account, by group name,
+		// when Options.CreateUsers is not set.
+		MissingUsers map[string][]string
+		// The groups of the source that are not user groups of the account, which are left unchanged.
+		UnmatchedGroups []string
+	}
+
+	// Options configure a sync.
+	Options struct {
+		// Whether to create the desired members that are not users of the account.
+		// If false, they are reported in Plan.MissingUsers.
+		CreateUsers bool
+
+		// The account role of the users created.
+		// If not provided, ROLE_READ is used.
+		AccountRole identityv1.AccountAccess_Role
+
+		// The number of members a sync may remove, over all groups.
+		// If not provided, DefaultMaxRemovals is used. A negative value removes the limit.
+		MaxRemovals int
+
+		// Whether to only plan the changes, without applying them.
+		DryRun bool
+
+		// The interval between two checks of an async operation.
+		// If not provided, the interval suggested by the server is used.
+		PollInterval time.Duration
+	}
+)
+
+// Sync plans the changes that converge the groups to the memberships of the source, and applies them unless
+// Options.DryRun is set.
+//
+// ErrTooManyRemovals is returned, along with the plan, if the plan exceeds Options.MaxRemovals. Nothing is
+// changed then. Otherwise the returned error joins the errors of the changes, which are also set on each change.
+func Sync(
+	ctx context.Context,
+	client cloudservicev1.CloudServiceClient,
+	source MembershipSource,
+	options Options,
+) (*Plan, error) {
+	plan, err := NewPlan(ctx, client, source, options)
+	if err != nil {
+		return nil, err
+	}
+	maxRemovals := options.MaxRemovals
+	if maxRemovals == 0 {
+		maxRemovals = DefaultMaxRemovals
+	}
+	if removals := plan.count(RemoveMember); maxRemovals > 0 && removals > maxRemovals {
+		return plan, fmt.Errorf("%w: the sync would remove %d members, the limit is %d", ErrTooManyRemovals, removals, maxRemovals)
+	}
+	if options.DryRun {
+		return plan, nil
+	}
+	return plan, plan.apply(ctx, client, options)
+}
+
+// NewPlan reads the memberships of the source and returns the changes that converge the groups to them,
+// without applying them. The groups of the source that are not user groups of the account are skipped and
+// reported in Plan.UnmatchedGroups.
+func NewPlan(
+	ctx context.Context,
+	client cloudservicev1.CloudServiceClient,
+	source MembershipSource,
+	options Options,
+) (*Plan, error) {
+	memberships, err := source.Memberships(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the memberships: %w", err)
+	}
+	groups, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.UserGroup, string, error) {
+		resp, err := client.GetUserGroups(ctx, &cloudservicev1.GetUserGroupsRequest{PageToken: pageToken})
+		return resp.GetGroups(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the user groups: %w", err)
+	}
+	users, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.User, string, error) {
+		resp, err := client.GetUsers(ctx, &cloudservicev1.GetUsersRequest{PageToken: pageToken})
+		return resp.GetUsers(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the users: %w", err)
+	}
+	idsByEmail := map[string]string{}
+	emailsByID := map[string]string{}
+	for _, u := range users {
+		email := strings.ToLower(u.GetSpec().GetEmail())
+		emailsByID[u.GetId()] = email
+		// A user being deleted cannot be added to a group, its email is the one of a missing user.
+		if !resources.Deleted(u.GetState()) {
+			idsByEmail[email] = u.GetId()
+		}
+	}
+
+	plan := &Plan{MissingUsers: map[string][]string{}}
+	created := map[string]bool{}
+	for _, key := range slices.Sorted(maps.Keys(memberships)) {
+		group, err := findGroup(groups, key)
+		if err != nil {
+			return nil, err
+		}
+		if group == nil {
+			plan.UnmatchedGroups = append(plan.UnmatchedGroups, key)
+			continue
+		}
+		members, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.UserGroupMember, string, error) {
+			resp, err := client.GetUserGroupMembers(ctx, &cloudservicev1.GetUserGroupMembersRequest{
+				GroupId:   group.GetId(),
+				PageToken: pageToken,
+			})
+			return resp.GetMembers(), resp.GetNextPageToken(), err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the members of group %q: %w", group.GetSpec().GetDisplayName(), err)
+		}
+
+		desired := map[string]bool{}
+		for _, email := range memberships[key] {
+			desired[strings.ToLower(strings.TrimSpace(email))] = true
+		}
+		current := map[string]bool{}
+		for _, m := range members {
+			id := m.GetMemberId().GetUserId()
+			if id == "" {
+				continue
+			}
+			current[id] = true
+			if email := emailsByID[id]; !desired[email] {
+				plan.Changes = append(plan.Changes, newChange(RemoveMember, group, email, id))
+			}
+		}
+		for _, email := range slices.Sorted(maps.Keys(desired)) {
+			id, ok := idsByEmail[email]
+			switch {
+			case ok && current[id]:
+				continue
+			case !ok && !options.CreateUsers:
+				name := group.GetSpec().GetDisplayName()
+				plan.MissingUsers[name] = append(plan.MissingUsers[name], email)
+				continue
+			case !ok && !created[email]:
+				created[email] = true
+				plan.Changes = append(plan.Changes, &Change{Kind: CreateUser, Email: email})
+			}
+			plan.Changes = append(plan.Changes, newChange(AddMember, group, email, id))
+		}
+	}
+	order := []ChangeKind{CreateUser, AddMember, RemoveMember}
+	slices.SortStableFunc(plan.Changes, func(c1, c2 *Change) int {
+		return cmp.Compare(slices.Index(order, c1.Kind), slices.Index(order, c2.Kind))
+	})
+	return plan, nil
+}
+
+// String returns the change, such as "add alice@example.com to group developers".
+func (c *Change) String() string {
+	switch c.Kind {
+	case CreateUser:
+		return "create user " + c.Email
+	case AddMember:
+		return fmt.Sprintf("add %s to group %s", c.Email, c.GroupName)
+	default:
+		return fmt.Sprintf("remove %s from group %s", c.Email, c.GroupName)
+	}
+}
+
+// apply makes the changes of the plan in order. A member is not added if its user could not be created.
+func (p *Plan) apply(ctx context.Context, client cloudservicev1.CloudServiceClient, options Options) error {
+	role := options.AccountRole
+	if role == identityv1.AccountAccess_ROLE_UNSPECIFIED {
+		role = identityv1.AccountAccess_ROLE_READ
+	}
+	createdIDs := map[string]string{}
+	var errs []error
+	for _, c := range p.Changes {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		switch c.Kind {
+		case CreateUser:
+			resp, err := client.CreateUser(ctx, &cloudservicev1.CreateUserRequest{
+				Spec: &identityv1.UserSpec{
+					Email:  c.Email,
+					Access: &identityv1.Access{AccountAccess: &identityv1.AccountAccess{Role: role}},
+				},
+			})
+			if err == nil {
+				c.UserID = resp.GetUserId()
+				_, err = asyncop.Wait(ctx, client, resp.GetAsyncOperation().GetId(), options.PollInterval)
+			}
+			if err != nil {
+				c.Err = fmt.Errorf("failed to create user %s: %w", c.Email, err)
+				break
+			}
+			createdIDs[c.Email] = c.UserID
+		case AddMember:
+			if c.UserID == "" {
+				c.UserID = createdIDs[c.Email]
+			}
+			if c.UserID == "" {
+				c.Err = fmt.Errorf("failed to add %s to group %s: the user was not created", c.Email, c.GroupName)
+				break
+			}
+			resp, err := client.AddUserGroupMember(ctx, &cloudservicev1.AddUserGroupMemberRequest{
+				GroupId:  c.GroupID,
+				MemberId: memberID(c.UserID),
+			})
+			if err == nil {
+				_, err = asyncop.Wait(ctx, client, resp.GetAsyncOperation().GetId(), options.PollInterval)
+			}
+			if err != nil {
+				c.Err = fmt.Errorf("failed to add %s to group %s: %w", c.Email, c.GroupName, err)
+			}
+		case RemoveMember:
+			resp, err := client.RemoveUserGroupMember(ctx, &cloudservicev1.RemoveUserGroupMemberRequest{
+				GroupId:  c.GroupID,
+				MemberId: memberID(c.UserID),
+			})
+			if err == nil {
+				_, err = asyncop.Wait(ctx, client, resp.GetAsyncOperation().GetId(), options.PollInterval)
+			}
+			if err != nil {
+				c.Err = fmt.Errorf("failed to remove %s from group %s: %w", c.Email, c.GroupName, err)
+			}
+		}
+		if c.Err != nil {
+			errs = append(errs, c.Err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *Plan) count(kind ChangeKind) int {
+	n := 0
+	for _, c := range p.Changes {
+		if c.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// findGroup returns the group with the given display name or id, which must be managed by Temporal Cloud,
+// or nil if there is none.
+func findGroup(groups []*identityv1.UserGroup, key string) (*identityv1.UserGroup, error) {
+	i := slices.IndexFunc(groups, func(g *identityv1.UserGroup) bool {
+		return g.GetSpec().GetDisplayName() == key
+	})
+	if i < 0 {
+		i = slices.IndexFunc(groups, func(g *identityv1.UserGroup) bool {
+			return g.GetId() == key
+		})
+	}
+	if i < 0 {
+		return nil, nil
+	}
+	if groups[i].GetSpec().GetCloudGroup() == nil {
+		return nil, fmt.Errorf("user group %q is not managed by Temporal Cloud, its members cannot be synchronized", key)
+	}
+	return groups[i], nil
+}
+
+func newChange(kind ChangeKind, group *identityv1.UserGroup, email, userID string) *Change {
+	return &Change{
+		Kind:      kind,
+		GroupID:   group.GetId(),
+		GroupName: group.GetSpec().GetDisplayName(),
+		Email:     email,
+		UserID:    userID,
+	}
+}
+
+func memberID(userID string) *identityv1.UserGroupMemberId {
+	return &identityv1.UserGroupMemberId{MemberType: &identityv1.UserGroupMemberId_UserId{UserId: userID}}
+}
@@ -0,0 +1,250 @@
+package groupsync
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	resourcev1 "go.temporal.io/cloud-sdk/api/resource/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/cloudservicemock"
+	"google.golang.org/grpc"
+)
+
+type fakeCloudService struct {
+	users   []*identityv1.User
+	members map[string][]string
+	calls   []string
+}
+
+func (s *fakeCloudService) GetUserGroups(ctx context.Context, req *cloudservicev1.GetUserGroupsRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUserGroupsResponse, error) {
+	cloud := &identityv1.UserGroupSpec_CloudGroup{CloudGroup: &identityv1.CloudGroupSpec{}}
+	return &cloudservicev1.GetUserGroupsResponse{Groups: []*identityv1.UserGroup{
+		{Id: "g1", Spec: &identityv1.UserGroupSpec{DisplayName: "developers", GroupType: cloud}},
+		{Id: "g2", Spec: &identityv1.UserGroupSpec{DisplayName: "operators", GroupType: cloud}},
+		{Id: "g3", Spec: &identityv1.UserGroupSpec{DisplayName: "google", GroupType: &identityv1.UserGroupSpec_GoogleGroup{}}},
+	}}, nil
+}
+
+func (s *fakeCloudService) GetUsers(ctx context.Context, req *cloudservicev1.GetUsersRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUsersResponse, error) {
+	return &cloudservicev1.GetUsersResponse{Users: s.users}, nil
+}
+
+func (s *fakeCloudService) GetUserGroupMembers(ctx context.Context, req *cloudservicev1.GetUserGroupMembersRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUserGroupMembersResponse, error) {
+	var members []*identityv1.UserGroupMember
+	for _, id := range s.members[req.GetGroupId()] {
+		members = append(members, &identityv1.UserGroupMember{MemberId: memberID(id)})
+	}
+	return &cloudservicev1.GetUserGroupMembersResponse{Members: members}, nil
+}
+
+func (s *fakeCloudService) CreateUser(ctx context.Context, req *cloudservicev1.CreateUserRequest, opts ...grpc.CallOption) (*cloudservicev1.CreateUserResponse, error) {
+	s.calls = append(s.calls, "create "+req.GetSpec().GetEmail()+" "+req.GetSpec().GetAccess().GetAccountAccess().GetRole().String())
+	return &cloudservicev1.CreateUserResponse{UserId: "new"}, nil
+}
+
+func (s *fakeCloudService) AddUserGroupMember(ctx context.Context, req *cloudservicev1.AddUserGroupMemberRequest, opts ...grpc.CallOption) (*cloudservicev1.AddUserGroupMemberResponse, error) {
+	s.calls = append(s.calls, "add "+req.GetMemberId().GetUserId()+" to "+req.GetGroupId())
+	return &cloudservicev1.AddUserGroupMemberResponse{}, nil
+}
+
+func (s *fakeCloudService) RemoveUserGroupMember(ctx context.Context, req *cloudservicev1.RemoveUserGroupMemberRequest, opts ...grpc.CallOption) (*cloudservicev1.RemoveUserGroupMemberResponse, error) {
+	s.calls = append(s.calls, "remove "+req.GetMemberId().GetUserId()+" from "+req.GetGroupId())
+	return &cloudservicev1.RemoveUserGroupMemberResponse{}, nil
+}
+
+type staticSource map[string][]string
+
+func (s staticSource) Memberships(ctx context.Context) (map[string][]string, error) {
+	return s, nil
+}
+
+func newFake() *fakeCloudService {
+	return &fakeCloudService{
+		users: []*identityv1.User{
+			{Id: "u1", Spec: &identityv1.UserSpec{Email: "alice@example.com"}},
+			{Id: "u2", Spec: &identityv1.UserSpec{Email: "bob@example.com"}},
+			{Id: "u3", Spec: &identityv1.UserSpec{Email: "carol@example.com"}},
+		},
+		members: map[string][]string{"g1": {"u1", "u2"}, "g2": {"u3"}},
+	}
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	source := staticSource{
+		"developers": {"Alice@example.com", "carol@example.com", "dan@example.com"},
+		"g2":         {},
+		"unknown":    {"alice@example.com"},
+	}
+
+	t.Run("Dry Run", func(t *testing.T) {
+		fake := newFake()
+		plan, err := Sync(ctx, cloudservicemock.NewFakeCloudServiceClient(t, fake), source, Options{DryRun: true})
+		if err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		var changes []string
+		for _, c := range plan.Changes {
+			changes = append(changes, c.String())
+		}
+		want := []string{
+			"add carol@example.com to group developers",
+			"remove bob@example.com from group developers",
+			"remove carol@example.com from group operators",
+		}
+		if !reflect.DeepEqual(changes, want) {
+			t.Errorf("Sync() changes = %q, want %q", changes, want)
+		}
+		if !reflect.DeepEqual(plan.MissingUsers, map[string][]string{"developers": {"dan@example.com"}}) {
+			t.Errorf("Sync() missing users = %v", plan.MissingUsers)
+		}
+		if !reflect.DeepEqual(plan.UnmatchedGroups, []string{"unknown"}) {
+			t.Errorf("Sync() unmatched groups = %v, want unknown", plan.UnmatchedGroups)
+		}
+		if len(fake.calls) != 0 {
+			t.Errorf("Sync() calls = %v in a dry run", fake.calls)
+		}
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		fake := newFake()
+		plan, err := Sync(ctx, cloudservicemock.NewFakeCloudServiceClient(t, fake), source, Options{CreateUsers: true, AccountRole: identityv1.AccountAccess_ROLE_DEVELOPER})
+		if err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		want := []string{
+			"create dan@example.com ROLE_DEVELOPER",
+			"add u3 to g1",
+			"add new to g1",
+			"remove u2 from g1",
+			"remove u3 from g2",
+		}
+		if !reflect.DeepEqual(fake.calls, want) {
+			t.Errorf("Sync() calls = %q, want %q", fake.calls, want)
+		}
+		if len(plan.MissingUsers) != 0 || plan.Changes[2].UserID != "new" {
+			t.Errorf("Sync() plan = %+v", plan)
+		}
+	})
+
+	t.Run("Too Many Removals", func(t *testing.T) {
+		fake := newFake()
+		plan, err := Sync(ctx, cloudservicemock.NewFakeCloudServiceClient(t, fake), source, Options{MaxRemovals: 1})
+		if !errors.Is(err, ErrTooManyRemovals) || plan == nil || len(fake.calls) != 0 {
+			t.Errorf("Sync() error = %v, calls = %v, want ErrTooManyRemovals and no call", err, fake.calls)
+		}
+		if _, err := Sync(ctx, cloudservicemock.NewFakeCloudServiceClient(t, fake), source, Options{MaxRemovals: -1}); err != nil {
+			t.Errorf("Sync() error = %v, want no limit", err)
+		}
+	})
+
+	t.Run("Deleted User", func(t *testing.T) {
+		fake := newFake()
+		fake.users = append(fake.users, &identityv1.User{Id: "u4", State: resourcev1.ResourceState_RESOURCE_STATE_DELETING, Spec: &identityv1.UserSpec{Email: "dan@example.com"}})
+		plan, err := Sync(ctx, cloudservicemock.NewFakeCloudServiceClient(t, fake), source, Options{DryRun: true})
+		if err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		if !reflect.DeepEqual(plan.MissingUsers, map[string][]string{"developers": {"dan@example.com"}}) {
+			t.Errorf("Sync() missing users = %v, want dan@example.com", plan.MissingUsers)
+		}
+	})
+
+	t.Run("Not A Cloud Group", func(t *testing.T) {
+		_, err := Sync(ctx, cloudservicemock.NewFakeCloudServiceClient(t, newFake()), staticSource{"google": nil}, Options{})
+		if err == nil || !strings.Contains(err.Error(), "not managed by Temporal Cloud") {
+			t.Errorf("Sync() error = %v", err)
+		}
+	})
+}
+
+const ldif = `version: 1
+
+# Users
+dn: uid=alice,ou=people,dc=example,dc=com
+objectClass: inetOrgPerson
+uid: alice
+mail: alice@example.com
+
+dn: uid=bob,ou=people,dc=example,dc=com
+objectClass: inetOrgPerson
+uid: bob
+mail:: Ym9iQGV4YW1wbGUuY29t
+
+dn: uid=carol,ou=people,dc=example,dc=com
+objectClass: inetOrgPerson
+uid: carol
+mail: carol@exam
+ ple.com
+
+dn: cn=developers,ou=groups,dc=example,dc=com
+objectClass: groupOfNames
+cn: developers
+member: uid=alice, ou=people, dc=example, dc=com
+member: cn=operators,ou=groups,dc=example,dc=com
+
+dn: cn=operators,ou=groups,dc=example,dc=com
+objectClass: posixGroup
+cn: operators
+memberUid: bob
+memberUid: carol
+`
+
+func TestSources(t *testing.T) {
+	dir := t.TempDir()
+	ldifPath := filepath.Join(dir, "directory.ldif")
+	if err := os.WriteFile(ldifPath, []byte(ldif), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	memberships, err := LDIFSource{Path: ldifPath}.Memberships(context.Background())
+	if err != nil {
+		t.Fatalf("Memberships() error = %v", err)
+	}
+	want := map[string][]string{
+		"developers": {"alice@example.com", "bob@example.com", "carol@example.com"},
+		"operators":  {"bob@example.com", "carol@example.com"},
+	}
+	if !reflect.DeepEqual(memberships, want) {
+		t.Errorf("LDIFSource.Memberships() = %v, want %v", memberships, want)
+	}
+
+	memberships, err = LDIFSource{Path: ldifPath, Groups: map[string]string{"operators": "ops"}}.Memberships(context.Background())
+	if err != nil || !reflect.DeepEqual(memberships, map[string][]string{"ops": {"bob@example.com", "carol@example.com"}}) {
+		t.Errorf("LDIFSource.Memberships() = %v, %v, want only the mapped operators", memberships, err)
+	}
+
+	if err := os.WriteFile(ldifPath, []byte("dn: cn=g,dc=example\nobjectClass: groupOfNames\ncn: g\nmember: uid=eve,dc=example\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (LDIFSource{Path: ldifPath}).Memberships(context.Background()); err == nil || !strings.Contains(err.Error(), `member "uid=eve,dc=example" of group "cn=g,dc=example" not found`) {
+		t.Errorf("LDIFSource.Memberships() error = %v", err)
+	}
+
+	jsonPath := filepath.Join(dir, "memberships.json")
+	if err := os.WriteFile(jsonPath, []byte(`{"developers": ["alice@example.com"]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	memberships, err = FileSource{Path: jsonPath}.Memberships(context.Background())
+	if err != nil || !reflect.DeepEqual(memberships, map[string][]string{"developers": {"alice@example.com"}}) {
+		t.Errorf("FileSource.Memberships() = %v, %v", memberships, err)
+	}
+}
+
+func TestNormalizeDN(t *testing.T) {
+	for dn, want := range map[string]string{
+		"uid=alice, ou=People ,DC=example": "uid=alice,ou=people,dc=example",
+		`CN=Doe\, John, ou=people`:         `cn=doe\, john,ou=people`,
+		`cn=back\\, ou=people`:             `cn=back\\,ou=people`,
+		`cn=trailing\,,ou=people`:          `cn=trailing\,,ou=people`,
+	} {
+		if got := normalizeDN(dn); got != want {
+			t.Errorf("normalizeDN(%q) = %q, want %q", dn, got, want)
+		}
+	}
+}
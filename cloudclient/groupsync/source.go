@@ -0,0 +1,255 @@
+package groupsync
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+type (
+	// FileSource reads the memberships from a JSON file mapping group names to the emails of their members,
+	// such as {"developers": ["alice@example.com", "bob@example.com"]}.
+	// The file is read again on every sync.
+	FileSource struct {
+		Path string
+	}
+
+	// LDIFSource reads the memberships from an LDIF export of an LDAP directory.
+	// The file is read again on every sync.
+	//
+	// Groups are the entries with a groupOfNames, groupOfUniqueNames or posixGroup object class, named after
+	// their cn attribute. Their member and uniqueMember attributes reference the DNs of users, or of nested groups
+	// whose members are included, and their memberUid attributes the uid of users. The email of a user is its mail
+	// attribute, every referenced user must be an entry of the file.
+	LDIFSource struct {
+		Path string
+
+		// The name of the user group each LDAP group is synchronized to, by cn. Only the mapped groups are read,
+		// and LDAP groups mapped to the same user group are merged.
+		// If not provided, every LDAP group is read and synchronized to the user group named after its cn.
+		Groups map[string]string
+	}
+
+	// ldifEntry is an entry of an LDIF file, with its attributes by lower case name.
+	ldifEntry struct {
+		dn         string
+		attributes map[string][]string
+	}
+)
+
+func (s FileSource) Memberships(ctx context.Context) (map[string][]string, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the memberships file: %w", err)
+	}
+	var memberships map[string][]string
+	if err := json.Unmarshal(data, &memberships); err != nil {
+		return nil, fmt.Errorf("failed to parse the memberships file %q: %w", s.Path, err)
+	}
+	return memberships, nil
+}
+
+func (s LDIFSource) Memberships(ctx context.Context) (map[string][]string, error) {
+	f, err := os.Open(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the LDIF file: %w", err)
+	}
+	defer f.Close()
+	entries, err := parseLDIF(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the LDIF file %q: %w", s.Path, err)
+	}
+
+	byDN := map[string]*ldifEntry{}
+	byUID := map[string]*ldifEntry{}
+	for _, e := range entries {
+		byDN[normalizeDN(e.dn)] = e
+		for _, uid := range e.attributes["uid"] {
+			byUID[uid] = e
+		}
+	}
+	var members func(group *ldifEntry, visited map[*ldifEntry]bool) ([]string, error)
+	members = func(group *ldifEntry, visited map[*ldifEntry]bool) ([]string, error) {
+		visited[group] = true
+		var emails []string
+		for _, dn := range slices.Concat(group.attributes["member"], group.attributes["uniquemember"]) {
+			e, ok := byDN[normalizeDN(dn)]
+			if !ok {
+				return nil, fmt.Errorf("member %q of group %q not found", dn, group.dn)
+			}
+			if e.isGroup() {
+				if visited[e] {
+					continue
+				}
+				nested, err := members(e, visited)
+				if err != nil {
+					return nil, err
+				}
+				emails = append(emails, nested...)
+				continue
+			}
+			email, err := e.email()
+			if err != nil {
+				return nil, err
+			}
+			emails = append(emails, email)
+		}
+		for _, uid := range group.attributes["memberuid"] {
+			e, ok := byUID[uid]
+			if !ok {
+				return nil, fmt.Errorf("member uid %q of group %q not found", uid, group.dn)
+			}
+			email, err := e.email()
+			if err != nil {
+				return nil, err
+			}
+			emails = append(emails, email)
+		}
+		return emails, nil
+	}
+
+	memberships := map[string][]string{}
+	for _, e := range entries {
+		if !e.isGroup() {
+			continue
+		}
+		names := e.attributes["cn"]
+		if len(names) == 0 {
+			return nil, fmt.Errorf("group %q has no cn", e.dn)
+		}
+		name := names[0]
+		if s.Groups != nil {
+			mapped, ok := s.Groups[name]
+			if !ok {
+				continue
+			}
+			name = mapped
+		}
+		emails, err := members(e, map[*ldifEntry]bool{})
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, memberships[name]...)
+		slices.Sort(emails)
+		memberships[name] = slices.Compact(emails)
+	}
+	return memberships, nil
+}
+
+func (e *ldifEntry) isGroup() bool {
+	return slices.ContainsFunc(e.attributes["objectclass"], func(class string) bool {
+		switch strings.ToLower(class) {
+		case "groupofnames", "groupofuniquenames", "posixgroup":
+			return true
+		}
+		return false
+	})
+}
+
+func (e *ldifEntry) email() (string, error) {
+	if len(e.attributes["mail"]) == 0 {
+		return "", fmt.Errorf("user %q has no mail", e.dn)
+	}
+	return e.attributes["mail"][0], nil
+}
+
+// parseLDIF parses the entries of an LDIF file. Change records and URL values are not supported.
+func parseLDIF(r io.Reader) ([]*ldifEntry, error) {
+	var (
+		entries []*ldifEntry
+		entry   *ldifEntry
+		lines   []string
+		numbers []int
+	)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.HasPrefix(line, " ") && len(lines) > 0 {
+			// A folded line continues the previous one.
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+		numbers = append(numbers, n)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, line := range lines {
+		n := numbers[i]
+		if line == "" {
+			entry = nil
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			return nil, fmt.Errorf("line %d: missing attribute separator", n)
+		}
+		switch {
+		case strings.HasPrefix(value, ":"):
+			decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value[1:]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid base64 value: %w", n, err)
+			}
+			value = string(decoded)
+		case strings.HasPrefix(value, "<"):
+			return nil, fmt.Errorf("line %d: URL values are not supported", n)
+		default:
+			value = strings.TrimLeft(value, " ")
+		}
+		name = strings.ToLower(name)
+		if entry == nil {
+			if name == "version" {
+				continue
+			}
+			if name != "dn" {
+				return nil, fmt.Errorf("line %d: expected a dn to start an entry, got %q", n, name)
+			}
+			entry = &ldifEntry{dn: value, attributes: map[string][]string{}}
+			entries = append(entries, entry)
+			continue
+		}
+		if name == "changetype" {
+			return nil, fmt.Errorf("line %d: change records are not supported", n)
+		}
+		entry.attributes[name] = append(entry.attributes[name], value)
+	}
+	return entries, nil
+}
+
+// normalizeDN returns the DN in lower case without spaces around its separators, so that equivalent DNs compare equal.
+func normalizeDN(dn string) string {
+	rdns := splitDN(dn)
+	for i, rdn := range rdns {
+		name, value, _ := strings.Cut(rdn, "=")
+		rdns[i] = strings.TrimSpace(name) + "=" + strings.TrimSpace(value)
+	}
+	return strings.ToLower(strings.Join(rdns, ","))
+}
+
+// splitDN splits the DN into its RDNs, on the commas that are not escaped by a backslash.
+func splitDN(dn string) []string {
+	var rdns []string
+	start := 0
+	for i := 0; i < len(dn); i++ {
+		switch dn[i] {
+		case '\\':
+			// The escaped character is part of the value.
+			i++
+		case ',':
+			rdns = append(rdns, dn[start:i])
+			start = i + 1
+		}
+	}
+	return append(rdns, dn[start:])
+}
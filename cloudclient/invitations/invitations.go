@@ -0,0 +1,210 @@
+// Package invitations tracks the invitations of Temporal Cloud users that were not accepted yet, and re-invites
+// the users whose invitation expired.
+//
+// WARNING: The package is currently experimental.
+//
+// An invitation cannot be sent again through the cloud operations API, a user is re-invited by deleting it and
+// creating it again with the same email and access, which sends a new invitation. The user gets a new id, and
+// the user group memberships of the deleted user are not carried over.
+package invitations
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	"go.temporal.io/cloud-sdk/internal/asyncop"
+	"go.temporal.io/cloud-sdk/internal/paging"
+)
+
+// The statuses of an invitation.
+const (
+	StatusPending Status = "pending"
+	StatusExpired Status = "expired"
+)
+
+// The outcomes of a re-invite.
+const (
+	// The user was deleted and created again.
+	Reinvited Outcome = "reinvited"
+	// The user would have been re-invited, but Options.DryRun is set.
+	WouldReinvite Outcome = "would_reinvite"
+	// The user was not re-invited since it does not match Options.Allowlist.
+	NotAllowed Outcome = "not_allowed"
+	// The user was not re-invited since its invitation was accepted, or renewed, after the users were listed.
+	NotExpired Outcome = "not_expired"
+)
+
+type (
+	// Status is the status of an invitation.
+	Status string
+
+	// Outcome is the outcome of the re-invite of a user.
+	Outcome string
+
+	// Invitation is the open invitation of a user.
+	Invitation struct {
+		UserID string
+		Email  string
+		// The spec of the user when it was listed, a re-invite creates the user with its latest spec.
+		Spec   *identityv1.UserSpec
+		Status Status
+		// When the invitation was sent.
+		Created time.Time
+		// When the invitation expires, or expired.
+		Expires time.Time
+		// How long ago the invitation was sent.
+		Age time.Duration
+
+		// The outcome of the re-invite, empty if the user was not considered for one.
+		Outcome Outcome
+		// The id of the user created by the re-invite.
+		NewUserID string
+		// The error that stopped the re-invite, nil on success.
+		Err error
+	}
+
+	// Options configure Check.
+	Options struct {
+		// Whether to re-invite the users whose invitation expired.
+		Reinvite bool
+
+		// Whether to only report the users that would be re-invited, without changing the account.
+		DryRun bool
+
+		// The users that can be re-invited, by email, such as "alice@example.com", by email domain, such as
+		// "@example.com", or "*" for every user. Emails are compared case insensitively.
+		// If not provided, no user is re-invited.
+		Allowlist []string
+
+		// The time the ages and statuses of the invitations are computed at.
+		// If not provided, the current time is used.
+		Now time.Time
+
+		// The interval between two checks of an async operation.
+		// If not provided, the interval suggested by the server is used.
+		PollInterval time.Duration
+	}
+)
+
+// Check lists the users with an open invitation, oldest first, and re-invites the users whose invitation expired
+// if Options.Reinvite is set.
+//
+// The returned error is only set if the users could not be listed, the failures of each re-invite are in the
+// invitations.
+func Check(ctx context.Context, client cloudservicev1.CloudServiceClient, options Options) ([]*Invitation, error) {
+	now := options.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	users, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.User, string, error) {
+		resp, err := client.GetUsers(ctx, &cloudservicev1.GetUsersRequest{PageToken: pageToken})
+		return resp.GetUsers(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the users: %w", err)
+	}
+
+	var invitations []*Invitation
+	for _, u := range users {
+		invitation := u.GetInvitation()
+		if invitation == nil {
+			continue
+		}
+		i := &Invitation{
+			UserID:  u.GetId(),
+			Email:   u.GetSpec().GetEmail(),
+			Spec:    u.GetSpec(),
+			Status:  StatusPending,
+			Created: invitation.GetCreatedTime().AsTime(),
+			Expires: invitation.GetExpiredTime().AsTime(),
+		}
+		i.Age = now.Sub(i.Created)
+		if invitation.GetExpiredTime() != nil && !now.Before(i.Expires) {
+			i.Status = StatusExpired
+		}
+		invitations = append(invitations, i)
+	}
+	slices.SortFunc(invitations, func(i1, i2 *Invitation) int {
+		return cmp.Or(i1.Created.Compare(i2.Created), cmp.Compare(i1.Email, i2.Email))
+	})
+
+	if !options.Reinvite {
+		return invitations, nil
+	}
+	for _, i := range invitations {
+		if i.Status != StatusExpired {
+			continue
+		}
+		switch {
+		case !allowed(options.Allowlist, i.Email):
+			i.Outcome = NotAllowed
+		case options.DryRun:
+			i.Outcome = WouldReinvite
+		default:
+			i.Outcome, i.NewUserID, i.Err = reinvite(ctx, client, i.UserID, now, options.PollInterval)
+		}
+	}
+	return invitations, nil
+}
+
+// reinvite deletes the user and creates it again with the same spec, if its invitation is still expired, and
+// returns the outcome and the id of the new user. The outcome is empty on failure.
+func reinvite(
+	ctx context.Context,
+	client cloudservicev1.CloudServiceClient,
+	userID string,
+	now time.Time,
+	pollInterval time.Duration,
+) (Outcome, string, error) {
+	latest, err := client.GetUser(ctx, &cloudservicev1.GetUserRequest{UserId: userID})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get the user: %w", err)
+	}
+	// The user may have accepted its invitation, or have been invited again, since the users were listed.
+	invitation := latest.GetUser().GetInvitation()
+	if invitation.GetExpiredTime() == nil || now.Before(invitation.GetExpiredTime().AsTime()) {
+		return NotExpired, "", nil
+	}
+	spec := latest.GetUser().GetSpec()
+	deleted, err := client.DeleteUser(ctx, &cloudservicev1.DeleteUserRequest{
+		UserId:          userID,
+		ResourceVersion: latest.GetUser().GetResourceVersion(),
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to delete the user: %w", err)
+	}
+	if _, err := asyncop.Wait(ctx, client, deleted.GetAsyncOperation().GetId(), pollInterval); err != nil {
+		return "", "", fmt.Errorf("failed to delete the user: %w", err)
+	}
+	created, err := client.CreateUser(ctx, &cloudservicev1.CreateUserRequest{
+		Spec: &identityv1.UserSpec{
+			Email:  spec.GetEmail(),
+			Access: spec.GetAccess(),
+		},
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create the user again, it was deleted: %w", err)
+	}
+	if _, err := asyncop.Wait(ctx, client, created.GetAsyncOperation().GetId(), pollInterval); err != nil {
+		return "", created.GetUserId(), fmt.Errorf("failed to create the user again, it was deleted: %w", err)
+	}
+	return Reinvited, created.GetUserId(), nil
+}
+
+// allowed reports whether the email matches the allowlist, an empty allowlist matches no email.
+func allowed(allowlist []string, email string) bool {
+	email = strings.ToLower(email)
+	for _, entry := range allowlist {
+		entry = strings.ToLower(entry)
+		if entry == "*" || email == entry || (strings.HasPrefix(entry, "@") && strings.HasSuffix(email, entry)) {
+			return true
+		}
+	}
+	return false
+}
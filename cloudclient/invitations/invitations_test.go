@@ -0,0 +1,170 @@
+package invitations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/cloudservicemock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+type fakeCloudService struct {
+	users []*identityv1.User
+	// The users returned by GetUser, by id, if they changed since they were listed.
+	latest  map[string]*identityv1.User
+	deleted []string
+	created []*identityv1.UserSpec
+}
+
+func (s *fakeCloudService) GetUsers(ctx context.Context, req *cloudservicev1.GetUsersRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUsersResponse, error) {
+	return &cloudservicev1.GetUsersResponse{Users: s.users}, nil
+}
+
+func (s *fakeCloudService) GetUser(ctx context.Context, req *cloudservicev1.GetUserRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUserResponse, error) {
+	if u, ok := s.latest[req.GetUserId()]; ok {
+		return &cloudservicev1.GetUserResponse{User: u}, nil
+	}
+	for _, u := range s.users {
+		if u.GetId() == req.GetUserId() {
+			return &cloudservicev1.GetUserResponse{User: u}, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "user not found")
+}
+
+func (s *fakeCloudService) DeleteUser(ctx context.Context, req *cloudservicev1.DeleteUserRequest, opts ...grpc.CallOption) (*cloudservicev1.DeleteUserResponse, error) {
+	s.deleted = append(s.deleted, req.GetUserId())
+	return &cloudservicev1.DeleteUserResponse{}, nil
+}
+
+func (s *fakeCloudService) CreateUser(ctx context.Context, req *cloudservicev1.CreateUserRequest, opts ...grpc.CallOption) (*cloudservicev1.CreateUserResponse, error) {
+	s.created = append(s.created, req.GetSpec())
+	return &cloudservicev1.CreateUserResponse{UserId: "new-" + req.GetSpec().GetEmail()}, nil
+}
+
+func user(id, email string, invitedDaysAgo int) *identityv1.User {
+	u := &identityv1.User{Id: id, Spec: &identityv1.UserSpec{
+		Email:  email,
+		Access: &identityv1.Access{AccountAccess: &identityv1.AccountAccess{Role: identityv1.AccountAccess_ROLE_DEVELOPER}},
+	}}
+	if invitedDaysAgo > 0 {
+		created := now.AddDate(0, 0, -invitedDaysAgo)
+		u.Invitation = &identityv1.Invitation{
+			CreatedTime: timestamppb.New(created),
+			ExpiredTime: timestamppb.New(created.AddDate(0, 0, 7)),
+		}
+	}
+	return u
+}
+
+func newFake() *fakeCloudService {
+	return &fakeCloudService{users: []*identityv1.User{
+		user("u1", "alice@example.com", 0),
+		user("u2", "bob@example.com", 10),
+		user("u3", "carol@example.com", 2),
+		user("u4", "dan@partner.com", 30),
+	}}
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("List", func(t *testing.T) {
+		fake := newFake()
+		invitations, err := Check(ctx, cloudservicemock.NewFakeCloudServiceClient(t, fake), Options{Now: now})
+		if err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+		if len(invitations) != 3 {
+			t.Fatalf("Check() = %v, want 3 invitations", invitations)
+		}
+		dan, bob, carol := invitations[0], invitations[1], invitations[2]
+		if dan.Email != "dan@partner.com" || dan.Status != StatusExpired || dan.Age != 30*24*time.Hour ||
+			bob.Status != StatusExpired || carol.Status != StatusPending || carol.Outcome != "" {
+			t.Errorf("Check() = %+v, %+v, %+v", dan, bob, carol)
+		}
+		if len(fake.deleted) != 0 {
+			t.Errorf("Check() deleted %v, want no re-invite", fake.deleted)
+		}
+	})
+
+	t.Run("Dry Run", func(t *testing.T) {
+		fake := newFake()
+		invitations, err := Check(ctx, cloudservicemock.NewFakeCloudServiceClient(t, fake), Options{Now: now, Reinvite: true, DryRun: true, Allowlist: []string{"@Example.com"}})
+		if err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+		if invitations[0].Outcome != NotAllowed || invitations[1].Outcome != WouldReinvite || invitations[2].Outcome != "" {
+			t.Errorf("Check() outcomes = %s, %s, %s", invitations[0].Outcome, invitations[1].Outcome, invitations[2].Outcome)
+		}
+		if len(fake.deleted) != 0 || len(fake.created) != 0 {
+			t.Errorf("Check() deleted %v and created %v in a dry run", fake.deleted, fake.created)
+		}
+	})
+
+	t.Run("Allowlist", func(t *testing.T) {
+		for _, tc := range []struct {
+			allowlist []string
+			want      Outcome
+		}{
+			{nil, NotAllowed},
+			{[]string{"*"}, WouldReinvite},
+		} {
+			fake := newFake()
+			invitations, err := Check(ctx, cloudservicemock.NewFakeCloudServiceClient(t, fake), Options{Now: now, Reinvite: true, DryRun: true, Allowlist: tc.allowlist})
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if invitations[0].Outcome != tc.want || invitations[1].Outcome != tc.want {
+				t.Errorf("Check(%q) outcomes = %s, %s, want %s", tc.allowlist, invitations[0].Outcome, invitations[1].Outcome, tc.want)
+			}
+		}
+	})
+
+	t.Run("Reinvite", func(t *testing.T) {
+		fake := newFake()
+		invitations, err := Check(ctx, cloudservicemock.NewFakeCloudServiceClient(t, fake), Options{Now: now, Reinvite: true, Allowlist: []string{"bob@example.com", "dan@partner.com"}})
+		if err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+		dan := invitations[0]
+		if dan.Outcome != Reinvited || dan.Err != nil || dan.NewUserID != "new-dan@partner.com" {
+			t.Errorf("Check() dan = %+v", dan)
+		}
+		if len(fake.deleted) != 2 || fake.deleted[0] != "u4" || fake.deleted[1] != "u2" {
+			t.Errorf("Check() deleted %v, want u4 and u2", fake.deleted)
+		}
+		if len(fake.created) != 2 || fake.created[0].GetAccess().GetAccountAccess().GetRole() != identityv1.AccountAccess_ROLE_DEVELOPER {
+			t.Errorf("Check() created %v, want the same access", fake.created)
+		}
+	})
+
+	t.Run("Changed Since Listed", func(t *testing.T) {
+		fake := newFake()
+		// Bob accepted the invitation, and the access of Dan was changed, after the users were listed.
+		bob, dan := user("u2", "bob@example.com", 0), user("u4", "dan@partner.com", 30)
+		dan.Spec.Access = &identityv1.Access{AccountAccess: &identityv1.AccountAccess{Role: identityv1.AccountAccess_ROLE_READ}}
+		fake.latest = map[string]*identityv1.User{"u2": bob, "u4": dan}
+		invitations, err := Check(ctx, cloudservicemock.NewFakeCloudServiceClient(t, fake), Options{Now: now, Reinvite: true, Allowlist: []string{"*"}})
+		if err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+		if invitations[0].Outcome != Reinvited || invitations[1].Outcome != NotExpired {
+			t.Errorf("Check() outcomes = %s, %s", invitations[0].Outcome, invitations[1].Outcome)
+		}
+		if len(fake.deleted) != 1 || fake.deleted[0] != "u4" {
+			t.Errorf("Check() deleted %v, want u4", fake.deleted)
+		}
+		if len(fake.created) != 1 || fake.created[0].GetAccess().GetAccountAccess().GetRole() != identityv1.AccountAccess_ROLE_READ {
+			t.Errorf("Check() created %v, want the latest access", fake.created)
+		}
+	})
+}
@@ -0,0 +1,426 @@
+// Package janitor finds the unused and stale identities and resources of a Temporal Cloud account, and cleans them up.
+//
+// WARNING: The package is currently experimental.
+//
+// Scan only reports findings, each with the reasons it was flagged. Nothing is deleted until the findings are passed
+// to Apply, which deletes them with the resource versions seen by Scan: a resource changed since it was scanned is
+// not deleted.
+package janitor
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	connectivityrulev1 "go.temporal.io/cloud-sdk/api/connectivityrule/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	operationv1 "go.temporal.io/cloud-sdk/api/operation/v1"
+	"go.temporal.io/cloud-sdk/internal/apikeys"
+	"go.temporal.io/cloud-sdk/internal/asyncop"
+	"go.temporal.io/cloud-sdk/internal/csvsafe"
+	"go.temporal.io/cloud-sdk/internal/paging"
+	"go.temporal.io/cloud-sdk/internal/resources"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The kinds of resources found, in the order they are reported and deleted.
+const (
+	KindAPIKey           Kind = "api_key"
+	KindUser             Kind = "user"
+	KindUserGroup        Kind = "user_group"
+	KindServiceAccount   Kind = "service_account"
+	KindConnectivityRule Kind = "connectivity_rule"
+)
+
+// DefaultMinAge is the default of Options.MinAge.
+const DefaultMinAge = 7 * 24 * time.Hour
+
+var kinds = []Kind{KindAPIKey, KindUser, KindUserGroup, KindServiceAccount, KindConnectivityRule}
+
+type (
+	// Kind is a kind of resource.
+	Kind string
+
+	// Finding is a resource flagged as unused or stale.
+	Finding struct {
+		Kind Kind
+		ID   string
+		// A human readable name of the resource, such as the email of a user, empty if it has none.
+		Name string
+		// The version of the resource when it was scanned, Apply only deletes this version.
+		ResourceVersion string
+		// Why the resource was flagged.
+		Reasons []string
+
+		// Whether Apply deleted the resource.
+		Deleted bool
+		// The error that stopped Apply from deleting the resource, nil on success.
+		Err error
+	}
+
+	// Options configure Scan.
+	Options struct {
+		// The kinds of resources to scan.
+		// If not provided, every kind is scanned.
+		Kinds []Kind
+
+		// The minimum age of the user groups, service accounts and connectivity rules flagged as unused, so that
+		// the ones just created and not in use yet are left alone. A negative age flags them regardless of age.
+		// If not provided, DefaultMinAge is used.
+		MinAge time.Duration
+
+		// The time the expirations and ages are computed at.
+		// If not provided, the current time is used.
+		Now time.Time
+	}
+
+	// ApplyOptions configure Apply.
+	ApplyOptions struct {
+		// The logger every deletion and every failure is logged to.
+		// If not provided, slog.Default() is used.
+		Logger *slog.Logger
+
+		// The interval between two checks of an async operation.
+		// If not provided, the interval suggested by the server is used.
+		PollInterval time.Duration
+	}
+
+	scanner struct {
+		client   cloudservicev1.CloudServiceClient
+		options  Options
+		findings []*Finding
+	}
+)
+
+// Scan lists the resources of the account and returns the ones flagged as unused or stale:
+//   - API keys that are disabled, expired, or owned by a user or service account that no longer exists,
+//   - users whose invitation expired without being accepted,
+//   - cloud user groups without members, the members of the other groups are managed by their identity provider,
+//   - service accounts without API keys,
+//   - connectivity rules not used by any namespace.
+//
+// The resources already deleted, or being deleted, are ignored.
+// The findings are sorted by kind, then name and id. The account is not changed.
+func Scan(ctx context.Context, client cloudservicev1.CloudServiceClient, options Options) ([]*Finding, error) {
+	if options.MinAge == 0 {
+		options.MinAge = DefaultMinAge
+	}
+	if options.Now.IsZero() {
+		options.Now = time.Now()
+	}
+	if len(options.Kinds) == 0 {
+		options.Kinds = kinds
+	}
+	for _, kind := range options.Kinds {
+		if !slices.Contains(kinds, kind) {
+			return nil, fmt.Errorf("unknown kind %q", kind)
+		}
+	}
+	s := &scanner{client: client, options: options}
+
+	users, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.User, string, error) {
+		resp, err := client.GetUsers(ctx, &cloudservicev1.GetUsersRequest{PageToken: pageToken})
+		return resp.GetUsers(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the users: %w", err)
+	}
+	serviceAccounts, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.ServiceAccount, string, error) {
+		resp, err := client.GetServiceAccounts(ctx, &cloudservicev1.GetServiceAccountsRequest{PageToken: pageToken})
+		return resp.GetServiceAccount(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the service accounts: %w", err)
+	}
+	keys, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.ApiKey, string, error) {
+		resp, err := client.GetApiKeys(ctx, &cloudservicev1.GetApiKeysRequest{PageToken: pageToken})
+		return resp.GetApiKeys(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the API keys: %w", err)
+	}
+	// the resources already being deleted are neither flagged, nor owners or owned.
+	users = slices.DeleteFunc(users, func(u *identityv1.User) bool { return resources.Deleted(u.GetState()) })
+	serviceAccounts = slices.DeleteFunc(serviceAccounts, func(sa *identityv1.ServiceAccount) bool { return resources.Deleted(sa.GetState()) })
+	keys = slices.DeleteFunc(keys, func(key *identityv1.ApiKey) bool { return resources.Deleted(key.GetState()) })
+
+	if s.scans(KindAPIKey) {
+		s.scanAPIKeys(keys, users, serviceAccounts)
+	}
+	if s.scans(KindUser) {
+		s.scanUsers(users)
+	}
+	if s.scans(KindUserGroup) {
+		if err := s.scanUserGroups(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if s.scans(KindServiceAccount) {
+		s.scanServiceAccounts(serviceAccounts, keys)
+	}
+	if s.scans(KindConnectivityRule) {
+		if err := s.scanConnectivityRules(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	slices.SortStableFunc(s.findings, func(f1, f2 *Finding) int {
+		if c := slices.Index(kinds, f1.Kind) - slices.Index(kinds, f2.Kind); c != 0 {
+			return c
+		}
+		if c := strings.Compare(f1.Name, f2.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(f1.ID, f2.ID)
+	})
+	return s.findings, nil
+}
+
+func (s *scanner) scans(kind Kind) bool {
+	return slices.Contains(s.options.Kinds, kind)
+}
+
+func (s *scanner) add(kind Kind, id, name, resourceVersion string, reasons ...string) {
+	if len(reasons) == 0 {
+		return
+	}
+	s.findings = append(s.findings, &Finding{
+		Kind:            kind,
+		ID:              id,
+		Name:            name,
+		ResourceVersion: resourceVersion,
+		Reasons:         reasons,
+	})
+}
+
+// oldEnough reports whether a resource created at the given time is older than Options.MinAge.
+// A resource without creation time is considered old enough.
+func (s *scanner) oldEnough(created *timestamppb.Timestamp) bool {
+	return s.options.MinAge < 0 || created == nil || s.options.Now.Sub(created.AsTime()) >= s.options.MinAge
+}
+
+func (s *scanner) scanAPIKeys(keys []*identityv1.ApiKey, users []*identityv1.User, serviceAccounts []*identityv1.ServiceAccount) {
+	userIDs := map[string]bool{}
+	for _, u := range users {
+		userIDs[u.GetId()] = true
+	}
+	serviceAccountIDs := map[string]bool{}
+	for _, sa := range serviceAccounts {
+		serviceAccountIDs[sa.GetId()] = true
+	}
+	for _, key := range keys {
+		spec := key.GetSpec()
+		var reasons []string
+		if spec.GetDisabled() {
+			reasons = append(reasons, "disabled")
+		}
+		if expiry := spec.GetExpiryTime(); expiry != nil && !s.options.Now.Before(expiry.AsTime()) {
+			reasons = append(reasons, "expired on "+expiry.AsTime().Format(time.RFC3339))
+		}
+		ownerID := spec.GetOwnerId()
+		switch apikeys.OwnerType(spec) {
+		case identityv1.OwnerType_OWNER_TYPE_USER:
+			if !userIDs[ownerID] {
+				reasons = append(reasons, fmt.Sprintf("owner user %s no longer exists", ownerID))
+			}
+		case identityv1.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT:
+			if !serviceAccountIDs[ownerID] {
+				reasons = append(reasons, fmt.Sprintf("owner service account %s no longer exists", ownerID))
+			}
+		default:
+			if !userIDs[ownerID] && !serviceAccountIDs[ownerID] {
+				reasons = append(reasons, fmt.Sprintf("owner %s no longer exists", ownerID))
+			}
+		}
+		s.add(KindAPIKey, key.GetId(), spec.GetDisplayName(), key.GetResourceVersion(), reasons...)
+	}
+}
+
+func (s *scanner) scanUsers(users []*identityv1.User) {
+	for _, u := range users {
+		invitation := u.GetInvitation()
+		if invitation == nil || invitation.GetExpiredTime() == nil || s.options.Now.Before(invitation.GetExpiredTime().AsTime()) {
+			continue
+		}
+		s.add(KindUser, u.GetId(), u.GetSpec().GetEmail(), u.GetResourceVersion(), fmt.Sprintf(
+			"invitation sent on %s expired on %s without being accepted",
+			invitation.GetCreatedTime().AsTime().Format(time.RFC3339),
+			invitation.GetExpiredTime().AsTime().Format(time.RFC3339),
+		))
+	}
+}
+
+func (s *scanner) scanUserGroups(ctx context.Context) error {
+	groups, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.UserGroup, string, error) {
+		resp, err := s.client.GetUserGroups(ctx, &cloudservicev1.GetUserGroupsRequest{PageToken: pageToken})
+		return resp.GetGroups(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the user groups: %w", err)
+	}
+	for _, g := range groups {
+		if g.GetSpec().GetCloudGroup() == nil || resources.Deleted(g.GetState()) || !s.oldEnough(g.GetCreatedTime()) {
+			continue
+		}
+		resp, err := s.client.GetUserGroupMembers(ctx, &cloudservicev1.GetUserGroupMembersRequest{GroupId: g.GetId(), PageSize: 1})
+		if err != nil {
+			return fmt.Errorf("failed to list the members of user group %s: %w", g.GetId(), err)
+		}
+		if len(resp.GetMembers()) == 0 {
+			s.add(KindUserGroup, g.GetId(), g.GetSpec().GetDisplayName(), g.GetResourceVersion(), "no members")
+		}
+	}
+	return nil
+}
+
+func (s *scanner) scanServiceAccounts(serviceAccounts []*identityv1.ServiceAccount, keys []*identityv1.ApiKey) {
+	owners := map[string]bool{}
+	for _, key := range keys {
+		owners[key.GetSpec().GetOwnerId()] = true
+	}
+	for _, sa := range serviceAccounts {
+		if owners[sa.GetId()] || !s.oldEnough(sa.GetCreatedTime()) {
+			continue
+		}
+		s.add(KindServiceAccount, sa.GetId(), sa.GetSpec().GetName(), sa.GetResourceVersion(), "no API keys")
+	}
+}
+
+func (s *scanner) scanConnectivityRules(ctx context.Context) error {
+	rules, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*connectivityrulev1.ConnectivityRule, string, error) {
+		resp, err := s.client.GetConnectivityRules(ctx, &cloudservicev1.GetConnectivityRulesRequest{PageToken: pageToken})
+		return resp.GetConnectivityRules(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the connectivity rules: %w", err)
+	}
+	namespaces, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*namespacev1.Namespace, string, error) {
+		resp, err := s.client.GetNamespaces(ctx, &cloudservicev1.GetNamespacesRequest{PageToken: pageToken})
+		return resp.GetNamespaces(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the namespaces: %w", err)
+	}
+	used := map[string]bool{}
+	for _, ns := range namespaces {
+		for _, id := range ns.GetSpec().GetConnectivityRuleIds() {
+			used[id] = true
+		}
+	}
+	for _, rule := range rules {
+		if used[rule.GetId()] || resources.Deleted(rule.GetState()) || !s.oldEnough(rule.GetCreatedTime()) {
+			continue
+		}
+		name := "public"
+		if private := rule.GetSpec().GetPrivateRule(); private != nil {
+			name = "private " + private.GetConnectionId()
+		}
+		s.add(KindConnectivityRule, rule.GetId(), name, rule.GetResourceVersion(), "not used by any namespace")
+	}
+	return nil
+}
+
+// Apply deletes the resources of the findings not deleted yet, in the order of the kinds, and logs every deletion.
+// A resource changed since it was scanned is not deleted.
+//
+// Apply carries on after a failure, the failures are set on the findings and the returned error summarizes them.
+// Running it again with the same findings retries the failed deletions.
+func Apply(ctx context.Context, client cloudservicev1.CloudServiceClient, findings []*Finding, options ApplyOptions) error {
+	logger := options.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	ordered := slices.Clone(findings)
+	slices.SortStableFunc(ordered, func(f1, f2 *Finding) int {
+		return slices.Index(kinds, f1.Kind) - slices.Index(kinds, f2.Kind)
+	})
+	var failed int
+	for _, f := range ordered {
+		if f.Deleted {
+			continue
+		}
+		attrs := []any{"kind", f.Kind, "id", f.ID, "name", f.Name, "reasons", strings.Join(f.Reasons, "; ")}
+		op, err := deleteResource(ctx, client, f)
+		if err == nil {
+			_, err = asyncop.Wait(ctx, client, op.GetId(), options.PollInterval)
+		}
+		if f.Err = err; err != nil {
+			failed++
+			logger.ErrorContext(ctx, "failed to delete resource", append(attrs, "error", err)...)
+			continue
+		}
+		f.Deleted = true
+		logger.InfoContext(ctx, "deleted resource", attrs...)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d resources", failed, len(findings))
+	}
+	return nil
+}
+
+// deleteResource starts the deletion of the resource of a finding.
+func deleteResource(ctx context.Context, client cloudservicev1.CloudServiceClient, f *Finding) (*operationv1.AsyncOperation, error) {
+	var (
+		resp interface {
+			GetAsyncOperation() *operationv1.AsyncOperation
+		}
+		err error
+	)
+	switch f.Kind {
+	case KindAPIKey:
+		resp, err = client.DeleteApiKey(ctx, &cloudservicev1.DeleteApiKeyRequest{KeyId: f.ID, ResourceVersion: f.ResourceVersion})
+	case KindUser:
+		resp, err = client.DeleteUser(ctx, &cloudservicev1.DeleteUserRequest{UserId: f.ID, ResourceVersion: f.ResourceVersion})
+	case KindUserGroup:
+		resp, err = client.DeleteUserGroup(ctx, &cloudservicev1.DeleteUserGroupRequest{GroupId: f.ID, ResourceVersion: f.ResourceVersion})
+	case KindServiceAccount:
+		resp, err = client.DeleteServiceAccount(ctx, &cloudservicev1.DeleteServiceAccountRequest{ServiceAccountId: f.ID, ResourceVersion: f.ResourceVersion})
+	case KindConnectivityRule:
+		resp, err = client.DeleteConnectivityRule(ctx, &cloudservicev1.DeleteConnectivityRuleRequest{ConnectivityRuleId: f.ID, ResourceVersion: f.ResourceVersion})
+	default:
+		return nil, fmt.Errorf("unknown kind %q", f.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetAsyncOperation(), nil
+}
+
+var csvHeader = []string{"kind", "id", "name", "resource_version", "reasons", "deleted", "error"}
+
+// WriteCSV writes the findings as CSV, with a header row, one row per finding and the reasons separated by "; ".
+// The names, reasons and errors starting with =, +, -, @, a tab or a carriage return are prefixed with a quote, for
+// spreadsheets to not evaluate them as formulas.
+func WriteCSV(w io.Writer, findings []*Finding) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, f := range findings {
+		var errMsg string
+		if f.Err != nil {
+			errMsg = f.Err.Error()
+		}
+		if err := cw.Write([]string{
+			string(f.Kind),
+			f.ID,
+			csvsafe.Value(f.Name),
+			f.ResourceVersion,
+			csvsafe.Value(strings.Join(f.Reasons, "; ")),
+			strconv.FormatBool(f.Deleted),
+			csvsafe.Value(errMsg),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
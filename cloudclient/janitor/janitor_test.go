@@ -0,0 +1,300 @@
+package janitor
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	connectivityrulev1 "go.temporal.io/cloud-sdk/api/connectivityrule/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	operationv1 "go.temporal.io/cloud-sdk/api/operation/v1"
+	resourcev1 "go.temporal.io/cloud-sdk/api/resource/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/cloudservicemock"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var now = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func ago(d time.Duration) *timestamppb.Timestamp {
+	return timestamppb.New(now.Add(-d))
+}
+
+type fakeCloudService struct {
+	users           []*identityv1.User
+	serviceAccounts []*identityv1.ServiceAccount
+	keys            []*identityv1.ApiKey
+	groups          []*identityv1.UserGroup
+	members         map[string]int
+	rules           []*connectivityrulev1.ConnectivityRule
+	namespaces      []*namespacev1.Namespace
+
+	deleted []string
+}
+
+func (f *fakeCloudService) GetUsers(ctx context.Context, req *cloudservicev1.GetUsersRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUsersResponse, error) {
+	return &cloudservicev1.GetUsersResponse{Users: f.users}, nil
+}
+
+func (f *fakeCloudService) GetServiceAccounts(ctx context.Context, req *cloudservicev1.GetServiceAccountsRequest, opts ...grpc.CallOption) (*cloudservicev1.GetServiceAccountsResponse, error) {
+	return &cloudservicev1.GetServiceAccountsResponse{ServiceAccount: f.serviceAccounts}, nil
+}
+
+func (f *fakeCloudService) GetApiKeys(ctx context.Context, req *cloudservicev1.GetApiKeysRequest, opts ...grpc.CallOption) (*cloudservicev1.GetApiKeysResponse, error) {
+	return &cloudservicev1.GetApiKeysResponse{ApiKeys: f.keys}, nil
+}
+
+func (f *fakeCloudService) GetUserGroups(ctx context.Context, req *cloudservicev1.GetUserGroupsRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUserGroupsResponse, error) {
+	return &cloudservicev1.GetUserGroupsResponse{Groups: f.groups}, nil
+}
+
+func (f *fakeCloudService) GetUserGroupMembers(ctx context.Context, req *cloudservicev1.GetUserGroupMembersRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUserGroupMembersResponse, error) {
+	resp := &cloudservicev1.GetUserGroupMembersResponse{}
+	for range f.members[req.GetGroupId()] {
+		resp.Members = append(resp.Members, &identityv1.UserGroupMember{})
+	}
+	return resp, nil
+}
+
+func (f *fakeCloudService) GetConnectivityRules(ctx context.Context, req *cloudservicev1.GetConnectivityRulesRequest, opts ...grpc.CallOption) (*cloudservicev1.GetConnectivityRulesResponse, error) {
+	return &cloudservicev1.GetConnectivityRulesResponse{ConnectivityRules: f.rules}, nil
+}
+
+func (f *fakeCloudService) GetNamespaces(ctx context.Context, req *cloudservicev1.GetNamespacesRequest, opts ...grpc.CallOption) (*cloudservicev1.GetNamespacesResponse, error) {
+	return &cloudservicev1.GetNamespacesResponse{Namespaces: f.namespaces}, nil
+}
+
+func (f *fakeCloudService) DeleteApiKey(ctx context.Context, req *cloudservicev1.DeleteApiKeyRequest, opts ...grpc.CallOption) (*cloudservicev1.DeleteApiKeyResponse, error) {
+	f.deleted = append(f.deleted, req.GetKeyId()+"@"+req.GetResourceVersion())
+	return &cloudservicev1.DeleteApiKeyResponse{}, nil
+}
+
+func (f *fakeCloudService) DeleteUser(ctx context.Context, req *cloudservicev1.DeleteUserRequest, opts ...grpc.CallOption) (*cloudservicev1.DeleteUserResponse, error) {
+	return nil, status.Error(codes.FailedPrecondition, "resource version mismatch")
+}
+
+func (f *fakeCloudService) DeleteUserGroup(ctx context.Context, req *cloudservicev1.DeleteUserGroupRequest, opts ...grpc.CallOption) (*cloudservicev1.DeleteUserGroupResponse, error) {
+	f.deleted = append(f.deleted, req.GetGroupId()+"@"+req.GetResourceVersion())
+	return &cloudservicev1.DeleteUserGroupResponse{}, nil
+}
+
+func (f *fakeCloudService) DeleteServiceAccount(ctx context.Context, req *cloudservicev1.DeleteServiceAccountRequest, opts ...grpc.CallOption) (*cloudservicev1.DeleteServiceAccountResponse, error) {
+	f.deleted = append(f.deleted, req.GetServiceAccountId()+"@"+req.GetResourceVersion())
+	return &cloudservicev1.DeleteServiceAccountResponse{}, nil
+}
+
+func (f *fakeCloudService) DeleteConnectivityRule(ctx context.Context, req *cloudservicev1.DeleteConnectivityRuleRequest, opts ...grpc.CallOption) (*cloudservicev1.DeleteConnectivityRuleResponse, error) {
+	f.deleted = append(f.deleted, req.GetConnectivityRuleId()+"@"+req.GetResourceVersion())
+	return &cloudservicev1.DeleteConnectivityRuleResponse{}, nil
+}
+
+func newFakeCloudService() *fakeCloudService {
+	old := ago(30 * 24 * time.Hour)
+	cloudGroup := &identityv1.UserGroupSpec_CloudGroup{CloudGroup: &identityv1.CloudGroupSpec{}}
+	return &fakeCloudService{
+		users: []*identityv1.User{
+			{Id: "u-active", Spec: &identityv1.UserSpec{Email: "active@example.com"}},
+			{Id: "u-invited", Spec: &identityv1.UserSpec{Email: "invited@example.com"}, Invitation: &identityv1.Invitation{
+				CreatedTime: ago(time.Hour), ExpiredTime: timestamppb.New(now.Add(time.Hour)),
+			}},
+			{Id: "u-expired", ResourceVersion: "1", Spec: &identityv1.UserSpec{Email: "expired@example.com"}, Invitation: &identityv1.Invitation{
+				CreatedTime: old, ExpiredTime: ago(23 * 24 * time.Hour),
+			}},
+			{Id: "u-deleting", State: resourcev1.ResourceState_RESOURCE_STATE_DELETING, Spec: &identityv1.UserSpec{Email: "deleting@example.com"}, Invitation: &identityv1.Invitation{
+				CreatedTime: old, ExpiredTime: ago(23 * 24 * time.Hour),
+			}},
+		},
+		serviceAccounts: []*identityv1.ServiceAccount{
+			{Id: "sa-ci", Spec: &identityv1.ServiceAccountSpec{Name: "ci"}, CreatedTime: old},
+			{Id: "sa-unused", ResourceVersion: "2", Spec: &identityv1.ServiceAccountSpec{Name: "unused"}, CreatedTime: old},
+			{Id: "sa-new", Spec: &identityv1.ServiceAccountSpec{Name: "new"}, CreatedTime: ago(time.Hour)},
+			{Id: "sa-keys-deleted", Spec: &identityv1.ServiceAccountSpec{Name: "keys-deleted"}, CreatedTime: old},
+		},
+		keys: []*identityv1.ApiKey{
+			{Id: "k-ok", Spec: &identityv1.ApiKeySpec{DisplayName: "ok", OwnerId: "sa-ci", OwnerType: identityv1.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT}},
+			{Id: "k-stale", ResourceVersion: "3", Spec: &identityv1.ApiKeySpec{
+				DisplayName: "stale",
+				OwnerId:     "u-active",
+				OwnerType:   identityv1.OwnerType_OWNER_TYPE_USER,
+				Disabled:    true,
+				ExpiryTime:  ago(time.Hour),
+			}},
+			{Id: "k-orphan", Spec: &identityv1.ApiKeySpec{DisplayName: "orphan", OwnerId: "u-gone", OwnerTypeDeprecated: "user"}},
+			{Id: "k-deleted", State: resourcev1.ResourceState_RESOURCE_STATE_DELETED, Spec: &identityv1.ApiKeySpec{
+				DisplayName: "deleted",
+				OwnerId:     "sa-keys-deleted",
+				OwnerType:   identityv1.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT,
+				Disabled:    true,
+			}},
+		},
+		groups: []*identityv1.UserGroup{
+			{Id: "g-team", Spec: &identityv1.UserGroupSpec{DisplayName: "team", GroupType: cloudGroup}, CreatedTime: old},
+			{Id: "g-empty", ResourceVersion: "4", Spec: &identityv1.UserGroupSpec{DisplayName: "empty", GroupType: cloudGroup}, CreatedTime: old},
+			{Id: "g-deleting", State: resourcev1.ResourceState_RESOURCE_STATE_DELETING, Spec: &identityv1.UserGroupSpec{DisplayName: "deleting", GroupType: cloudGroup}, CreatedTime: old},
+			{Id: "g-google", Spec: &identityv1.UserGroupSpec{
+				DisplayName: "google",
+				GroupType:   &identityv1.UserGroupSpec_GoogleGroup{GoogleGroup: &identityv1.GoogleGroupSpec{}},
+			}},
+		},
+		members: map[string]int{"g-team": 2},
+		rules: []*connectivityrulev1.ConnectivityRule{
+			{Id: "r-used", CreatedTime: old},
+			{Id: "r-unused", ResourceVersion: "5", CreatedTime: old, Spec: &connectivityrulev1.ConnectivityRuleSpec{
+				ConnectionType: &connectivityrulev1.ConnectivityRuleSpec_PrivateRule{PrivateRule: &connectivityrulev1.PrivateConnectivityRule{ConnectionId: "vpce-1"}},
+			}},
+			{Id: "r-deleting", CreatedTime: old, State: resourcev1.ResourceState_RESOURCE_STATE_DELETING},
+		},
+		namespaces: []*namespacev1.Namespace{
+			{Namespace: "prod", Spec: &namespacev1.NamespaceSpec{ConnectivityRuleIds: []string{"r-used"}}},
+		},
+	}
+}
+
+func TestScan(t *testing.T) {
+	fake := newFakeCloudService()
+	findings, err := Scan(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), Options{Now: now})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	var got []string
+	for _, f := range findings {
+		got = append(got, string(f.Kind)+" "+f.ID+": "+strings.Join(f.Reasons, "; "))
+	}
+	want := []string{
+		"api_key k-orphan: owner user u-gone no longer exists",
+		"api_key k-stale: disabled; expired on 2025-05-31T23:00:00Z",
+		"user u-expired: invitation sent on 2025-05-02T00:00:00Z expired on 2025-05-09T00:00:00Z without being accepted",
+		"user_group g-empty: no members",
+		"service_account sa-keys-deleted: no API keys",
+		"service_account sa-unused: no API keys",
+		"connectivity_rule r-unused: not used by any namespace",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Scan() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if len(fake.deleted) != 0 {
+		t.Errorf("Scan() deleted %v", fake.deleted)
+	}
+
+	findings, err = Scan(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), Options{Now: now, Kinds: []Kind{KindServiceAccount}, MinAge: -1})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(findings) != 3 || findings[0].ID != "sa-keys-deleted" || findings[1].ID != "sa-new" || findings[2].ID != "sa-unused" {
+		t.Errorf("Scan() = %v, want the service accounts without keys, new and unused", findings)
+	}
+
+	if _, err := Scan(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), Options{Kinds: []Kind{"namespace"}}); err == nil {
+		t.Errorf("Scan() error = nil, want an unknown kind error")
+	}
+}
+
+func TestApply(t *testing.T) {
+	fake := newFakeCloudService()
+	findings, err := Scan(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), Options{Now: now})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	// Apply deletes in the order of the kinds, whatever the order of the findings.
+	findings[0], findings[len(findings)-1] = findings[len(findings)-1], findings[0]
+
+	var logs bytes.Buffer
+	options := ApplyOptions{Logger: slog.New(slog.NewTextHandler(&logs, nil))}
+	err = Apply(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), findings, options)
+	if err == nil || err.Error() != "failed to delete 1 of 7 resources" {
+		t.Errorf("Apply() error = %v, want 1 failure", err)
+	}
+	want := []string{"k-stale@3", "k-orphan@", "g-empty@4", "sa-keys-deleted@", "sa-unused@2", "r-unused@5"}
+	if strings.Join(fake.deleted, ",") != strings.Join(want, ",") {
+		t.Errorf("Apply() deleted %v, want %v", fake.deleted, want)
+	}
+	for _, f := range findings {
+		if f.Deleted != (f.Kind != KindUser) || (f.Err != nil) != (f.Kind == KindUser) {
+			t.Errorf("Apply() finding %s: deleted = %v, error = %v", f.ID, f.Deleted, f.Err)
+		}
+	}
+	if !strings.Contains(logs.String(), `msg="deleted resource" kind=service_account id=sa-unused name=unused reasons="no API keys"`) ||
+		!strings.Contains(logs.String(), `msg="failed to delete resource" kind=user id=u-expired`) {
+		t.Errorf("Apply() logs =\n%s", logs.String())
+	}
+
+	// Applying again only retries the failed deletion.
+	fake.deleted = nil
+	if err := Apply(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), findings, options); err == nil {
+		t.Errorf("Apply() error = nil, want the user deletion to fail again")
+	}
+	if len(fake.deleted) != 0 {
+		t.Errorf("Apply() deleted %v again", fake.deleted)
+	}
+
+	var csv bytes.Buffer
+	if err := WriteCSV(&csv, findings); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(csv.String()), "\n"); len(lines) != 8 ||
+		lines[0] != "kind,id,name,resource_version,reasons,deleted,error" {
+		t.Errorf("WriteCSV() =\n%s", csv.String())
+	}
+}
+
+func TestWriteCSVNeutralizesFormulas(t *testing.T) {
+	findings := []*Finding{{
+		Kind:            KindAPIKey,
+		ID:              "k1",
+		Name:            "=HYPERLINK(\"https://example.com\")",
+		ResourceVersion: "1",
+		Reasons:         []string{"-owner deleted"},
+	}}
+	var csv bytes.Buffer
+	if err := WriteCSV(&csv, findings); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	if want := `api_key,k1,"'=HYPERLINK(""https://example.com"")",1,'-owner deleted,false,` + "\n"; !strings.HasSuffix(csv.String(), want) {
+		t.Errorf("WriteCSV() =\n%s\nwant the row %s", csv.String(), want)
+	}
+}
+
+func TestApplyOperationNotFulfilled(t *testing.T) {
+	client := cloudservicemock.NewMockCloudServiceClient(gomock.NewController(t))
+	for id, state := range map[string]operationv1.AsyncOperation_State{
+		"k1": operationv1.AsyncOperation_STATE_FAILED,
+		"k2": operationv1.AsyncOperation_STATE_REJECTED,
+		"k3": operationv1.AsyncOperation_STATE_FULFILLED,
+	} {
+		client.EXPECT().
+			DeleteApiKey(gomock.Any(), cloudservicemock.EqualProto(&cloudservicev1.DeleteApiKeyRequest{KeyId: id, ResourceVersion: "1"})).
+			Return(&cloudservicev1.DeleteApiKeyResponse{AsyncOperation: &operationv1.AsyncOperation{Id: "op-" + id}}, nil)
+		// EqualProto ignores the async operation id, the operations are matched by id explicitly.
+		client.EXPECT().
+			GetAsyncOperation(gomock.Any(), gomock.Cond(func(req *cloudservicev1.GetAsyncOperationRequest) bool {
+				return req.GetAsyncOperationId() == "op-"+id
+			})).
+			Return(&cloudservicev1.GetAsyncOperationResponse{AsyncOperation: &operationv1.AsyncOperation{Id: "op-" + id, State: state}}, nil)
+	}
+
+	var findings []*Finding
+	for _, id := range []string{"k1", "k2", "k3"} {
+		findings = append(findings, &Finding{Kind: KindAPIKey, ID: id, ResourceVersion: "1"})
+	}
+	options := ApplyOptions{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), PollInterval: time.Millisecond}
+	if err := Apply(context.Background(), client, findings, options); err == nil || err.Error() != "failed to delete 2 of 3 resources" {
+		t.Errorf("Apply() error = %v, want 2 failures", err)
+	}
+	for _, f := range findings[:2] {
+		if f.Deleted || f.Err == nil || !strings.Contains(f.Err.Error(), "did not fulfill") {
+			t.Errorf("Apply() finding %s: deleted = %v, error = %v, want the operation not fulfilled", f.ID, f.Deleted, f.Err)
+		}
+	}
+	if f := findings[2]; !f.Deleted || f.Err != nil {
+		t.Errorf("Apply() finding %s: deleted = %v, error = %v", f.ID, f.Deleted, f.Err)
+	}
+}
@@ -0,0 +1,291 @@
+// Package keyexpiry checks the API keys of a Temporal Cloud account for the ones expiring soon, or already expired
+// but still enabled, and notifies their owners so that the keys are rotated before anything breaks.
+//
+// WARNING: The package is currently experimental.
+//
+// The keys are grouped by owner, with one notification per owner. The notifications of users carry their email in
+// the "owner_email" field, set notify.EmailNotifier.ToField to it to email the owners directly.
+package keyexpiry
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/notify"
+	"go.temporal.io/cloud-sdk/internal/apikeys"
+	"go.temporal.io/cloud-sdk/internal/paging"
+	"go.temporal.io/cloud-sdk/internal/resources"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultWithin is how soon a key must expire to be reported, used when none is provided.
+	DefaultWithin = 14 * 24 * time.Hour
+
+	// DefaultPollInterval is the interval between two checks of Checker.Run, used when none is provided.
+	DefaultPollInterval = 24 * time.Hour
+
+	notificationSource = "keyexpiry"
+)
+
+// The statuses of a reported key.
+const (
+	StatusExpiring Status = "expiring"
+	StatusExpired  Status = "expired"
+)
+
+type (
+	// Status is the status of a reported key.
+	Status string
+
+	// Key is an API key expiring soon, or expired but still enabled.
+	Key struct {
+		ID   string
+		Name string
+		// When the key expires, or expired.
+		ExpiryTime time.Time
+		Status     Status
+	}
+
+	// Owner is the owner of reported keys.
+	Owner struct {
+		ID   string
+		Type identityv1.OwnerType
+		// The email of a user, or the name of a service account, empty if the owner no longer exists.
+		Name string
+		// The email of a user, empty for a service account.
+		Email string
+		// The keys of the owner, the first to expire first.
+		Keys []*Key
+	}
+
+	// Options to configure a checker.
+	Options struct {
+		// How soon a key must expire to be reported.
+		// If not provided, DefaultWithin is used.
+		Within time.Duration
+
+		// Where to send the notifications of Checker.Run.
+		// If not provided, the notifications are not sent.
+		Notifier notify.Notifier
+
+		// The interval between two checks of Checker.Run.
+		// If not provided, DefaultPollInterval is used.
+		PollInterval time.Duration
+
+		// Called when a check of Checker.Run fails or a notification cannot be sent. Checker.Run carries on.
+		// If not provided, errors are ignored.
+		OnError func(error)
+	}
+
+	// Checker checks the expiry of the API keys of an account.
+	Checker struct {
+		client  cloudservicev1.CloudServiceClient
+		options Options
+		now     func() time.Time
+	}
+)
+
+// NewChecker creates a checker.
+func NewChecker(client cloudservicev1.CloudServiceClient, options Options) *Checker {
+	if options.Within <= 0 {
+		options.Within = DefaultWithin
+	}
+	if options.PollInterval <= 0 {
+		options.PollInterval = DefaultPollInterval
+	}
+	return &Checker{
+		client:  client,
+		options: options,
+		now:     time.Now,
+	}
+}
+
+// Check returns the owners of the enabled API keys that expire within Options.Within or already expired, sorted by
+// the first key to expire. Disabled keys, keys being deleted and keys without expiry are not reported.
+func (c *Checker) Check(ctx context.Context) ([]*Owner, error) {
+	keys, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.ApiKey, string, error) {
+		resp, err := c.client.GetApiKeys(ctx, &cloudservicev1.GetApiKeysRequest{PageToken: pageToken})
+		return resp.GetApiKeys(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the API keys: %w", err)
+	}
+
+	now := c.now()
+	owners := map[string]*Owner{}
+	for _, key := range keys {
+		spec := key.GetSpec()
+		if spec.GetDisabled() || spec.GetExpiryTime() == nil || resources.Deleted(key.GetState()) {
+			continue
+		}
+		expiry := spec.GetExpiryTime().AsTime()
+		if expiry.Sub(now) > c.options.Within {
+			continue
+		}
+		k := &Key{ID: key.GetId(), Name: spec.GetDisplayName(), ExpiryTime: expiry, Status: StatusExpiring}
+		if !now.Before(expiry) {
+			k.Status = StatusExpired
+		}
+		owner, ok := owners[spec.GetOwnerId()]
+		if !ok {
+			owner = &Owner{ID: spec.GetOwnerId(), Type: apikeys.OwnerType(spec)}
+			owners[owner.ID] = owner
+		}
+		owner.Keys = append(owner.Keys, k)
+	}
+
+	result := make([]*Owner, 0, len(owners))
+	for _, owner := range owners {
+		if err := c.resolve(ctx, owner); err != nil {
+			return nil, err
+		}
+		slices.SortFunc(owner.Keys, func(k1, k2 *Key) int {
+			return cmp.Or(k1.ExpiryTime.Compare(k2.ExpiryTime), strings.Compare(k1.ID, k2.ID))
+		})
+		result = append(result, owner)
+	}
+	slices.SortFunc(result, func(o1, o2 *Owner) int {
+		return cmp.Or(o1.Keys[0].ExpiryTime.Compare(o2.Keys[0].ExpiryTime), strings.Compare(o1.ID, o2.ID))
+	})
+	return result, nil
+}
+
+// resolve sets the name and email of the owner, left empty if the owner no longer exists.
+func (c *Checker) resolve(ctx context.Context, owner *Owner) error {
+	if owner.Type != identityv1.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT {
+		resp, err := c.client.GetUser(ctx, &cloudservicev1.GetUserRequest{UserId: owner.ID})
+		if err == nil {
+			owner.Type = identityv1.OwnerType_OWNER_TYPE_USER
+			owner.Email = resp.GetUser().GetSpec().GetEmail()
+			owner.Name = owner.Email
+			return nil
+		}
+		if !notFound(err) {
+			return fmt.Errorf("failed to get user %s: %w", owner.ID, err)
+		}
+		if owner.Type == identityv1.OwnerType_OWNER_TYPE_USER {
+			return nil
+		}
+	}
+	resp, err := c.client.GetServiceAccount(ctx, &cloudservicev1.GetServiceAccountRequest{ServiceAccountId: owner.ID})
+	if err == nil {
+		owner.Type = identityv1.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT
+		owner.Name = resp.GetServiceAccount().GetSpec().GetName()
+		return nil
+	}
+	if !notFound(err) {
+		return fmt.Errorf("failed to get service account %s: %w", owner.ID, err)
+	}
+	return nil
+}
+
+// Run checks the keys every Options.PollInterval and sends a notification per owner of reported keys, as a
+// reminder, until ctx is done.
+func (c *Checker) Run(ctx context.Context) error {
+	for {
+		if err := c.checkAndNotify(ctx); err != nil && c.options.OnError != nil && ctx.Err() == nil {
+			c.options.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.options.PollInterval):
+		}
+	}
+}
+
+func (c *Checker) checkAndNotify(ctx context.Context) error {
+	owners, err := c.Check(ctx)
+	if err != nil {
+		return err
+	}
+	if c.options.Notifier == nil {
+		return nil
+	}
+	now := c.now()
+	var errs []error
+	for _, owner := range owners {
+		if err := c.options.Notifier.Notify(ctx, owner.Notification(now)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send the notification of %s: %w", owner, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// String returns the kind and name of the owner, such as "user alice@example.com".
+func (o *Owner) String() string {
+	kind := "owner"
+	switch o.Type {
+	case identityv1.OwnerType_OWNER_TYPE_USER:
+		kind = "user"
+	case identityv1.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT:
+		kind = "service account"
+	}
+	return fmt.Sprintf("%s %s", kind, cmp.Or(o.Name, o.ID))
+}
+
+// Notification returns the notification of the keys of the owner, as of the given time. It is critical if a key
+// already expired, or else a warning.
+func (o *Owner) Notification(now time.Time) notify.Notification {
+	n := notify.Notification{
+		Source:   notificationSource,
+		Severity: notify.SeverityWarning,
+		Title:    fmt.Sprintf("%d API key(s) of %s expiring soon", len(o.Keys), o),
+		Time:     now,
+		Fields: map[string]string{
+			"owner_id":   o.ID,
+			"owner_type": strings.ToLower(strings.TrimPrefix(o.Type.String(), "OWNER_TYPE_")),
+			"owner_name": o.Name,
+		},
+	}
+	if o.Email != "" {
+		n.Fields["owner_email"] = o.Email
+	}
+	var expired int
+	var msg, ids []string
+	for _, k := range o.Keys {
+		ids = append(ids, k.ID)
+		name := k.ID
+		if k.Name != "" {
+			name = fmt.Sprintf("%s (%s)", k.Name, k.ID)
+		}
+		if k.Status == StatusExpired {
+			expired++
+			msg = append(msg, fmt.Sprintf("%s expired on %s and is still enabled", name, k.ExpiryTime.UTC().Format(time.RFC3339)))
+		} else {
+			msg = append(msg, fmt.Sprintf("%s expires on %s, in %s", name, k.ExpiryTime.UTC().Format(time.RFC3339), days(k.ExpiryTime.Sub(now))))
+		}
+	}
+	if expired > 0 {
+		n.Severity = notify.SeverityCritical
+		n.Title = fmt.Sprintf("%d API key(s) of %s expired", expired, o)
+		if expired < len(o.Keys) {
+			n.Title += fmt.Sprintf(", %d expiring soon", len(o.Keys)-expired)
+		}
+	}
+	n.Fields["key_ids"] = strings.Join(ids, ",")
+	n.Message = strings.Join(msg, "\n")
+	return n
+}
+
+// days formats a duration as a number of days, rounded up, such as "3 days".
+func days(d time.Duration) string {
+	n := (d + 24*time.Hour - 1) / (24 * time.Hour)
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
+
+func notFound(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
@@ -0,0 +1,154 @@
+package keyexpiry
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	resourcev1 "go.temporal.io/cloud-sdk/api/resource/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/cloudservicemock"
+	"go.temporal.io/cloud-sdk/cloudclient/notify"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+type fakeCloudService struct {
+	keys            []*identityv1.ApiKey
+	users           map[string]string
+	serviceAccounts map[string]string
+}
+
+func (f *fakeCloudService) GetApiKeys(ctx context.Context, req *cloudservicev1.GetApiKeysRequest, opts ...grpc.CallOption) (*cloudservicev1.GetApiKeysResponse, error) {
+	return &cloudservicev1.GetApiKeysResponse{ApiKeys: f.keys}, nil
+}
+
+func (f *fakeCloudService) GetUser(ctx context.Context, req *cloudservicev1.GetUserRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUserResponse, error) {
+	email, ok := f.users[req.GetUserId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &cloudservicev1.GetUserResponse{User: &identityv1.User{Id: req.GetUserId(), Spec: &identityv1.UserSpec{Email: email}}}, nil
+}
+
+func (f *fakeCloudService) GetServiceAccount(ctx context.Context, req *cloudservicev1.GetServiceAccountRequest, opts ...grpc.CallOption) (*cloudservicev1.GetServiceAccountResponse, error) {
+	name, ok := f.serviceAccounts[req.GetServiceAccountId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "service account not found")
+	}
+	return &cloudservicev1.GetServiceAccountResponse{ServiceAccount: &identityv1.ServiceAccount{
+		Id:   req.GetServiceAccountId(),
+		Spec: &identityv1.ServiceAccountSpec{Name: name},
+	}}, nil
+}
+
+func key(id, owner string, ownerType identityv1.OwnerType, expiresIn time.Duration, disabled bool) *identityv1.ApiKey {
+	return &identityv1.ApiKey{Id: id, Spec: &identityv1.ApiKeySpec{
+		DisplayName: id + "-name",
+		OwnerId:     owner,
+		OwnerType:   ownerType,
+		ExpiryTime:  timestamppb.New(now.Add(expiresIn)),
+		Disabled:    disabled,
+	}}
+}
+
+func deleting(key *identityv1.ApiKey) *identityv1.ApiKey {
+	key.State = resourcev1.ResourceState_RESOURCE_STATE_DELETING
+	return key
+}
+
+func newChecker(t *testing.T, notifier notify.Notifier) *Checker {
+	t.Helper()
+	user, sa := identityv1.OwnerType_OWNER_TYPE_USER, identityv1.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT
+	day := 24 * time.Hour
+	fake := &fakeCloudService{
+		keys: []*identityv1.ApiKey{
+			key("k-far", "u1", user, 60*day, false),
+			key("k-soon", "u1", user, 3*day, false),
+			key("k-ci", "sa1", sa, -day, false),
+			key("k-ci-next", "sa1", sa, 10*day, false),
+			key("k-disabled", "sa1", sa, -day, true),
+			key("k-orphan", "gone", identityv1.OwnerType_OWNER_TYPE_UNSPECIFIED, 2*day, false),
+			{Id: "k-forever", Spec: &identityv1.ApiKeySpec{OwnerId: "u1"}},
+			deleting(key("k-deleting", "u1", user, -day, false)),
+		},
+		users:           map[string]string{"u1": "alice@example.com"},
+		serviceAccounts: map[string]string{"sa1": "ci"},
+	}
+	checker := NewChecker(cloudservicemock.NewFakeCloudServiceClient(t, fake), Options{Notifier: notifier, PollInterval: time.Millisecond})
+	checker.now = func() time.Time { return now }
+	return checker
+}
+
+func TestCheck(t *testing.T) {
+	owners, err := newChecker(t, nil).Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	var got []string
+	for _, o := range owners {
+		var keys []string
+		for _, k := range o.Keys {
+			keys = append(keys, k.ID+"="+string(k.Status))
+		}
+		got = append(got, o.String()+": "+strings.Join(keys, ","))
+	}
+	want := []string{
+		"service account ci: k-ci=expired,k-ci-next=expiring",
+		"owner gone: k-orphan=expiring",
+		"user alice@example.com: k-soon=expiring",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Check() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if owners[2].Email != "alice@example.com" || owners[0].Email != "" {
+		t.Errorf("Check() emails = %q %q", owners[2].Email, owners[0].Email)
+	}
+
+	n := owners[0].Notification(now)
+	if n.Severity != notify.SeverityCritical || n.Title != "1 API key(s) of service account ci expired, 1 expiring soon" ||
+		n.Fields["key_ids"] != "k-ci,k-ci-next" || n.Fields["owner_type"] != "service_account" ||
+		n.Message != "k-ci-name (k-ci) expired on 2026-09-30T12:00:00Z and is still enabled\n"+
+			"k-ci-next-name (k-ci-next) expires on 2026-10-11T12:00:00Z, in 10 days" {
+		t.Errorf("Notification() = %+v", n)
+	}
+	n = owners[2].Notification(now)
+	if n.Severity != notify.SeverityWarning || n.Fields["owner_email"] != "alice@example.com" {
+		t.Errorf("Notification() = %+v", n)
+	}
+}
+
+func TestRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var notifications []notify.Notification
+	var errs []error
+	checker := newChecker(t, notify.NotifierFunc(func(ctx context.Context, n notify.Notification) error {
+		notifications = append(notifications, n)
+		if len(notifications) == 6 {
+			cancel()
+		}
+		if n.Fields["owner_id"] == "gone" {
+			return errors.New("unreachable")
+		}
+		return nil
+	}))
+	checker.options.OnError = func(err error) { errs = append(errs, err) }
+	if err := checker.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() error = %v, want context.Canceled", err)
+	}
+	// Every check sends a reminder per owner.
+	if len(notifications) != 6 {
+		t.Errorf("Run() sent %d notifications, want 6", len(notifications))
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "owner gone: unreachable") {
+		t.Errorf("Run() errors = %v", errs)
+	}
+}
@@ -0,0 +1,444 @@
+// Package leastprivilege recommends narrower access for the users and service accounts of a Temporal Cloud
+// account, from the operations they actually performed according to the audit logs.
+//
+// WARNING: The package is currently experimental.
+//
+// The operations of the audit logs are classified with the catalog of the customroles package, which tells
+// the type of resource each acts on, then mapped to the lowest account role and namespace permission allowing
+// them. A principal whose granted account role is higher than the one its operations require is reported as
+// overprivileged, typically the service accounts created with ROLE_ADMIN to get started. For principals that
+// only need a few administrative operations, a custom role allowing just those is proposed as well.
+//
+// The audit logs record the operations of the cloud operations API, but not the operations on workflows, which
+// are allowed by the namespace permissions too. The namespace permissions are therefore kept unless
+// Options.NarrowNamespaceAccess is set, which is only suitable for principals that do not run workers or clients.
+package leastprivilege
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	auditlogv1 "go.temporal.io/cloud-sdk/api/auditlog/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/accessreview"
+	"go.temporal.io/cloud-sdk/cloudclient/auditlogs"
+	"go.temporal.io/cloud-sdk/cloudclient/customroles"
+	"go.temporal.io/cloud-sdk/internal/paging"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DefaultWindow is the window of audit logs Analyze reads, used when no start time is provided.
+const DefaultWindow = 30 * 24 * time.Hour
+
+type (
+	// Usage is the operations a principal performed.
+	Usage struct {
+		PrincipalID string
+		// The type of the principal, such as accessreview.PrincipalServiceAccount, as the access review spells it.
+		PrincipalType string
+		PrincipalName string
+		// The ids of the API keys the operations were authenticated with.
+		APIKeyIDs []string
+		// The number of times each operation was performed, by operation then namespace. The namespace is
+		// empty for the operations that do not act on a namespace, or whose namespace could not be determined.
+		Operations map[string]map[string]int
+		// The emit time of the first and last operations.
+		First, Last time.Time
+	}
+
+	// Recommendation is the access proposed for a principal.
+	Recommendation struct {
+		PrincipalID   string
+		PrincipalType string
+		PrincipalName string
+		// The operations of the principal, nil if it performed none.
+		Usage *Usage
+		// The access granted to the principal.
+		Current *identityv1.Access
+		// The narrowest access allowing the operations of the principal.
+		Proposed *identityv1.Access
+		// A custom role allowing the administrative operations of the principal, proposed along with a lower
+		// account role when these operations are what required ROLE_ADMIN or ROLE_OWNER. Nil otherwise.
+		CustomRole *identityv1.CustomRoleSpec
+		// Whether the proposed access is narrower than the current one.
+		Overprivileged bool
+		// Why the access is proposed, one line per finding.
+		Reasons []string
+	}
+
+	// Options to configure the analysis.
+	Options struct {
+		// The start of the window of audit logs.
+		// If not provided, DefaultWindow before End is used.
+		Start time.Time
+		// The end of the window of audit logs.
+		// If not provided, the current time is used.
+		End time.Time
+		// Whether to narrow the namespace permissions to the namespaces the principal performed operations on.
+		// Only suitable for principals that do not run workers or clients, see the package documentation.
+		NarrowNamespaceAccess bool
+		// The catalog classifying the operations.
+		// If not provided, customroles.DefaultCatalog is used.
+		Catalog *customroles.Catalog
+	}
+)
+
+// Collect groups the operations of the log records by principal id, ignoring the operations that failed since
+// they were not allowed, or did not need to be. It stops at the first error of seq.
+func Collect(seq iter.Seq2[*auditlogv1.LogRecord, error]) (map[string]*Usage, error) {
+	usages := map[string]*Usage{}
+	for r, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		p := r.GetPrincipal()
+		if p.GetId() == "" || !auditlogs.Succeeded(r) {
+			continue
+		}
+		u, ok := usages[p.GetId()]
+		if !ok {
+			u = &Usage{PrincipalID: p.GetId(), Operations: map[string]map[string]int{}}
+			usages[p.GetId()] = u
+		}
+		u.PrincipalType = principalType(p.GetType())
+		if p.GetName() != "" {
+			u.PrincipalName = p.GetName()
+		}
+		if id := p.GetApiKeyId(); id != "" && !slices.Contains(u.APIKeyIDs, id) {
+			u.APIKeyIDs = append(u.APIKeyIDs, id)
+		}
+		if u.Operations[r.GetOperation()] == nil {
+			u.Operations[r.GetOperation()] = map[string]int{}
+		}
+		u.Operations[r.GetOperation()][namespace(r.GetRawDetails())]++
+		emitTime := r.GetEmitTime().AsTime()
+		if u.First.IsZero() || emitTime.Before(u.First) {
+			u.First = emitTime
+		}
+		if emitTime.After(u.Last) {
+			u.Last = emitTime
+		}
+	}
+	return usages, nil
+}
+
+// principalType returns the type of principal of the access review matching a type of principal of the audit logs,
+// which spells service accounts "serviceaccount". Unknown types are returned unchanged.
+func principalType(auditType string) string {
+	switch auditType {
+	case "user":
+		return string(accessreview.PrincipalUser)
+	case "serviceaccount", "service_account":
+		return string(accessreview.PrincipalServiceAccount)
+	default:
+		return auditType
+	}
+}
+
+// Analyze reads the audit logs of the window and recommends an access for every user and service account of
+// the account, sorted by principal type and name.
+func Analyze(ctx context.Context, client cloudservicev1.CloudServiceClient, options Options) ([]*Recommendation, error) {
+	end := options.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+	start := options.Start
+	if start.IsZero() {
+		start = end.Add(-DefaultWindow)
+	}
+	usages, err := Collect(paging.Seq(ctx, func(ctx context.Context, pageToken string) ([]*auditlogv1.LogRecord, string, error) {
+		resp, err := client.GetAuditLogs(ctx, &cloudservicev1.GetAuditLogsRequest{
+			StartTimeInclusive: timestamppb.New(start),
+			EndTimeExclusive:   timestamppb.New(end),
+			PageToken:          pageToken,
+		})
+		return resp.GetLogs(), resp.GetNextPageToken(), err
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the audit logs: %w", err)
+	}
+
+	users, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.User, string, error) {
+		resp, err := client.GetUsers(ctx, &cloudservicev1.GetUsersRequest{PageToken: pageToken})
+		return resp.GetUsers(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	serviceAccounts, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.ServiceAccount, string, error) {
+		resp, err := client.GetServiceAccounts(ctx, &cloudservicev1.GetServiceAccountsRequest{PageToken: pageToken})
+		return resp.GetServiceAccount(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+
+	var recommendations []*Recommendation
+	for _, u := range users {
+		r := Recommend(u.GetSpec().GetAccess(), usages[u.GetId()], options)
+		r.PrincipalID, r.PrincipalType, r.PrincipalName = u.GetId(), string(accessreview.PrincipalUser), u.GetSpec().GetEmail()
+		recommendations = append(recommendations, r)
+	}
+	for _, sa := range serviceAccounts {
+		r := Recommend(sa.GetSpec().GetAccess(), usages[sa.GetId()], options)
+		r.PrincipalID, r.PrincipalType, r.PrincipalName = sa.GetId(), string(accessreview.PrincipalServiceAccount), sa.GetSpec().GetName()
+		recommendations = append(recommendations, r)
+	}
+	slices.SortFunc(recommendations, func(r1, r2 *Recommendation) int {
+		return strings.Compare(r1.PrincipalType+"\x00"+r1.PrincipalName, r2.PrincipalType+"\x00"+r2.PrincipalName)
+	})
+	return recommendations, nil
+}
+
+// Recommend proposes the narrowest access allowing the operations of the usage, which is nil if the principal
+// performed none. The proposed access is never broader than the current one: operations that the proposed
+// account role does not allow are assumed to be allowed by the current custom roles, which are then kept.
+// When the account role is lowered, the namespaces the principal performed operations on are granted the
+// permission these operations require, since the current role may be what allowed them.
+// Options.Start and Options.End are ignored.
+func Recommend(current *identityv1.Access, usage *Usage, options Options) *Recommendation {
+	catalog := options.Catalog
+	if catalog == nil {
+		catalog = customroles.DefaultCatalog()
+	}
+	r := &Recommendation{Usage: usage, Current: current}
+	if usage != nil {
+		r.PrincipalID, r.PrincipalType, r.PrincipalName = usage.PrincipalID, usage.PrincipalType, usage.PrincipalName
+	} else {
+		usage = &Usage{}
+		r.Reasons = append(r.Reasons, "no operation in the audit logs of the window")
+	}
+
+	currentRole := current.GetAccountAccess().GetRole()
+	role := identityv1.AccountAccess_ROLE_READ
+	if currentRole == identityv1.AccountAccess_ROLE_METRICS_READ {
+		role = currentRole
+	}
+	baseRole := role
+	// the administrative operations, by resource type, for the alternative custom role
+	adminActions := map[string][]string{}
+	namespaces := map[string]identityv1.NamespaceAccess_Permission{}
+	var unknownNamespace []string
+	for _, operation := range slices.Sorted(maps.Keys(usage.Operations)) {
+		resourceType := resourceTypeOf(catalog, operation)
+		switch resourceType {
+		case "":
+			r.Reasons = append(r.Reasons, fmt.Sprintf("%s is not in the catalog and was not taken into account", operation))
+		case "namespace":
+			permission := requiredPermission(operation)
+			for ns := range usage.Operations[operation] {
+				if ns == "" {
+					unknownNamespace = append(unknownNamespace, operation)
+					continue
+				}
+				namespaces[ns] = stronger(namespaces[ns], permission)
+			}
+		case "api_key":
+			// principals manage their own API keys whatever their role
+		default:
+			required := requiredRole(resourceType, operation)
+			if required == identityv1.AccountAccess_ROLE_ADMIN {
+				adminActions[resourceType] = append(adminActions[resourceType], operation)
+			} else {
+				baseRole = combine(baseRole, required)
+			}
+			role = combine(role, required)
+		}
+	}
+
+	proposed := &identityv1.Access{AccountAccess: &identityv1.AccountAccess{Role: role}}
+	if !covers(currentRole, role) {
+		// the operations were allowed by custom roles or by a role this package does not rank as expected
+		proposed.AccountAccess.Role = currentRole
+		r.Reasons = append(r.Reasons, fmt.Sprintf("the operations require %s, more than the current %s, the current role is kept", role, currentRole))
+	}
+	proposed.AccountAccess.CustomRoles = current.GetAccountAccess().GetCustomRoles()
+
+	switch {
+	case !options.NarrowNamespaceAccess:
+		proposed.NamespaceAccesses = current.GetNamespaceAccesses()
+	case len(unknownNamespace) > 0:
+		proposed.NamespaceAccesses = current.GetNamespaceAccesses()
+		r.Reasons = append(r.Reasons, fmt.Sprintf("the namespace of %s could not be determined, the namespace permissions are kept",
+			strings.Join(slices.Compact(unknownNamespace), ", ")))
+	default:
+		for ns, access := range current.GetNamespaceAccesses() {
+			required, used := namespaces[ns]
+			if !used {
+				r.Reasons = append(r.Reasons, fmt.Sprintf("no operation on namespace %s", ns))
+				continue
+			}
+			if proposed.NamespaceAccesses == nil {
+				proposed.NamespaceAccesses = map[string]*identityv1.NamespaceAccess{}
+			}
+			if stronger(access.GetPermission(), required) != access.GetPermission() {
+				// allowed through the account role, keep the current permission
+				required = access.GetPermission()
+			}
+			proposed.NamespaceAccesses[ns] = &identityv1.NamespaceAccess{Permission: required}
+			if required != access.GetPermission() {
+				r.Reasons = append(r.Reasons, fmt.Sprintf("only %s operations on namespace %s", strings.TrimPrefix(required.String(), "PERMISSION_"), ns))
+			}
+		}
+	}
+	if proposed.GetAccountAccess().GetRole() != currentRole {
+		// the operations on namespaces may have been allowed by the current account role alone, grant them
+		// explicitly now that it is lowered.
+		proposed.NamespaceAccesses = maps.Clone(proposed.GetNamespaceAccesses())
+		for _, ns := range slices.Sorted(maps.Keys(namespaces)) {
+			existing := proposed.GetNamespaceAccesses()[ns].GetPermission()
+			required := stronger(existing, namespaces[ns])
+			if required == existing {
+				continue
+			}
+			if proposed.NamespaceAccesses == nil {
+				proposed.NamespaceAccesses = map[string]*identityv1.NamespaceAccess{}
+			}
+			proposed.NamespaceAccesses[ns] = &identityv1.NamespaceAccess{Permission: required}
+			r.Reasons = append(r.Reasons, fmt.Sprintf("the operations on namespace %s require %s without %s",
+				ns, strings.TrimPrefix(required.String(), "PERMISSION_"), currentRole))
+		}
+	}
+	r.Proposed = proposed
+
+	if proposed.GetAccountAccess().GetRole() != currentRole {
+		r.Overprivileged = true
+		r.Reasons = append(r.Reasons, fmt.Sprintf("the operations only require %s, not %s", proposed.GetAccountAccess().GetRole(), currentRole))
+	}
+	if len(current.GetNamespaceAccesses()) != len(proposed.GetNamespaceAccesses()) ||
+		!maps.EqualFunc(current.GetNamespaceAccesses(), proposed.GetNamespaceAccesses(), func(a, b *identityv1.NamespaceAccess) bool {
+			return proto.Equal(a, b)
+		}) {
+		r.Overprivileged = true
+	}
+
+	if len(adminActions) > 0 && !covers(baseRole, identityv1.AccountAccess_ROLE_ADMIN) {
+		spec := &identityv1.CustomRoleSpec{
+			Name:        "least-privilege-" + r.PrincipalID,
+			Description: fmt.Sprintf("The administrative operations of %s, to grant with %s", cmp.Or(r.PrincipalName, r.PrincipalID), baseRole),
+		}
+		for _, resourceType := range slices.Sorted(maps.Keys(adminActions)) {
+			spec.Permissions = append(spec.Permissions, &identityv1.CustomRoleSpec_Permission{
+				Resources: &identityv1.CustomRoleSpec_Resources{ResourceType: resourceType, AllowAll: true},
+				Actions:   slices.Compact(adminActions[resourceType]),
+			})
+		}
+		r.CustomRole = spec
+		r.Reasons = append(r.Reasons, fmt.Sprintf("alternatively, %s with the custom role %s allows the same operations", baseRole, spec.GetName()))
+	}
+	return r
+}
+
+// resourceTypeOf returns the resource type the operation acts on, according to the catalog.
+func resourceTypeOf(catalog *customroles.Catalog, operation string) string {
+	for _, resourceType := range catalog.Types() {
+		if catalog.Has(resourceType, operation) {
+			return resourceType
+		}
+	}
+	return ""
+}
+
+// requiredPermission returns the namespace permission allowing an operation on a namespace.
+func requiredPermission(operation string) identityv1.NamespaceAccess_Permission {
+	switch {
+	case strings.HasPrefix(operation, "Get"):
+		return identityv1.NamespaceAccess_PERMISSION_READ
+	case operation == "DeleteNamespace", strings.HasSuffix(operation, "NamespaceAccess"):
+		return identityv1.NamespaceAccess_PERMISSION_ADMIN
+	}
+	return identityv1.NamespaceAccess_PERMISSION_WRITE
+}
+
+// requiredRole returns the account role allowing an operation on a resource that is not a namespace.
+func requiredRole(resourceType, operation string) identityv1.AccountAccess_Role {
+	switch operation {
+	case "GetUsage", "CreateBillingReport", "GetBillingReport":
+		return identityv1.AccountAccess_ROLE_FINANCE_ADMIN
+	case "CreateNamespace":
+		return identityv1.AccountAccess_ROLE_DEVELOPER
+	case "GetAuditLogs":
+		return identityv1.AccountAccess_ROLE_ADMIN
+	}
+	switch {
+	case strings.Contains(operation, "AuditLogSink"):
+		return identityv1.AccountAccess_ROLE_ADMIN
+	case strings.HasPrefix(operation, "Get"):
+		return identityv1.AccountAccess_ROLE_READ
+	case resourceType == "nexus_endpoint":
+		return identityv1.AccountAccess_ROLE_DEVELOPER
+	}
+	return identityv1.AccountAccess_ROLE_ADMIN
+}
+
+// combine returns the lowest role covering both roles.
+func combine(a, b identityv1.AccountAccess_Role) identityv1.AccountAccess_Role {
+	switch {
+	case covers(a, b):
+		return a
+	case covers(b, a):
+		return b
+	}
+	// a finance admin that also needs to develop or administer
+	return identityv1.AccountAccess_ROLE_OWNER
+}
+
+// covers reports whether the role a allows everything the role b allows.
+func covers(a, b identityv1.AccountAccess_Role) bool {
+	rank := map[identityv1.AccountAccess_Role]int{
+		identityv1.AccountAccess_ROLE_METRICS_READ: 1,
+		identityv1.AccountAccess_ROLE_READ:         2,
+		identityv1.AccountAccess_ROLE_DEVELOPER:    3,
+		identityv1.AccountAccess_ROLE_ADMIN:        4,
+		identityv1.AccountAccess_ROLE_OWNER:        5,
+	}
+	switch {
+	case a == b || a == identityv1.AccountAccess_ROLE_OWNER:
+		return true
+	case a == identityv1.AccountAccess_ROLE_FINANCE_ADMIN:
+		return rank[b] > 0 && rank[b] <= rank[identityv1.AccountAccess_ROLE_READ]
+	case b == identityv1.AccountAccess_ROLE_FINANCE_ADMIN:
+		return false
+	}
+	return rank[a] >= rank[b]
+}
+
+// stronger returns the highest of two namespace permissions.
+func stronger(a, b identityv1.NamespaceAccess_Permission) identityv1.NamespaceAccess_Permission {
+	rank := map[identityv1.NamespaceAccess_Permission]int{
+		identityv1.NamespaceAccess_PERMISSION_READ:  1,
+		identityv1.NamespaceAccess_PERMISSION_WRITE: 2,
+		identityv1.NamespaceAccess_PERMISSION_ADMIN: 3,
+	}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// namespace returns the namespace field of the raw details of a log record, looked up at any depth.
+func namespace(details *structpb.Struct) string {
+	queue := []*structpb.Struct{details}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		if ns := s.GetFields()["namespace"].GetStringValue(); ns != "" {
+			return ns
+		}
+		for _, key := range slices.Sorted(maps.Keys(s.GetFields())) {
+			if nested := s.GetFields()[key].GetStructValue(); nested != nil {
+				queue = append(queue, nested)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,205 @@
+package leastprivilege
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	auditlogv1 "go.temporal.io/cloud-sdk/api/auditlog/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/accessreview"
+	"go.temporal.io/cloud-sdk/cloudclient/cloudservicemock"
+	"go.temporal.io/cloud-sdk/cloudclient/customroles"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func record(t *testing.T, principalID, operation, namespace string) *auditlogv1.LogRecord {
+	t.Helper()
+	details, err := structpb.NewStruct(map[string]any{"request": map[string]any{"namespace": namespace}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &auditlogv1.LogRecord{
+		EmitTime:   timestamppb.New(now),
+		Operation:  operation,
+		Principal:  &auditlogv1.Principal{Type: "serviceaccount", Id: principalID, ApiKeyId: "k-" + principalID},
+		RawDetails: details,
+	}
+}
+
+func failed(r *auditlogv1.LogRecord) *auditlogv1.LogRecord {
+	r.Status = "PermissionDenied"
+	return r
+}
+
+type fakeCloudService struct {
+	logs []*auditlogv1.LogRecord
+}
+
+func (s *fakeCloudService) GetAuditLogs(ctx context.Context, req *cloudservicev1.GetAuditLogsRequest, opts ...grpc.CallOption) (*cloudservicev1.GetAuditLogsResponse, error) {
+	return &cloudservicev1.GetAuditLogsResponse{Logs: s.logs}, nil
+}
+
+func (s *fakeCloudService) GetUsers(ctx context.Context, req *cloudservicev1.GetUsersRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUsersResponse, error) {
+	return &cloudservicev1.GetUsersResponse{Users: []*identityv1.User{
+		{Id: "u1", Spec: &identityv1.UserSpec{Email: "alice@example.com", Access: role(identityv1.AccountAccess_ROLE_READ)}},
+	}}, nil
+}
+
+func (s *fakeCloudService) GetServiceAccounts(ctx context.Context, req *cloudservicev1.GetServiceAccountsRequest, opts ...grpc.CallOption) (*cloudservicev1.GetServiceAccountsResponse, error) {
+	deployer := role(identityv1.AccountAccess_ROLE_ADMIN)
+	deployer.NamespaceAccesses = map[string]*identityv1.NamespaceAccess{
+		"prod.a1b2c": {Permission: identityv1.NamespaceAccess_PERMISSION_ADMIN},
+		"dev.a1b2c":  {Permission: identityv1.NamespaceAccess_PERMISSION_WRITE},
+	}
+	return &cloudservicev1.GetServiceAccountsResponse{ServiceAccount: []*identityv1.ServiceAccount{
+		{Id: "sa1", Spec: &identityv1.ServiceAccountSpec{Name: "deployer", Access: deployer}},
+		{Id: "sa2", Spec: &identityv1.ServiceAccountSpec{Name: "provisioner", Access: role(identityv1.AccountAccess_ROLE_ADMIN)}},
+	}}, nil
+}
+
+func role(r identityv1.AccountAccess_Role) *identityv1.Access {
+	return &identityv1.Access{AccountAccess: &identityv1.AccountAccess{Role: r}}
+}
+
+func TestCollect(t *testing.T) {
+	user := record(t, "u1", "GetNamespace", "prod.a1b2c")
+	user.Principal.Type = "user"
+	usages, err := Collect(func(yield func(*auditlogv1.LogRecord, error) bool) {
+		_ = yield(record(t, "sa1", "GetNamespace", "prod.a1b2c"), nil) && yield(user, nil)
+	})
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if got := usages["sa1"].PrincipalType; got != string(accessreview.PrincipalServiceAccount) {
+		t.Errorf("Collect() sa1 type = %q, want %q", got, accessreview.PrincipalServiceAccount)
+	}
+	if got := usages["u1"].PrincipalType; got != string(accessreview.PrincipalUser) {
+		t.Errorf("Collect() u1 type = %q, want %q", got, accessreview.PrincipalUser)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	fake := &fakeCloudService{logs: []*auditlogv1.LogRecord{
+		record(t, "sa1", "GetNamespace", "prod.a1b2c"),
+		record(t, "sa1", "UpdateNamespace", "prod.a1b2c"),
+		record(t, "sa1", "GetNamespace", "prod.a1b2c"),
+		record(t, "sa2", "CreateNamespace", ""),
+		record(t, "sa2", "CreateServiceAccount", ""),
+		record(t, "sa2", "GetUsers", ""),
+		// the failed operations do not require an access.
+		failed(record(t, "sa1", "DeleteNamespace", "prod.a1b2c")),
+		failed(record(t, "u1", "CreateUser", "")),
+	}}
+	recommendations, err := Analyze(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), Options{End: now, NarrowNamespaceAccess: true})
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if len(recommendations) != 3 {
+		t.Fatalf("Analyze() = %v, want a recommendation per principal", recommendations)
+	}
+
+	deployer := recommendations[0]
+	if deployer.PrincipalType != "service_account" || deployer.PrincipalName != "deployer" || deployer.Usage.Operations["DeleteNamespace"] != nil || deployer.Usage.Operations["GetNamespace"]["prod.a1b2c"] != 2 {
+		t.Fatalf("Analyze() deployer = %+v", deployer)
+	}
+	proposed := deployer.Proposed
+	if !deployer.Overprivileged || proposed.GetAccountAccess().GetRole() != identityv1.AccountAccess_ROLE_READ ||
+		len(proposed.GetNamespaceAccesses()) != 1 ||
+		proposed.GetNamespaceAccesses()["prod.a1b2c"].GetPermission() != identityv1.NamespaceAccess_PERMISSION_WRITE {
+		t.Errorf("Analyze() deployer proposed = %v, want READ and WRITE on prod only", proposed)
+	}
+	if deployer.CustomRole != nil {
+		t.Errorf("Analyze() deployer custom role = %v, want none", deployer.CustomRole)
+	}
+
+	provisioner := recommendations[1]
+	if provisioner.Overprivileged || provisioner.Proposed.GetAccountAccess().GetRole() != identityv1.AccountAccess_ROLE_ADMIN {
+		t.Errorf("Analyze() provisioner proposed = %v, want ADMIN kept", provisioner.Proposed)
+	}
+	custom := provisioner.CustomRole
+	if custom == nil || len(custom.GetPermissions()) != 1 ||
+		custom.GetPermissions()[0].GetResources().GetResourceType() != "service_account" ||
+		strings.Join(custom.GetPermissions()[0].GetActions(), ",") != "CreateServiceAccount" ||
+		!strings.Contains(strings.Join(provisioner.Reasons, "\n"), "alternatively, ROLE_DEVELOPER with the custom role") {
+		t.Errorf("Analyze() provisioner custom role = %v, reasons = %q", custom, provisioner.Reasons)
+	}
+	if err := customroles.Validate(custom, nil); err != nil {
+		t.Errorf("Analyze() provisioner custom role is invalid: %v", err)
+	}
+
+	alice := recommendations[2]
+	if alice.Overprivileged || alice.Usage != nil || alice.Reasons[0] != "no operation in the audit logs of the window" {
+		t.Errorf("Analyze() alice = %+v", alice)
+	}
+}
+
+func TestRecommendKeepsNamespaceAccess(t *testing.T) {
+	current := role(identityv1.AccountAccess_ROLE_DEVELOPER)
+	current.NamespaceAccesses = map[string]*identityv1.NamespaceAccess{
+		"prod.a1b2c": {Permission: identityv1.NamespaceAccess_PERMISSION_ADMIN},
+	}
+	usage := &Usage{PrincipalID: "u1", Operations: map[string]map[string]int{"GetNamespaces": {"": 1}}}
+	r := Recommend(current, usage, Options{})
+	if !r.Overprivileged || r.Proposed.GetAccountAccess().GetRole() != identityv1.AccountAccess_ROLE_READ ||
+		r.Proposed.GetNamespaceAccesses()["prod.a1b2c"].GetPermission() != identityv1.NamespaceAccess_PERMISSION_ADMIN {
+		t.Errorf("Recommend() = %v, want READ and the namespace permissions kept", r.Proposed)
+	}
+}
+
+// allowed reports whether the access allows the operation on the namespace, per the rules of Recommend,
+// ignoring the custom roles.
+func allowed(access *identityv1.Access, operation, namespace string) bool {
+	role := access.GetAccountAccess().GetRole()
+	switch resourceType := resourceTypeOf(customroles.DefaultCatalog(), operation); resourceType {
+	case "namespace":
+		if covers(role, identityv1.AccountAccess_ROLE_ADMIN) {
+			return true
+		}
+		permission := access.GetNamespaceAccesses()[namespace].GetPermission()
+		required := requiredPermission(operation)
+		return permission != identityv1.NamespaceAccess_PERMISSION_UNSPECIFIED && stronger(permission, required) == permission
+	case "api_key":
+		return true
+	default:
+		return covers(role, requiredRole(resourceType, operation))
+	}
+}
+
+func TestRecommendAllowsObservedOperations(t *testing.T) {
+	current := role(identityv1.AccountAccess_ROLE_ADMIN)
+	current.NamespaceAccesses = map[string]*identityv1.NamespaceAccess{
+		"dev.a1b2c": {Permission: identityv1.NamespaceAccess_PERMISSION_READ},
+	}
+	usage := &Usage{PrincipalID: "sa1", Operations: map[string]map[string]int{
+		"UpdateNamespace": {"prod.a1b2c": 3, "dev.a1b2c": 1},
+		"GetNamespace":    {"staging.a1b2c": 1},
+		"GetUsers":        {"": 2},
+	}}
+	for _, narrow := range []bool{false, true} {
+		r := Recommend(current, usage, Options{NarrowNamespaceAccess: narrow})
+		if !r.Overprivileged || r.Proposed.GetAccountAccess().GetRole() != identityv1.AccountAccess_ROLE_READ {
+			t.Fatalf("Recommend(narrow=%v) = %v, want READ", narrow, r.Proposed)
+		}
+		for operation, namespaces := range usage.Operations {
+			for ns := range namespaces {
+				if !allowed(r.Proposed, operation, ns) {
+					t.Errorf("Recommend(narrow=%v) = %v, does not allow %s on %q", narrow, r.Proposed, operation, ns)
+				}
+			}
+		}
+		if got := r.Proposed.GetNamespaceAccesses()["prod.a1b2c"].GetPermission(); got != identityv1.NamespaceAccess_PERMISSION_WRITE {
+			t.Errorf("Recommend(narrow=%v) permission on prod.a1b2c = %v, want WRITE", narrow, got)
+		}
+	}
+	// the current access is not modified.
+	if len(current.GetNamespaceAccesses()) != 1 || current.GetNamespaceAccesses()["dev.a1b2c"].GetPermission() != identityv1.NamespaceAccess_PERMISSION_READ {
+		t.Errorf("Recommend() modified the current access: %v", current)
+	}
+}
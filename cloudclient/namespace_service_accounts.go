@@ -0,0 +1,176 @@
+package cloudclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	identity "go.temporal.io/cloud-sdk/api/identity/v1"
+	"go.temporal.io/cloud-sdk/internal/resources"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NamespaceServiceAccountKeyTTL is how long the API key of a service account created by CreateNamespaceServiceAccount
+// is valid for, unless NamespaceServiceAccountOptions.KeyTTL is provided.
+const NamespaceServiceAccountKeyTTL = 90 * 24 * time.Hour
+
+type (
+	// NamespaceServiceAccount is a service account limited to a single namespace, with its API key.
+	NamespaceServiceAccount struct {
+		ServiceAccount *identity.ServiceAccount
+		// APIKeyID is the id of the API key of the service account.
+		APIKeyID string
+		// APIKeyToken is the secret to authenticate with, it cannot be retrieved later on.
+		APIKeyToken string
+	}
+
+	// NamespaceServiceAccountOptions configure CreateNamespaceServiceAccountWithOptions.
+	NamespaceServiceAccountOptions struct {
+		// How long the API key of the service account is valid for.
+		// If not provided, NamespaceServiceAccountKeyTTL is used.
+		KeyTTL time.Duration
+	}
+
+	// OrphanedServiceAccount is a namespace scoped service account whose namespace no longer exists.
+	OrphanedServiceAccount struct {
+		ServiceAccount *identity.ServiceAccount
+		// APIKeyIDs are the ids of the API keys owned by the service account.
+		APIKeyIDs []string
+		// Deleted reports whether the service account and its API keys were deleted.
+		Deleted bool
+	}
+)
+
+// CreateNamespaceServiceAccount creates a service account with the given permission on a single namespace, and an
+// API key for it valid for NamespaceServiceAccountKeyTTL. If the API key cannot be created, the service account is
+// deleted so that no service account without key is left behind.
+func (c *Client) CreateNamespaceServiceAccount(
+	ctx context.Context,
+	namespace string,
+	name string,
+	permission identity.NamespaceAccess_Permission,
+) (*NamespaceServiceAccount, error) {
+	return c.CreateNamespaceServiceAccountWithOptions(ctx, namespace, name, permission, NamespaceServiceAccountOptions{})
+}
+
+// CreateNamespaceServiceAccountWithOptions is CreateNamespaceServiceAccount with options, such as the time to live of
+// the API key.
+func (c *Client) CreateNamespaceServiceAccountWithOptions(
+	ctx context.Context,
+	namespace string,
+	name string,
+	permission identity.NamespaceAccess_Permission,
+	options NamespaceServiceAccountOptions,
+) (*NamespaceServiceAccount, error) {
+	ttl := options.KeyTTL
+	if ttl <= 0 {
+		ttl = NamespaceServiceAccountKeyTTL
+	}
+	if _, err := c.Namespaces().Get(ctx, namespace); err != nil {
+		return nil, fmt.Errorf("failed to get namespace %q: %w", namespace, err)
+	}
+	id, op, err := c.ServiceAccounts().Create(ctx, &identity.ServiceAccountSpec{
+		Name:        name,
+		Description: fmt.Sprintf("Service account scoped to namespace %s", namespace),
+		NamespaceScopedAccess: &identity.NamespaceScopedAccess{
+			Namespace: namespace,
+			Access:    &identity.NamespaceAccess{Permission: permission},
+		},
+	})
+	if err == nil {
+		err = op.Wait(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service account %q: %w", name, err)
+	}
+
+	keyID, token, op, err := c.APIKeys().Create(ctx, &identity.ApiKeySpec{
+		OwnerId:     id,
+		OwnerType:   identity.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT,
+		DisplayName: name,
+		ExpiryTime:  timestamppb.New(time.Now().Add(ttl)),
+	})
+	if err == nil {
+		err = op.Wait(ctx)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to create API key for service account %s: %w", id, err)
+		if op, deleteErr := c.ServiceAccounts().Delete(ctx, id, ""); deleteErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to delete service account %s: %w", id, deleteErr))
+		} else if waitErr := op.Wait(ctx); waitErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to delete service account %s: %w", id, waitErr))
+		}
+		return nil, err
+	}
+
+	sa, err := c.ServiceAccounts().Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service account %s: %w", id, err)
+	}
+	return &NamespaceServiceAccount{ServiceAccount: sa, APIKeyID: keyID, APIKeyToken: token}, nil
+}
+
+// DeleteOrphanedNamespaceServiceAccounts finds the namespace scoped service accounts whose namespace no longer
+// exists, and deletes them along with their API keys unless dryRun is set. A namespace being deleted counts as no
+// longer existing.
+//
+// The orphaned service accounts are returned even on error, the ones deleted so far are marked as such.
+// Running it again resumes the cleanup: the service accounts and API keys already being deleted are skipped, and
+// the ones deleted in the meantime are considered deleted.
+func (c *Client) DeleteOrphanedNamespaceServiceAccounts(ctx context.Context, dryRun bool) ([]*OrphanedServiceAccount, error) {
+	namespaces, err := c.Namespaces().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	exists := make(map[string]bool, len(namespaces))
+	for _, ns := range namespaces {
+		if !resources.Deleted(ns.GetState()) {
+			exists[ns.GetNamespace()] = true
+		}
+	}
+	serviceAccounts, err := c.ServiceAccounts().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+
+	var orphans []*OrphanedServiceAccount
+	for _, sa := range serviceAccounts {
+		scoped := sa.GetSpec().GetNamespaceScopedAccess()
+		if scoped == nil || exists[scoped.GetNamespace()] || resources.Deleted(sa.GetState()) {
+			continue
+		}
+		keys, err := c.APIKeys().ListByOwner(ctx, sa.GetId(), identity.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT)
+		if err != nil {
+			return orphans, fmt.Errorf("failed to list API keys of service account %s: %w", sa.GetId(), err)
+		}
+		keys = slices.DeleteFunc(keys, func(key *identity.ApiKey) bool { return resources.Deleted(key.GetState()) })
+		orphan := &OrphanedServiceAccount{ServiceAccount: sa}
+		for _, key := range keys {
+			orphan.APIKeyIDs = append(orphan.APIKeyIDs, key.GetId())
+		}
+		orphans = append(orphans, orphan)
+		if dryRun {
+			continue
+		}
+		for _, key := range keys {
+			op, err := c.APIKeys().Delete(ctx, key.GetId(), key.GetResourceVersion())
+			if err == nil {
+				err = op.Wait(ctx)
+			}
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				return orphans, fmt.Errorf("failed to delete API key %s of service account %s: %w", key.GetId(), sa.GetId(), err)
+			}
+		}
+		op, err := c.ServiceAccounts().Delete(ctx, sa.GetId(), "")
+		if err == nil {
+			err = op.Wait(ctx)
+		}
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return orphans, fmt.Errorf("failed to delete service account %s: %w", sa.GetId(), err)
+		}
+		orphan.Deleted = true
+	}
+	return orphans, nil
+}
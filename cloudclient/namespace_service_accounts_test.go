@@ -0,0 +1,222 @@
+package cloudclient_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cloudservice "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identity "go.temporal.io/cloud-sdk/api/identity/v1"
+	namespace "go.temporal.io/cloud-sdk/api/namespace/v1"
+	operation "go.temporal.io/cloud-sdk/api/operation/v1"
+	resource "go.temporal.io/cloud-sdk/api/resource/v1"
+	"go.temporal.io/cloud-sdk/cloudclient"
+	"go.temporal.io/cloud-sdk/cloudclient/cloudservicemock"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// expectFulfilled expects the async operation to be checked once, and fulfilled.
+func expectFulfilled(client *cloudservicemock.MockCloudServiceClient, id string) *gomock.Call {
+	return client.EXPECT().
+		GetAsyncOperation(gomock.Any(), cloudservicemock.EqualProto(&cloudservice.GetAsyncOperationRequest{AsyncOperationId: id})).
+		Return(&cloudservice.GetAsyncOperationResponse{AsyncOperation: &operation.AsyncOperation{
+			Id:    id,
+			State: operation.AsyncOperation_STATE_FULFILLED,
+		}}, nil)
+}
+
+func TestCreateNamespaceServiceAccount(t *testing.T) {
+	ctx := context.Background()
+	spec := &identity.ServiceAccountSpec{
+		Name:        "ci",
+		Description: "Service account scoped to namespace prod.acct",
+		NamespaceScopedAccess: &identity.NamespaceScopedAccess{
+			Namespace: "prod.acct",
+			Access:    &identity.NamespaceAccess{Permission: identity.NamespaceAccess_PERMISSION_WRITE},
+		},
+	}
+	// expectCreate expects the service account to be created, and returns the call creating its key.
+	expectCreate := func(client *cloudservicemock.MockCloudServiceClient) *gomock.Call {
+		gomock.InOrder(
+			client.EXPECT().
+				GetNamespace(gomock.Any(), cloudservicemock.EqualProto(&cloudservice.GetNamespaceRequest{Namespace: "prod.acct"})).
+				Return(&cloudservice.GetNamespaceResponse{Namespace: &namespace.Namespace{Namespace: "prod.acct"}}, nil),
+			client.EXPECT().
+				CreateServiceAccount(gomock.Any(), cloudservicemock.EqualProto(&cloudservice.CreateServiceAccountRequest{Spec: spec})).
+				Return(&cloudservice.CreateServiceAccountResponse{ServiceAccountId: "sa", AsyncOperation: &operation.AsyncOperation{Id: "op-sa"}}, nil),
+			expectFulfilled(client, "op-sa"),
+		)
+		return client.EXPECT().CreateApiKey(gomock.Any(), gomock.Any())
+	}
+
+	t.Run("Created", func(t *testing.T) {
+		client := cloudservicemock.NewMockCloudServiceClient(gomock.NewController(t))
+		var createKey *cloudservice.CreateApiKeyRequest
+		gomock.InOrder(
+			expectCreate(client).DoAndReturn(func(_ context.Context, req *cloudservice.CreateApiKeyRequest, _ ...grpc.CallOption) (*cloudservice.CreateApiKeyResponse, error) {
+				createKey = req
+				return &cloudservice.CreateApiKeyResponse{KeyId: "key", Token: "secret", AsyncOperation: &operation.AsyncOperation{Id: "op-key"}}, nil
+			}),
+			expectFulfilled(client, "op-key"),
+			client.EXPECT().
+				GetServiceAccount(gomock.Any(), cloudservicemock.EqualProto(&cloudservice.GetServiceAccountRequest{ServiceAccountId: "sa"})).
+				Return(&cloudservice.GetServiceAccountResponse{ServiceAccount: &identity.ServiceAccount{Id: "sa", Spec: spec}}, nil),
+		)
+		sa, err := cloudclient.NewWithCloudServiceClient(client).
+			CreateNamespaceServiceAccount(ctx, "prod.acct", "ci", identity.NamespaceAccess_PERMISSION_WRITE)
+		if err != nil {
+			t.Fatalf("CreateNamespaceServiceAccount() error = %v", err)
+		}
+		if sa.ServiceAccount.GetId() != "sa" || sa.APIKeyID != "key" || sa.APIKeyToken != "secret" {
+			t.Errorf("CreateNamespaceServiceAccount() = %v %q %q, want sa key secret", sa.ServiceAccount, sa.APIKeyID, sa.APIKeyToken)
+		}
+		if spec := createKey.GetSpec(); spec.GetOwnerId() != "sa" || spec.GetOwnerType() != identity.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT || spec.GetDisplayName() != "ci" {
+			t.Errorf("CreateNamespaceServiceAccount() key spec = %v", spec)
+		}
+		if expiry := createKey.GetSpec().GetExpiryTime().AsTime(); time.Until(expiry) < cloudclient.NamespaceServiceAccountKeyTTL-time.Minute {
+			t.Errorf("CreateNamespaceServiceAccount() key expiry = %v, want in %v", expiry, cloudclient.NamespaceServiceAccountKeyTTL)
+		}
+	})
+
+	t.Run("Key TTL", func(t *testing.T) {
+		client := cloudservicemock.NewMockCloudServiceClient(gomock.NewController(t))
+		var createKey *cloudservice.CreateApiKeyRequest
+		gomock.InOrder(
+			expectCreate(client).DoAndReturn(func(_ context.Context, req *cloudservice.CreateApiKeyRequest, _ ...grpc.CallOption) (*cloudservice.CreateApiKeyResponse, error) {
+				createKey = req
+				return &cloudservice.CreateApiKeyResponse{KeyId: "key", Token: "secret"}, nil
+			}),
+			client.EXPECT().
+				GetServiceAccount(gomock.Any(), cloudservicemock.EqualProto(&cloudservice.GetServiceAccountRequest{ServiceAccountId: "sa"})).
+				Return(&cloudservice.GetServiceAccountResponse{ServiceAccount: &identity.ServiceAccount{Id: "sa", Spec: spec}}, nil),
+		)
+		options := cloudclient.NamespaceServiceAccountOptions{KeyTTL: 24 * time.Hour}
+		_, err := cloudclient.NewWithCloudServiceClient(client).
+			CreateNamespaceServiceAccountWithOptions(ctx, "prod.acct", "ci", identity.NamespaceAccess_PERMISSION_WRITE, options)
+		if err != nil {
+			t.Fatalf("CreateNamespaceServiceAccountWithOptions() error = %v", err)
+		}
+		if expiry := createKey.GetSpec().GetExpiryTime().AsTime(); time.Until(expiry) > 24*time.Hour {
+			t.Errorf("CreateNamespaceServiceAccountWithOptions() key expiry = %v, want in a day", expiry)
+		}
+	})
+
+	t.Run("Missing Namespace", func(t *testing.T) {
+		client := cloudservicemock.NewMockCloudServiceClient(gomock.NewController(t))
+		client.EXPECT().
+			GetNamespace(gomock.Any(), cloudservicemock.EqualProto(&cloudservice.GetNamespaceRequest{Namespace: "missing"})).
+			Return(nil, status.Error(codes.NotFound, "namespace not found"))
+		_, err := cloudclient.NewWithCloudServiceClient(client).
+			CreateNamespaceServiceAccount(ctx, "missing", "ci", identity.NamespaceAccess_PERMISSION_READ)
+		if !errors.Is(err, cloudclient.ErrNotFound) {
+			t.Errorf("CreateNamespaceServiceAccount() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("Rolled Back", func(t *testing.T) {
+		client := cloudservicemock.NewMockCloudServiceClient(gomock.NewController(t))
+		gomock.InOrder(
+			expectCreate(client).Return(nil, status.Error(codes.PermissionDenied, "denied")),
+			client.EXPECT().
+				GetServiceAccount(gomock.Any(), cloudservicemock.EqualProto(&cloudservice.GetServiceAccountRequest{ServiceAccountId: "sa"})).
+				Return(&cloudservice.GetServiceAccountResponse{ServiceAccount: &identity.ServiceAccount{Id: "sa", ResourceVersion: "rv"}}, nil),
+			client.EXPECT().
+				DeleteServiceAccount(gomock.Any(), cloudservicemock.EqualProto(&cloudservice.DeleteServiceAccountRequest{ServiceAccountId: "sa", ResourceVersion: "rv"})).
+				Return(&cloudservice.DeleteServiceAccountResponse{AsyncOperation: &operation.AsyncOperation{Id: "op-delete"}}, nil),
+			expectFulfilled(client, "op-delete"),
+		)
+		_, err := cloudclient.NewWithCloudServiceClient(client).
+			CreateNamespaceServiceAccount(ctx, "prod.acct", "ci", identity.NamespaceAccess_PERMISSION_WRITE)
+		if !errors.Is(err, cloudclient.ErrPermissionDenied) {
+			t.Errorf("CreateNamespaceServiceAccount() error = %v, want ErrPermissionDenied", err)
+		}
+	})
+}
+
+func TestDeleteOrphanedNamespaceServiceAccounts(t *testing.T) {
+	ctx := context.Background()
+	scoped := func(id, ns string, state resource.ResourceState) *identity.ServiceAccount {
+		return &identity.ServiceAccount{Id: id, ResourceVersion: "rv", State: state, Spec: &identity.ServiceAccountSpec{
+			NamespaceScopedAccess: &identity.NamespaceScopedAccess{Namespace: ns},
+		}}
+	}
+	// expectLists expects the namespaces, the service accounts and the API keys of the orphans to be listed.
+	expectLists := func(client *cloudservicemock.MockCloudServiceClient) {
+		keys := func(owner string, keys ...*identity.ApiKey) *gomock.Call {
+			return client.EXPECT().
+				GetApiKeys(gomock.Any(), cloudservicemock.EqualProto(&cloudservice.GetApiKeysRequest{
+					OwnerId:   owner,
+					OwnerType: identity.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT,
+				})).
+				Return(&cloudservice.GetApiKeysResponse{ApiKeys: keys}, nil)
+		}
+		gomock.InOrder(
+			client.EXPECT().
+				GetNamespaces(gomock.Any(), cloudservicemock.EqualProto(&cloudservice.GetNamespacesRequest{})).
+				Return(&cloudservice.GetNamespacesResponse{Namespaces: []*namespace.Namespace{
+					{Namespace: "live"},
+					{Namespace: "going", State: resource.ResourceState_RESOURCE_STATE_DELETING},
+				}}, nil),
+			client.EXPECT().
+				GetServiceAccounts(gomock.Any(), cloudservicemock.EqualProto(&cloudservice.GetServiceAccountsRequest{})).
+				Return(&cloudservice.GetServiceAccountsResponse{ServiceAccount: []*identity.ServiceAccount{
+					scoped("sa-live", "live", resource.ResourceState_RESOURCE_STATE_ACTIVE),
+					scoped("sa-going", "going", resource.ResourceState_RESOURCE_STATE_ACTIVE),
+					scoped("sa-gone", "gone", resource.ResourceState_RESOURCE_STATE_ACTIVE),
+					// already being deleted by a previous run
+					scoped("sa-deleting", "gone", resource.ResourceState_RESOURCE_STATE_DELETING),
+					{Id: "sa-account", Spec: &identity.ServiceAccountSpec{Access: &identity.Access{}}},
+				}}, nil),
+			keys("sa-going"),
+			keys("sa-gone",
+				&identity.ApiKey{Id: "key-gone", ResourceVersion: "rv"},
+				&identity.ApiKey{Id: "key-deleted", ResourceVersion: "rv", State: resource.ResourceState_RESOURCE_STATE_DELETED},
+			),
+		)
+	}
+
+	t.Run("Dry Run", func(t *testing.T) {
+		client := cloudservicemock.NewMockCloudServiceClient(gomock.NewController(t))
+		expectLists(client)
+		orphans, err := cloudclient.NewWithCloudServiceClient(client).DeleteOrphanedNamespaceServiceAccounts(ctx, true)
+		if err != nil {
+			t.Fatalf("DeleteOrphanedNamespaceServiceAccounts() error = %v", err)
+		}
+		if len(orphans) != 2 || orphans[0].ServiceAccount.GetId() != "sa-going" || orphans[1].ServiceAccount.GetId() != "sa-gone" ||
+			len(orphans[1].APIKeyIDs) != 1 || orphans[1].APIKeyIDs[0] != "key-gone" || orphans[1].Deleted {
+			t.Errorf("DeleteOrphanedNamespaceServiceAccounts() dry run = %v", orphans)
+		}
+	})
+
+	t.Run("Resumed", func(t *testing.T) {
+		client := cloudservicemock.NewMockCloudServiceClient(gomock.NewController(t))
+		// The previous run was interrupted after deleting sa-going, whose deletion completed since, and key-gone.
+		gomock.InOrder(
+			client.EXPECT().
+				GetServiceAccount(gomock.Any(), cloudservicemock.EqualProto(&cloudservice.GetServiceAccountRequest{ServiceAccountId: "sa-going"})).
+				Return(nil, status.Error(codes.NotFound, "service account not found")),
+			client.EXPECT().
+				DeleteApiKey(gomock.Any(), cloudservicemock.EqualProto(&cloudservice.DeleteApiKeyRequest{KeyId: "key-gone", ResourceVersion: "rv"})).
+				Return(nil, status.Error(codes.NotFound, "api key not found")),
+			client.EXPECT().
+				GetServiceAccount(gomock.Any(), cloudservicemock.EqualProto(&cloudservice.GetServiceAccountRequest{ServiceAccountId: "sa-gone"})).
+				Return(&cloudservice.GetServiceAccountResponse{ServiceAccount: scoped("sa-gone", "gone", resource.ResourceState_RESOURCE_STATE_ACTIVE)}, nil),
+			client.EXPECT().
+				DeleteServiceAccount(gomock.Any(), cloudservicemock.EqualProto(&cloudservice.DeleteServiceAccountRequest{ServiceAccountId: "sa-gone", ResourceVersion: "rv"})).
+				Return(&cloudservice.DeleteServiceAccountResponse{AsyncOperation: &operation.AsyncOperation{Id: "op"}}, nil),
+			expectFulfilled(client, "op"),
+		)
+		expectLists(client)
+		orphans, err := cloudclient.NewWithCloudServiceClient(client).DeleteOrphanedNamespaceServiceAccounts(ctx, false)
+		if err != nil {
+			t.Fatalf("DeleteOrphanedNamespaceServiceAccounts() error = %v", err)
+		}
+		if len(orphans) != 2 || !orphans[0].Deleted || !orphans[1].Deleted {
+			t.Errorf("DeleteOrphanedNamespaceServiceAccounts() = %v, want both orphans deleted", orphans)
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package cloudclient
+
+import (
+	"context"
+
+	cloudservice "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	namespace "go.temporal.io/cloud-sdk/api/namespace/v1"
+	"go.temporal.io/cloud-sdk/internal/paging"
+)
+
+type (
+	// NamespaceClient to manage the namespaces of the account.
+	NamespaceClient interface {
+		// Get returns the namespace with the given name.
+		Get(ctx context.Context, name string) (*namespace.Namespace, error)
+		// List returns every namespace of the account.
+		List(ctx context.Context) ([]*namespace.Namespace, error)
+		// Create starts the creation of a namespace and returns its name.
+		Create(ctx context.Context, spec *namespace.NamespaceSpec, tags map[string]string) (string, *AsyncOperation, error)
+		// Update replaces the spec of a namespace with ns.Spec.
+		// The update is rejected if the namespace changed since ns was retrieved, see ns.ResourceVersion.
+		Update(ctx context.Context, ns *namespace.Namespace) (*AsyncOperation, error)
+		// UpdateTags adds or replaces the tags to upsert and removes the tags to remove.
+		UpdateTags(ctx context.Context, name string, upsert map[string]string, remove []string) (*AsyncOperation, error)
+		// Delete deletes a namespace.
+		// If resourceVersion is empty, the latest version of the namespace is deleted.
+		Delete(ctx context.Context, name string, resourceVersion string) (*AsyncOperation, error)
+	}
+
+	namespaceClient struct {
+		client cloudservice.CloudServiceClient
+	}
+)
+
+func (c namespaceClient) Get(ctx context.Context, name string) (*namespace.Namespace, error) {
+	resp, err := c.client.GetNamespace(ctx, &cloudservice.GetNamespaceRequest{
+		Namespace: name,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return resp.GetNamespace(), nil
+}
+
+func (c namespaceClient) List(ctx context.Context) ([]*namespace.Namespace, error) {
+	namespaces, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*namespace.Namespace, string, error) {
+		resp, err := c.client.GetNamespaces(ctx, &cloudservice.GetNamespacesRequest{PageToken: pageToken})
+		return resp.GetNamespaces(), resp.GetNextPageToken(), err
+	})
+	return namespaces, toAPIError(err)
+}
+
+func (c namespaceClient) Create(ctx context.Context, spec *namespace.NamespaceSpec, tags map[string]string) (string, *AsyncOperation, error) {
+	resp, err := c.client.CreateNamespace(ctx, &cloudservice.CreateNamespaceRequest{
+		Spec: spec,
+		Tags: tags,
+	})
+	if err != nil {
+		return "", nil, toAPIError(err)
+	}
+	return resp.GetNamespace(), newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c namespaceClient) Update(ctx context.Context, ns *namespace.Namespace) (*AsyncOperation, error) {
+	resp, err := c.client.UpdateNamespace(ctx, &cloudservice.UpdateNamespaceRequest{
+		Namespace:       ns.GetNamespace(),
+		Spec:            ns.GetSpec(),
+		ResourceVersion: ns.GetResourceVersion(),
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c namespaceClient) UpdateTags(ctx context.Context, name string, upsert map[string]string, remove []string) (*AsyncOperation, error) {
+	resp, err := c.client.UpdateNamespaceTags(ctx, &cloudservice.UpdateNamespaceTagsRequest{
+		Namespace:    name,
+		TagsToUpsert: upsert,
+		TagsToRemove: remove,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c namespaceClient) Delete(ctx context.Context, name string, resourceVersion string) (*AsyncOperation, error) {
+	if resourceVersion == "" {
+		ns, err := c.Get(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		resourceVersion = ns.GetResourceVersion()
+	}
+	resp, err := c.client.DeleteNamespace(ctx, &cloudservice.DeleteNamespaceRequest{
+		Namespace:       name,
+		ResourceVersion: resourceVersion,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
@@ -0,0 +1,108 @@
+package cloudclient
+
+import (
+	"context"
+	"fmt"
+
+	cloudservice "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	nexus "go.temporal.io/cloud-sdk/api/nexus/v1"
+	"go.temporal.io/cloud-sdk/internal/paging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type (
+	// NexusEndpointClient to manage the Nexus endpoints of the account.
+	NexusEndpointClient interface {
+		// Get returns the endpoint with the given id.
+		Get(ctx context.Context, id string) (*nexus.Endpoint, error)
+		// GetByName returns the endpoint with the given name.
+		// An error matching ErrNotFound is returned if there is no such endpoint.
+		GetByName(ctx context.Context, name string) (*nexus.Endpoint, error)
+		// List returns every endpoint of the account.
+		List(ctx context.Context) ([]*nexus.Endpoint, error)
+		// Create starts the creation of an endpoint and returns its id.
+		Create(ctx context.Context, spec *nexus.EndpointSpec) (string, *AsyncOperation, error)
+		// Update replaces the spec of an endpoint with endpoint.Spec.
+		// The update is rejected if the endpoint changed since it was retrieved, see endpoint.ResourceVersion.
+		Update(ctx context.Context, endpoint *nexus.Endpoint) (*AsyncOperation, error)
+		// Delete deletes an endpoint.
+		// If resourceVersion is empty, the latest version of the endpoint is deleted.
+		Delete(ctx context.Context, id string, resourceVersion string) (*AsyncOperation, error)
+	}
+
+	nexusEndpointClient struct {
+		client cloudservice.CloudServiceClient
+	}
+)
+
+func (c nexusEndpointClient) Get(ctx context.Context, id string) (*nexus.Endpoint, error) {
+	resp, err := c.client.GetNexusEndpoint(ctx, &cloudservice.GetNexusEndpointRequest{
+		EndpointId: id,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return resp.GetEndpoint(), nil
+}
+
+func (c nexusEndpointClient) GetByName(ctx context.Context, name string) (*nexus.Endpoint, error) {
+	resp, err := c.client.GetNexusEndpoints(ctx, &cloudservice.GetNexusEndpointsRequest{
+		Name: name,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	if len(resp.GetEndpoints()) == 0 {
+		return nil, toAPIError(status.Error(codes.NotFound, fmt.Sprintf("nexus endpoint %q not found", name)))
+	}
+	return resp.GetEndpoints()[0], nil
+}
+
+func (c nexusEndpointClient) List(ctx context.Context) ([]*nexus.Endpoint, error) {
+	endpoints, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*nexus.Endpoint, string, error) {
+		resp, err := c.client.GetNexusEndpoints(ctx, &cloudservice.GetNexusEndpointsRequest{PageToken: pageToken})
+		return resp.GetEndpoints(), resp.GetNextPageToken(), err
+	})
+	return endpoints, toAPIError(err)
+}
+
+func (c nexusEndpointClient) Create(ctx context.Context, spec *nexus.EndpointSpec) (string, *AsyncOperation, error) {
+	resp, err := c.client.CreateNexusEndpoint(ctx, &cloudservice.CreateNexusEndpointRequest{
+		Spec: spec,
+	})
+	if err != nil {
+		return "", nil, toAPIError(err)
+	}
+	return resp.GetEndpointId(), newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c nexusEndpointClient) Update(ctx context.Context, endpoint *nexus.Endpoint) (*AsyncOperation, error) {
+	resp, err := c.client.UpdateNexusEndpoint(ctx, &cloudservice.UpdateNexusEndpointRequest{
+		EndpointId:      endpoint.GetId(),
+		Spec:            endpoint.GetSpec(),
+		ResourceVersion: endpoint.GetResourceVersion(),
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c nexusEndpointClient) Delete(ctx context.Context, id string, resourceVersion string) (*AsyncOperation, error) {
+	if resourceVersion == "" {
+		endpoint, err := c.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		resourceVersion = endpoint.GetResourceVersion()
+	}
+	resp, err := c.client.DeleteNexusEndpoint(ctx, &cloudservice.DeleteNexusEndpointRequest{
+		EndpointId:      id,
+		ResourceVersion: resourceVersion,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
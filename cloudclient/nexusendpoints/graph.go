@@ -0,0 +1,200 @@
+package nexusendpoints
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	nexusv1 "go.temporal.io/cloud-sdk/api/nexus/v1"
+	"go.temporal.io/cloud-sdk/internal/resources"
+)
+
+// The kinds of nodes of a graph.
+const (
+	KindNamespace NodeKind = "namespace"
+	KindEndpoint  NodeKind = "endpoint"
+	KindTaskQueue NodeKind = "task_queue"
+)
+
+// The kinds of edges of a graph.
+const (
+	// A caller namespace is allowed to call an endpoint.
+	EdgeCalls EdgeKind = "calls"
+	// An endpoint forwards the requests to a task queue.
+	EdgeTargets EdgeKind = "targets"
+)
+
+type (
+	// NodeKind is a kind of node.
+	NodeKind string
+
+	// EdgeKind is a kind of edge.
+	EdgeKind string
+
+	// Graph maps the caller namespaces of the Nexus endpoints of an account, the endpoints, and their target
+	// task queues.
+	Graph struct {
+		Nodes []*Node `json:"nodes"`
+		Edges []*Edge `json:"edges"`
+		// The endpoints whose target or allowed namespaces no longer exist.
+		Dangling []*DanglingEndpoint `json:"dangling,omitempty"`
+	}
+
+	// Node is a namespace, an endpoint or a task queue.
+	Node struct {
+		// The id of the node, unique within the graph, such as "namespace:payments.a1b2c".
+		ID   string   `json:"id"`
+		Kind NodeKind `json:"kind"`
+		// The name of the namespace or the endpoint, or the name of the task queue.
+		Label string `json:"label"`
+		// The namespace of a task queue.
+		Namespace string `json:"namespace,omitempty"`
+		// Whether the namespace, or the namespace of the task queue, no longer exists.
+		Missing bool `json:"missing,omitempty"`
+	}
+
+	// Edge connects a caller namespace to an endpoint, or an endpoint to its target task queue.
+	Edge struct {
+		From string   `json:"from"`
+		To   string   `json:"to"`
+		Kind EdgeKind `json:"kind"`
+	}
+
+	// DanglingEndpoint is an endpoint whose target or allowed namespaces no longer exist.
+	DanglingEndpoint struct {
+		EndpointID string `json:"endpoint_id"`
+		Name       string `json:"name"`
+		// The target namespace, if it no longer exists.
+		MissingTarget string `json:"missing_target,omitempty"`
+		// The allowed namespaces that no longer exist.
+		MissingCallers []string `json:"missing_callers,omitempty"`
+	}
+)
+
+// LoadGraph lists the Nexus endpoints and the namespaces of the account and returns their graph.
+func LoadGraph(ctx context.Context, client cloudservicev1.CloudServiceClient) (*Graph, error) {
+	endpoints, err := listEndpoints(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := listNamespaces(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return NewGraph(endpoints, namespaces), nil
+}
+
+// NewGraph returns the graph of the endpoints, given the namespaces of the account. Only the namespaces connected
+// to an endpoint are in the graph. A namespace deleted, or being deleted, is missing.
+func NewGraph(endpoints []*nexusv1.Endpoint, namespaces []*namespacev1.Namespace) *Graph {
+	exists := map[string]bool{}
+	for _, ns := range namespaces {
+		if !resources.Deleted(ns.GetState()) {
+			exists[ns.GetNamespace()] = true
+		}
+	}
+	g := &Graph{}
+	nodes := map[string]*Node{}
+	node := func(n *Node) string {
+		if _, ok := nodes[n.ID]; !ok {
+			nodes[n.ID] = n
+			g.Nodes = append(g.Nodes, n)
+		}
+		return n.ID
+	}
+	namespaceNode := func(ns string) string {
+		return node(&Node{ID: "namespace:" + ns, Kind: KindNamespace, Label: ns, Missing: !exists[ns]})
+	}
+
+	for _, e := range endpoints {
+		spec := e.GetSpec()
+		endpoint := node(&Node{ID: "endpoint:" + e.GetId(), Kind: KindEndpoint, Label: spec.GetName()})
+		var dangling DanglingEndpoint
+		if target := spec.GetTargetSpec().GetWorkerTargetSpec(); target != nil {
+			ns := target.GetNamespaceId()
+			taskQueue := node(&Node{
+				ID:        "task_queue:" + ns + "/" + target.GetTaskQueue(),
+				Kind:      KindTaskQueue,
+				Label:     target.GetTaskQueue(),
+				Namespace: ns,
+				Missing:   !exists[ns],
+			})
+			g.Edges = append(g.Edges, &Edge{From: endpoint, To: taskQueue, Kind: EdgeTargets})
+			if !exists[ns] {
+				dangling.MissingTarget = ns
+			}
+		}
+		for _, policy := range spec.GetPolicySpecs() {
+			allowed := policy.GetAllowedCloudNamespacePolicySpec()
+			if allowed == nil {
+				continue
+			}
+			ns := allowed.GetNamespaceId()
+			g.Edges = append(g.Edges, &Edge{From: namespaceNode(ns), To: endpoint, Kind: EdgeCalls})
+			if !exists[ns] {
+				dangling.MissingCallers = append(dangling.MissingCallers, ns)
+			}
+		}
+		if dangling.MissingTarget != "" || len(dangling.MissingCallers) > 0 {
+			dangling.EndpointID, dangling.Name = e.GetId(), spec.GetName()
+			g.Dangling = append(g.Dangling, &dangling)
+		}
+	}
+
+	slices.SortFunc(g.Nodes, func(n1, n2 *Node) int {
+		return strings.Compare(n1.ID, n2.ID)
+	})
+	slices.SortFunc(g.Edges, func(e1, e2 *Edge) int {
+		return cmp.Or(strings.Compare(e1.From, e2.From), strings.Compare(e1.To, e2.To))
+	})
+	slices.SortFunc(g.Dangling, func(d1, d2 *DanglingEndpoint) int {
+		return cmp.Or(strings.Compare(d1.Name, d2.Name), strings.Compare(d1.EndpointID, d2.EndpointID))
+	})
+	return g
+}
+
+// WriteJSON writes the graph as an indented JSON object.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(g)
+}
+
+// WriteDOT writes the graph in the DOT language of Graphviz, from the caller namespaces on the left to the task
+// queues on the right. The missing namespaces and task queues, and the dangling endpoints, are drawn in red.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	dangling := map[string]bool{}
+	for _, d := range g.Dangling {
+		dangling["endpoint:"+d.EndpointID] = true
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph nexus {")
+	fmt.Fprintln(bw, "  rankdir=LR;")
+	for _, n := range g.Nodes {
+		label, shape := n.Label, "box"
+		switch n.Kind {
+		case KindEndpoint:
+			shape = "ellipse"
+		case KindTaskQueue:
+			label, shape = n.Namespace+"\n"+n.Label, "cds"
+		}
+		attrs := []string{"label=" + strconv.Quote(label), "shape=" + shape}
+		if n.Missing || dangling[n.ID] {
+			attrs = append(attrs, "color=red", "style=dashed")
+		}
+		fmt.Fprintf(bw, "  %s [%s];\n", strconv.Quote(n.ID), strings.Join(attrs, ", "))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(bw, "  %s -> %s [label=%s];\n", strconv.Quote(e.From), strconv.Quote(e.To), strconv.Quote(string(e.Kind)))
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
@@ -0,0 +1,230 @@
+// Package nexusendpoints builds and validates the Nexus endpoints of a Temporal Cloud account, and maps the
+// namespaces they connect.
+//
+// WARNING: The package is currently experimental.
+//
+// A Nexus endpoint forwards the requests of its caller namespaces, listed by its allowed namespace policies, to a
+// task queue of its target namespace. The Builder checks the specs of new endpoints against the account before
+// creating them, and the Graph of the existing endpoints shows which namespaces call which:
+//
+//	b, err := nexusendpoints.NewBuilder(ctx, client)
+//	...
+//	id, err := b.Create(ctx, nexusendpoints.Endpoint{
+//		Name:              "payments",
+//		TargetNamespace:   "payments.a1b2c",
+//		TaskQueue:         "payments-nexus",
+//		AllowedNamespaces: []string{"orders.a1b2c"},
+//	}, nexusendpoints.Options{})
+package nexusendpoints
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	commonv1 "go.temporal.io/api/common/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	nexusv1 "go.temporal.io/cloud-sdk/api/nexus/v1"
+	"go.temporal.io/cloud-sdk/internal/asyncop"
+	"go.temporal.io/cloud-sdk/internal/paging"
+	"go.temporal.io/cloud-sdk/internal/resources"
+)
+
+// maxNameLength is the maximum length of the name of an endpoint.
+const maxNameLength = 200
+
+// endpointNameRE is the format of the name of an endpoint, see nexusv1.EndpointSpec.Name.
+var endpointNameRE = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9\-]*[a-zA-Z0-9]$`)
+
+type (
+	// Endpoint configures a Nexus endpoint.
+	Endpoint struct {
+		// The name of the endpoint, unique within the account, such as "payments".
+		Name string
+		// The markdown description of the endpoint, if any.
+		Description string
+		// The namespace the requests are forwarded to, such as "payments.a1b2c".
+		TargetNamespace string
+		// The task queue of the target namespace the requests are forwarded to.
+		TaskQueue string
+		// The namespaces allowed to call the endpoint.
+		AllowedNamespaces []string
+	}
+
+	// Options to configure the creation of an endpoint.
+	Options struct {
+		// The interval between two polls of the async operation creating the endpoint.
+		// If not provided, the check duration suggested by the server is used.
+		PollInterval time.Duration
+	}
+
+	// Builder builds the specs of new endpoints, validated against the namespaces and endpoints of the account
+	// when the builder was created, and the endpoints built since.
+	Builder struct {
+		client     cloudservicev1.CloudServiceClient
+		namespaces map[string]bool
+		// the endpoint ids by lowercase name, empty for the endpoints built but not created
+		names map[string]string
+	}
+)
+
+// Spec validates the format of the configuration and returns the spec of the endpoint. The existence of the
+// namespaces and the uniqueness of the name are checked by Builder.Build.
+func (e Endpoint) Spec() (*nexusv1.EndpointSpec, error) {
+	var errs []error
+	if err := ValidateName(e.Name); err != nil {
+		errs = append(errs, err)
+	}
+	if e.TargetNamespace == "" {
+		errs = append(errs, errors.New("missing target namespace"))
+	}
+	if e.TaskQueue == "" {
+		errs = append(errs, errors.New("missing task queue"))
+	}
+	if len(e.AllowedNamespaces) == 0 {
+		errs = append(errs, errors.New("missing allowed namespaces: no namespace could call the endpoint"))
+	}
+	spec := &nexusv1.EndpointSpec{
+		Name: e.Name,
+		TargetSpec: &nexusv1.EndpointTargetSpec{Variant: &nexusv1.EndpointTargetSpec_WorkerTargetSpec{
+			WorkerTargetSpec: &nexusv1.WorkerTargetSpec{NamespaceId: e.TargetNamespace, TaskQueue: e.TaskQueue},
+		}},
+	}
+	for i, ns := range e.AllowedNamespaces {
+		switch {
+		case ns == "":
+			errs = append(errs, errors.New("empty allowed namespace"))
+			continue
+		case slices.Contains(e.AllowedNamespaces[:i], ns):
+			errs = append(errs, fmt.Errorf("namespace %q allowed more than once", ns))
+			continue
+		}
+		spec.PolicySpecs = append(spec.PolicySpecs, &nexusv1.EndpointPolicySpec{
+			Variant: &nexusv1.EndpointPolicySpec_AllowedCloudNamespacePolicySpec{
+				AllowedCloudNamespacePolicySpec: &nexusv1.AllowedCloudNamespacePolicySpec{NamespaceId: ns},
+			},
+		})
+	}
+	if e.Description != "" {
+		data, err := json.Marshal(e.Description)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal the description: %w", err)
+		}
+		spec.Description = &commonv1.Payload{
+			Metadata: map[string][]byte{"encoding": []byte("json/plain")},
+			Data:     data,
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid Nexus endpoint %q: %w", e.Name, errors.Join(errs...))
+	}
+	return spec, nil
+}
+
+// ValidateName returns an error if the name is not a valid endpoint name: letters, digits and hyphens, starting
+// with a letter and not ending with a hyphen.
+func ValidateName(name string) error {
+	if len(name) > maxNameLength || !endpointNameRE.MatchString(name) {
+		return fmt.Errorf("invalid name %q: expected letters, digits and hyphens, starting with a letter and not ending with a hyphen, up to %d characters", name, maxNameLength)
+	}
+	return nil
+}
+
+// NewBuilder creates a builder, listing the namespaces and endpoints of the account.
+func NewBuilder(ctx context.Context, client cloudservicev1.CloudServiceClient) (*Builder, error) {
+	namespaces, err := listNamespaces(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	endpoints, err := listEndpoints(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	b := &Builder{client: client, namespaces: map[string]bool{}, names: map[string]string{}}
+	for _, ns := range namespaces {
+		if !resources.Deleted(ns.GetState()) {
+			b.namespaces[ns.GetNamespace()] = true
+		}
+	}
+	for _, e := range endpoints {
+		b.names[strings.ToLower(e.GetSpec().GetName())] = e.GetId()
+	}
+	return b, nil
+}
+
+// Build validates the configuration and returns the spec of the endpoint. Besides the format checked by
+// Endpoint.Spec, the name must not be used by another endpoint, compared case insensitively, and the target and
+// allowed namespaces must exist. The name is then reserved, building another endpoint with it fails.
+func (b *Builder) Build(e Endpoint) (*nexusv1.EndpointSpec, error) {
+	spec, err := e.Spec()
+	if err != nil {
+		return nil, err
+	}
+	var errs []error
+	if id, ok := b.names[strings.ToLower(e.Name)]; ok {
+		if id != "" {
+			errs = append(errs, fmt.Errorf("name already used by endpoint %s", id))
+		} else {
+			errs = append(errs, errors.New("name already used by another endpoint being built"))
+		}
+	}
+	if !b.namespaces[e.TargetNamespace] {
+		errs = append(errs, fmt.Errorf("target namespace %q does not exist", e.TargetNamespace))
+	}
+	for _, ns := range e.AllowedNamespaces {
+		if !b.namespaces[ns] {
+			errs = append(errs, fmt.Errorf("allowed namespace %q does not exist", ns))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid Nexus endpoint %q: %w", e.Name, errors.Join(errs...))
+	}
+	b.names[strings.ToLower(e.Name)] = ""
+	return spec, nil
+}
+
+// Create builds the endpoint, creates it and waits for its creation to complete, and returns its id.
+func (b *Builder) Create(ctx context.Context, e Endpoint, options Options) (string, error) {
+	spec, err := b.Build(e)
+	if err != nil {
+		return "", err
+	}
+	resp, err := b.client.CreateNexusEndpoint(ctx, &cloudservicev1.CreateNexusEndpointRequest{Spec: spec})
+	if err != nil {
+		delete(b.names, strings.ToLower(e.Name))
+		return "", fmt.Errorf("failed to create Nexus endpoint %q: %w", e.Name, err)
+	}
+	b.names[strings.ToLower(e.Name)] = resp.GetEndpointId()
+	if _, err := asyncop.Wait(ctx, b.client, resp.GetAsyncOperation().GetId(), options.PollInterval); err != nil {
+		return resp.GetEndpointId(), fmt.Errorf("failed to create Nexus endpoint %q: %w", e.Name, err)
+	}
+	return resp.GetEndpointId(), nil
+}
+
+func listNamespaces(ctx context.Context, client cloudservicev1.CloudServiceClient) ([]*namespacev1.Namespace, error) {
+	namespaces, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*namespacev1.Namespace, string, error) {
+		resp, err := client.GetNamespaces(ctx, &cloudservicev1.GetNamespacesRequest{PageToken: pageToken})
+		return resp.GetNamespaces(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the namespaces: %w", err)
+	}
+	return namespaces, nil
+}
+
+func listEndpoints(ctx context.Context, client cloudservicev1.CloudServiceClient) ([]*nexusv1.Endpoint, error) {
+	endpoints, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*nexusv1.Endpoint, string, error) {
+		resp, err := client.GetNexusEndpoints(ctx, &cloudservicev1.GetNexusEndpointsRequest{PageToken: pageToken})
+		return resp.GetEndpoints(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the Nexus endpoints: %w", err)
+	}
+	return endpoints, nil
+}
@@ -0,0 +1,196 @@
+package nexusendpoints
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	nexusv1 "go.temporal.io/cloud-sdk/api/nexus/v1"
+	operationv1 "go.temporal.io/cloud-sdk/api/operation/v1"
+	resourcev1 "go.temporal.io/cloud-sdk/api/resource/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/cloudservicemock"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+)
+
+type fakeCloudService struct {
+	namespaces []*namespacev1.Namespace
+	endpoints  []*nexusv1.Endpoint
+	created    []*nexusv1.EndpointSpec
+}
+
+func (f *fakeCloudService) GetNamespaces(ctx context.Context, req *cloudservicev1.GetNamespacesRequest, opts ...grpc.CallOption) (*cloudservicev1.GetNamespacesResponse, error) {
+	return &cloudservicev1.GetNamespacesResponse{Namespaces: f.namespaces}, nil
+}
+
+func (f *fakeCloudService) GetNexusEndpoints(ctx context.Context, req *cloudservicev1.GetNexusEndpointsRequest, opts ...grpc.CallOption) (*cloudservicev1.GetNexusEndpointsResponse, error) {
+	return &cloudservicev1.GetNexusEndpointsResponse{Endpoints: f.endpoints}, nil
+}
+
+func (f *fakeCloudService) CreateNexusEndpoint(ctx context.Context, req *cloudservicev1.CreateNexusEndpointRequest, opts ...grpc.CallOption) (*cloudservicev1.CreateNexusEndpointResponse, error) {
+	f.created = append(f.created, req.GetSpec())
+	return &cloudservicev1.CreateNexusEndpointResponse{EndpointId: "new-id"}, nil
+}
+
+func endpoint(id, name, target, taskQueue string, callers ...string) *nexusv1.Endpoint {
+	spec, err := Endpoint{Name: name, TargetNamespace: target, TaskQueue: taskQueue, AllowedNamespaces: callers}.Spec()
+	if err != nil {
+		panic(err)
+	}
+	return &nexusv1.Endpoint{Id: id, Spec: spec}
+}
+
+func newFakeCloudService() *fakeCloudService {
+	return &fakeCloudService{
+		namespaces: []*namespacev1.Namespace{
+			{Namespace: "orders.a1"},
+			{Namespace: "payments.a1"},
+			{Namespace: "shipping.a1"},
+			{Namespace: "legacy.a1", State: resourcev1.ResourceState_RESOURCE_STATE_DELETING},
+		},
+		endpoints: []*nexusv1.Endpoint{
+			endpoint("e1", "payments", "payments.a1", "payments-tq", "orders.a1", "shipping.a1"),
+			endpoint("e2", "Legacy-Billing", "legacy.a1", "billing", "orders.a1", "gone.a1"),
+		},
+	}
+}
+
+func TestEndpointSpec(t *testing.T) {
+	spec, err := Endpoint{
+		Name:              "payments-v2",
+		Description:       "Takes payments.",
+		TargetNamespace:   "payments.a1",
+		TaskQueue:         "tq",
+		AllowedNamespaces: []string{"orders.a1"},
+	}.Spec()
+	if err != nil {
+		t.Fatalf("Spec() error = %v", err)
+	}
+	if target := spec.GetTargetSpec().GetWorkerTargetSpec(); target.GetNamespaceId() != "payments.a1" || target.GetTaskQueue() != "tq" ||
+		spec.GetPolicySpecs()[0].GetAllowedCloudNamespacePolicySpec().GetNamespaceId() != "orders.a1" ||
+		string(spec.GetDescription().GetData()) != `"Takes payments."` {
+		t.Errorf("Spec() = %v", spec)
+	}
+
+	for _, name := range []string{"", "1payments", "payments-", "pay_ments", "a", strings.Repeat("a", 201)} {
+		if ValidateName(name) == nil {
+			t.Errorf("ValidateName(%q) error = nil, want an invalid name", name)
+		}
+	}
+	_, err = Endpoint{Name: "ok", AllowedNamespaces: []string{"a", "a"}}.Spec()
+	for _, want := range []string{"missing target namespace", "missing task queue", `namespace "a" allowed more than once`} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Spec() error = %v, want %q", err, want)
+		}
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeCloudService()
+	b, err := NewBuilder(ctx, cloudservicemock.NewFakeCloudServiceClient(t, fake))
+	if err != nil {
+		t.Fatalf("NewBuilder() error = %v", err)
+	}
+
+	_, err = b.Build(Endpoint{Name: "PAYMENTS", TargetNamespace: "legacy.a1", TaskQueue: "tq", AllowedNamespaces: []string{"orders.a1", "nope.a1"}})
+	for _, want := range []string{"name already used by endpoint e1", `target namespace "legacy.a1" does not exist`, `allowed namespace "nope.a1" does not exist`} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Build() error = %v, want %q", err, want)
+		}
+	}
+
+	shipping := Endpoint{Name: "shipping", TargetNamespace: "shipping.a1", TaskQueue: "tq", AllowedNamespaces: []string{"orders.a1"}}
+	id, err := b.Create(ctx, shipping, Options{})
+	if err != nil || id != "new-id" || len(fake.created) != 1 || fake.created[0].GetName() != "shipping" {
+		t.Fatalf("Create() = %q, %v, created %v", id, err, fake.created)
+	}
+	if _, err := b.Build(shipping); err == nil || !strings.Contains(err.Error(), "name already used by endpoint new-id") {
+		t.Errorf("Build() error = %v, want the name used by the created endpoint", err)
+	}
+}
+
+func TestCreateRejected(t *testing.T) {
+	ctx := context.Background()
+	client := cloudservicemock.NewMockCloudServiceClient(gomock.NewController(t))
+	client.EXPECT().GetNamespaces(gomock.Any(), gomock.Any()).
+		Return(&cloudservicev1.GetNamespacesResponse{Namespaces: []*namespacev1.Namespace{{Namespace: "orders.a1"}, {Namespace: "shipping.a1"}}}, nil)
+	client.EXPECT().GetNexusEndpoints(gomock.Any(), gomock.Any()).Return(&cloudservicev1.GetNexusEndpointsResponse{}, nil)
+	b, err := NewBuilder(ctx, client)
+	if err != nil {
+		t.Fatalf("NewBuilder() error = %v", err)
+	}
+
+	shipping := Endpoint{Name: "shipping", TargetNamespace: "shipping.a1", TaskQueue: "tq", AllowedNamespaces: []string{"orders.a1"}}
+	spec, err := shipping.Spec()
+	if err != nil {
+		t.Fatalf("Spec() error = %v", err)
+	}
+	client.EXPECT().
+		CreateNexusEndpoint(gomock.Any(), cloudservicemock.EqualProto(&cloudservicev1.CreateNexusEndpointRequest{Spec: spec})).
+		Return(&cloudservicev1.CreateNexusEndpointResponse{EndpointId: "e1", AsyncOperation: &operationv1.AsyncOperation{Id: "op"}}, nil)
+	client.EXPECT().
+		GetAsyncOperation(gomock.Any(), cloudservicemock.EqualProto(&cloudservicev1.GetAsyncOperationRequest{AsyncOperationId: "op"})).
+		Return(&cloudservicev1.GetAsyncOperationResponse{AsyncOperation: &operationv1.AsyncOperation{
+			Id:            "op",
+			State:         operationv1.AsyncOperation_STATE_REJECTED,
+			FailureReason: "endpoint limit reached",
+		}}, nil)
+	id, err := b.Create(ctx, shipping, Options{PollInterval: time.Millisecond})
+	if id != "e1" || err == nil || !strings.Contains(err.Error(), `state=STATE_REJECTED reason="endpoint limit reached"`) {
+		t.Errorf("Create() = %q, %v, want the endpoint id and the rejection", id, err)
+	}
+}
+
+func TestGraph(t *testing.T) {
+	g, err := LoadGraph(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, newFakeCloudService()))
+	if err != nil {
+		t.Fatalf("LoadGraph() error = %v", err)
+	}
+	if len(g.Dangling) != 1 || g.Dangling[0].Name != "Legacy-Billing" || g.Dangling[0].MissingTarget != "legacy.a1" ||
+		strings.Join(g.Dangling[0].MissingCallers, ",") != "gone.a1" {
+		t.Errorf("Dangling = %+v", g.Dangling)
+	}
+
+	var dot bytes.Buffer
+	if err := g.WriteDOT(&dot); err != nil {
+		t.Fatalf("WriteDOT() error = %v", err)
+	}
+	want := `digraph nexus {
+  rankdir=LR;
+  "endpoint:e1" [label="payments", shape=ellipse];
+  "endpoint:e2" [label="Legacy-Billing", shape=ellipse, color=red, style=dashed];
+  "namespace:gone.a1" [label="gone.a1", shape=box, color=red, style=dashed];
+  "namespace:orders.a1" [label="orders.a1", shape=box];
+  "namespace:shipping.a1" [label="shipping.a1", shape=box];
+  "task_queue:legacy.a1/billing" [label="legacy.a1\nbilling", shape=cds, color=red, style=dashed];
+  "task_queue:payments.a1/payments-tq" [label="payments.a1\npayments-tq", shape=cds];
+  "endpoint:e1" -> "task_queue:payments.a1/payments-tq" [label="targets"];
+  "endpoint:e2" -> "task_queue:legacy.a1/billing" [label="targets"];
+  "namespace:gone.a1" -> "endpoint:e2" [label="calls"];
+  "namespace:orders.a1" -> "endpoint:e1" [label="calls"];
+  "namespace:orders.a1" -> "endpoint:e2" [label="calls"];
+  "namespace:shipping.a1" -> "endpoint:e1" [label="calls"];
+}
+`
+	if dot.String() != want {
+		t.Errorf("WriteDOT() =\n%s\nwant\n%s", dot.String(), want)
+	}
+
+	var buf bytes.Buffer
+	if err := g.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var decoded Graph
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("failed to decode the JSON graph: %v", err)
+	}
+	if len(decoded.Nodes) != 7 || len(decoded.Edges) != 6 || len(decoded.Dangling) != 1 || !decoded.Nodes[2].Missing {
+		t.Errorf("WriteJSON() =\n%s", buf.String())
+	}
+}
@@ -0,0 +1,181 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/mail"
+	"net/smtp"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+)
+
+var (
+	errLineBreak = errors.New("line break in address")
+
+	// DefaultSubjectTemplate renders the subject of the emails of an EmailNotifier, used when none is provided.
+	DefaultSubjectTemplate = template.Must(template.New("subject").Parse(`[{{.Severity}}] {{.Title}}`))
+
+	// DefaultBodyTemplate renders the body of the emails of an EmailNotifier, used when none is provided.
+	DefaultBodyTemplate = template.Must(template.New("body").Parse(`{{.Title}}
+{{with .Message}}
+{{.}}
+{{end}}
+Source: {{.Source}}
+Severity: {{.Severity}}
+Time: {{.Time.UTC.Format "2006-01-02T15:04:05Z07:00"}}
+{{range $key, $value := .Fields}}{{$key}}: {{$value}}
+{{end}}`))
+)
+
+// EmailNotifier sends every notification as a plain text email rendered with templates.
+// The templates are executed with the Notification.
+type EmailNotifier struct {
+	// The address of the SMTP server, such as "smtp.example.com:587".
+	Addr string
+
+	// The authentication to the SMTP server.
+	// If not provided, the emails are sent without authentication.
+	Auth smtp.Auth
+
+	// The sender of the emails, an address such as "alerts@example.com" or "Alerts <alerts@example.com>".
+	From string
+
+	// The recipients of every email, addresses in the format of From.
+	To []string
+
+	// The name of a field of the notifications holding additional recipients, comma separated addresses in the
+	// format of From, such as the owner of the resource a notification is about.
+	// If not provided, the emails are only sent to To.
+	ToField string
+
+	// The template of the subject of the emails.
+	// If not provided, DefaultSubjectTemplate is used.
+	Subject *template.Template
+
+	// The template of the body of the emails.
+	// If not provided, DefaultBodyTemplate is used.
+	Body *template.Template
+
+	// The function to send the emails with, which has the signature of smtp.SendMail.
+	// If not provided, smtp.SendMail is used.
+	SendMail func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// Render returns the subject and the body of the email of a notification.
+func (e *EmailNotifier) Render(n Notification) (subject string, body string, err error) {
+	var buf bytes.Buffer
+	tmpl := e.Subject
+	if tmpl == nil {
+		tmpl = DefaultSubjectTemplate
+	}
+	if err := tmpl.Execute(&buf, n); err != nil {
+		return "", "", fmt.Errorf("failed to render the subject: %w", err)
+	}
+	// a subject is a single header line
+	subject = strings.Join(strings.Fields(buf.String()), " ")
+
+	buf.Reset()
+	tmpl = e.Body
+	if tmpl == nil {
+		tmpl = DefaultBodyTemplate
+	}
+	if err := tmpl.Execute(&buf, n); err != nil {
+		return "", "", fmt.Errorf("failed to render the body: %w", err)
+	}
+	return subject, buf.String(), nil
+}
+
+// Notify renders the email of the notification and sends it to its recipients.
+// An error is returned if the notification has no recipient, or if the sender or a recipient is not a valid address.
+func (e *EmailNotifier) Notify(ctx context.Context, n Notification) error {
+	from, err := parseAddress(e.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender %q: %w", e.From, err)
+	}
+	var to []*mail.Address
+	add := func(addrs ...*mail.Address) {
+		for _, addr := range addrs {
+			if !slices.ContainsFunc(to, func(a *mail.Address) bool { return strings.EqualFold(a.Address, addr.Address) }) {
+				to = append(to, addr)
+			}
+		}
+	}
+	for _, recipient := range e.To {
+		addr, err := parseAddress(recipient)
+		if err != nil {
+			return fmt.Errorf("invalid recipient %q: %w", recipient, err)
+		}
+		add(addr)
+	}
+	if field := strings.TrimSpace(n.Fields[e.ToField]); e.ToField != "" && field != "" {
+		addrs, err := parseAddressList(field)
+		if err != nil {
+			return fmt.Errorf("invalid recipients in field %s: %w", e.ToField, err)
+		}
+		add(addrs...)
+	}
+	if len(to) == 0 {
+		return fmt.Errorf("no recipient for notification %q", n.Title)
+	}
+	subject, body, err := e.Render(n)
+	if err != nil {
+		return err
+	}
+
+	recipients := make([]string, len(to))
+	headers := make([]string, len(to))
+	for i, addr := range to {
+		recipients[i], headers[i] = addr.Address, formatAddress(addr)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", formatAddress(from))
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(headers, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	sendMail := e.SendMail
+	if sendMail == nil {
+		sendMail = smtp.SendMail
+	}
+	if err := sendMail(e.Addr, e.Auth, from.Address, recipients, msg.Bytes()); err != nil {
+		return fmt.Errorf("failed to send the email: %w", err)
+	}
+	return nil
+}
+
+// parseAddress parses an address, rejecting the line breaks that would end the header it goes in.
+func parseAddress(addr string) (*mail.Address, error) {
+	if strings.ContainsAny(addr, "\r\n") {
+		return nil, errLineBreak
+	}
+	return mail.ParseAddress(addr)
+}
+
+// parseAddressList parses comma separated addresses, rejecting the line breaks that would end the header they go in.
+func parseAddressList(list string) ([]*mail.Address, error) {
+	if strings.ContainsAny(list, "\r\n") {
+		return nil, errLineBreak
+	}
+	return mail.ParseAddressList(list)
+}
+
+// formatAddress formats an address for a header, the bare address if it has no name.
+func formatAddress(addr *mail.Address) string {
+	if addr.Name == "" {
+		return addr.Address
+	}
+	return addr.String()
+}
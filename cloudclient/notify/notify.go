@@ -0,0 +1,111 @@
+// Package notify delivers the notifications raised by the monitoring helpers of this module, such as the audit log
+// anomaly detector, to the people who need to act on them.
+//
+// WARNING: The package is currently experimental.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// The severities of notifications, from the least to the most severe.
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+type (
+	// Severity is how urgently a notification should be acted on.
+	Severity string
+
+	// Notification is a message to deliver.
+	Notification struct {
+		// What raised the notification, such as the name of a rule.
+		Source string `json:"source"`
+		// A one line summary.
+		Title string `json:"title"`
+		// The details, possibly over several lines.
+		Message  string   `json:"message,omitempty"`
+		Severity Severity `json:"severity"`
+		// When the notified event happened.
+		Time time.Time `json:"time"`
+		// Structured details, such as the ids of the resources involved.
+		Fields map[string]string `json:"fields,omitempty"`
+	}
+
+	// Notifier delivers notifications.
+	Notifier interface {
+		Notify(ctx context.Context, n Notification) error
+	}
+
+	// NotifierFunc adapts a function to a Notifier.
+	NotifierFunc func(ctx context.Context, n Notification) error
+
+	// Multi delivers every notification to each of its notifiers.
+	Multi []Notifier
+
+	// WebhookNotifier posts every notification as a JSON object to a URL.
+	WebhookNotifier struct {
+		// The URL to post the notifications to.
+		URL string
+
+		// Headers to add to the requests, such as an authorization header.
+		Header http.Header
+
+		// The HTTP client to post the notifications with.
+		// If not provided, http.DefaultClient is used.
+		HTTPClient *http.Client
+	}
+)
+
+func (f NotifierFunc) Notify(ctx context.Context, n Notification) error {
+	return f(ctx, n)
+}
+
+// Notify delivers the notification to every notifier, even if some of them fail, and returns their errors.
+func (m Multi) Notify(ctx context.Context, n Notification) error {
+	var errs []error
+	for _, notifier := range m {
+		errs = append(errs, notifier.Notify(ctx, n))
+	}
+	return errors.Join(errs...)
+}
+
+// Notify posts the notification, an error is returned if the response status is not 2xx.
+func (w *WebhookNotifier) Notify(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create the webhook request: %w", err)
+	}
+	for key, values := range w.Header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := w.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send the webhook request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected webhook response status: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	var got Notification
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode the notification: %v", err)
+		}
+		if got.Severity == SeverityCritical {
+			http.Error(w, "rejected", http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	notifier := &WebhookNotifier{URL: server.URL, Header: http.Header{"Authorization": {"Bearer token"}}}
+	want := Notification{
+		Source:   "test",
+		Title:    "something happened",
+		Severity: SeverityWarning,
+		Time:     time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		Fields:   map[string]string{"namespace": "prod.a1b2c"},
+	}
+	if err := notifier.Notify(context.Background(), want); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if got.Title != want.Title || !got.Time.Equal(want.Time) || got.Fields["namespace"] != "prod.a1b2c" || auth != "Bearer token" {
+		t.Errorf("webhook received %+v with authorization %q", got, auth)
+	}
+
+	want.Severity = SeverityCritical
+	if err := notifier.Notify(context.Background(), want); err == nil || !strings.Contains(err.Error(), "rejected") {
+		t.Errorf("Notify() error = %v, want the response status", err)
+	}
+}
+
+func TestMulti(t *testing.T) {
+	var calls int
+	ok := NotifierFunc(func(ctx context.Context, n Notification) error {
+		calls++
+		return nil
+	})
+	failing := NotifierFunc(func(ctx context.Context, n Notification) error {
+		calls++
+		return context.DeadlineExceeded
+	})
+	if err := (Multi{failing, ok}).Notify(context.Background(), Notification{}); err == nil || calls != 2 {
+		t.Errorf("Multi.Notify() error = %v after %d calls, want an error after 2 calls", err, calls)
+	}
+}
+
+func TestEmailNotifier(t *testing.T) {
+	var addr, from string
+	var to []string
+	var msg []byte
+	notifier := &EmailNotifier{
+		Addr:    "smtp.example.com:587",
+		From:    "alerts@example.com",
+		To:      []string{"ops@example.com"},
+		ToField: "owner_email",
+		SendMail: func(a string, auth smtp.Auth, f string, t []string, m []byte) error {
+			addr, from, to, msg = a, f, t, m
+			return nil
+		},
+	}
+	n := Notification{
+		Source:   "test",
+		Title:    "key\nexpires",
+		Message:  "Renew it.",
+		Severity: SeverityWarning,
+		Time:     time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		Fields:   map[string]string{"owner_email": "alice@example.com, ops@example.com"},
+	}
+	if err := notifier.Notify(context.Background(), n); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if addr != notifier.Addr || from != notifier.From || strings.Join(to, ",") != "ops@example.com,alice@example.com" {
+		t.Errorf("Notify() sent from %q to %v through %q", from, to, addr)
+	}
+	for _, want := range []string{
+		"To: ops@example.com, alice@example.com\r\n",
+		"Subject: [warning] key expires\r\n",
+		"\r\n\r\nkey\r\nexpires\r\n\r\nRenew it.\r\n",
+		"Time: 2026-10-01T00:00:00Z\r\nowner_email: alice@example.com, ops@example.com\r\n",
+	} {
+		if !strings.Contains(string(msg), want) {
+			t.Errorf("Notify() message =\n%s\nwant it to contain %q", msg, want)
+		}
+	}
+
+	n.Fields["owner_email"] = `"Alice A." <alice@example.com>`
+	if err := notifier.Notify(context.Background(), n); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if strings.Join(to, ",") != "ops@example.com,alice@example.com" || !strings.Contains(string(msg), "To: ops@example.com, \"Alice A.\" <alice@example.com>\r\n") {
+		t.Errorf("Notify() sent to %v, message =\n%s", to, msg)
+	}
+
+	// the addresses go in the headers, a line break would inject headers
+	for _, tc := range []struct {
+		from, owner string
+	}{
+		{"alerts@example.com\r\nBcc: eve@example.com", "alice@example.com"},
+		{"alerts@example.com", "alice@example.com\r\nBcc: eve@example.com"},
+		{"alerts@example.com", "alice@example.com\nBcc: eve@example.com"},
+		{"alerts@example.com", "not an address"},
+	} {
+		msg = nil
+		notifier.From, n.Fields["owner_email"] = tc.from, tc.owner
+		if err := notifier.Notify(context.Background(), n); err == nil || msg != nil {
+			t.Errorf("Notify(from=%q, owner=%q) error = %v, want an invalid address and no email", tc.from, tc.owner, err)
+		}
+	}
+	notifier.From = "alerts@example.com"
+
+	notifier.To, notifier.ToField = nil, ""
+	if err := notifier.Notify(context.Background(), n); err == nil {
+		t.Errorf("Notify() error = nil, want no recipient")
+	}
+
+	notifier.Subject = template.Must(template.New("subject").Parse(`{{.Fields.missing.nope}}`))
+	notifier.Subject.Option("missingkey=error")
+	if _, _, err := notifier.Render(Notification{Fields: map[string]string{}}); err == nil {
+		t.Errorf("Render() error = nil, want a template error")
+	}
+}
@@ -0,0 +1,168 @@
+package onboarding
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+)
+
+// reportHeader is the header of the CSV export of a report, one column per field of Result.
+var reportHeader = []string{"email", "user_id", "status", "actions", "error"}
+
+// ReadUsers reads the users to onboard from CSV with a header line and the columns:
+//
+//   - email, required.
+//   - account_role, such as "developer" or "ROLE_DEVELOPER". If empty, "read" is used.
+//   - namespaces, the namespace accesses separated by semicolons, such as "prod.a1b2c=read;dev.a1b2c=write".
+//   - groups, the ids of the user groups separated by semicolons.
+//
+// Only the email column is required, and the columns can be in any order. Other columns are ignored.
+func ReadUsers(r io.Reader) ([]User, error) {
+	rows, columns, err := readCSV(r, "email")
+	if err != nil {
+		return nil, err
+	}
+	var users []User
+	for i, row := range rows {
+		line := i + 2
+		u := User{Email: strings.TrimSpace(row[columns["email"]])}
+		if u.Email == "" {
+			return nil, fmt.Errorf("line %d: missing email", line)
+		}
+		u.AccountRole = identityv1.AccountAccess_ROLE_READ
+		if c, ok := columns["account_role"]; ok && strings.TrimSpace(row[c]) != "" {
+			role, err := parseEnum(row[c], "ROLE_", identityv1.AccountAccess_Role_value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid account role: %w", line, err)
+			}
+			u.AccountRole = identityv1.AccountAccess_Role(role)
+		}
+		if c, ok := columns["namespaces"]; ok {
+			for _, access := range splitList(row[c]) {
+				ns, permission, found := strings.Cut(access, "=")
+				if !found {
+					return nil, fmt.Errorf("line %d: invalid namespace access %q, expected namespace=permission", line, access)
+				}
+				p, err := parseEnum(permission, "PERMISSION_", identityv1.NamespaceAccess_Permission_value)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: invalid permission on namespace %q: %w", line, ns, err)
+				}
+				if u.NamespaceAccesses == nil {
+					u.NamespaceAccesses = map[string]identityv1.NamespaceAccess_Permission{}
+				}
+				u.NamespaceAccesses[strings.TrimSpace(ns)] = identityv1.NamespaceAccess_Permission(p)
+			}
+		}
+		if c, ok := columns["groups"]; ok {
+			u.Groups = splitList(row[c])
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+// ReadEmails reads the emails of the users to offboard from the email column of CSV with a header line.
+// Other columns are ignored.
+func ReadEmails(r io.Reader) ([]string, error) {
+	rows, columns, err := readCSV(r, "email")
+	if err != nil {
+		return nil, err
+	}
+	var emails []string
+	for i, row := range rows {
+		email := strings.TrimSpace(row[columns["email"]])
+		if email == "" {
+			return nil, fmt.Errorf("line %d: missing email", i+2)
+		}
+		emails = append(emails, email)
+	}
+	return emails, nil
+}
+
+// WriteCSV writes a line per user with its status, "ok" or "failed", and its actions separated by semicolons,
+// with a header line.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(reportHeader); err != nil {
+		return fmt.Errorf("failed to write the CSV header: %w", err)
+	}
+	for _, result := range r.Results {
+		status, errMsg := "ok", ""
+		if result.Err != nil {
+			status, errMsg = "failed", result.Err.Error()
+		}
+		err := cw.Write([]string{
+			result.Email,
+			result.UserID,
+			status,
+			strings.Join(result.Actions, ";"),
+			errMsg,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write the CSV row: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write the CSV rows: %w", err)
+	}
+	return nil
+}
+
+// readCSV reads CSV with a header line, and returns the rows after the header and the index of each column by name.
+func readCSV(r io.Reader, required ...string) ([][]string, map[string]int, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read the CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil, errors.New("failed to read the CSV: missing header")
+	}
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, nil, fmt.Errorf("failed to read the CSV: missing %s column", name)
+		}
+	}
+	return records[1:], columns, nil
+}
+
+// parseEnum parses the name of an enum value, case insensitive and with or without its prefix.
+func parseEnum(s, prefix string, values map[string]int32) (int32, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	if !strings.HasPrefix(name, prefix) {
+		name = prefix + name
+	}
+	v, ok := values[name]
+	if !ok || v == 0 {
+		var names []string
+		for n, v := range values {
+			if v != 0 {
+				names = append(names, strings.ToLower(strings.TrimPrefix(n, prefix)))
+			}
+		}
+		slices.Sort(names)
+		return 0, fmt.Errorf("unknown value %q, expected one of %s", s, strings.Join(names, ", "))
+	}
+	return v, nil
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ";") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,420 @@
+// Package onboarding adds and removes batches of users of a Temporal Cloud account: onboarding creates the users,
+// grants them access to namespaces and adds them to user groups, offboarding disables or deletes their API keys,
+// removes them from their groups and deletes them.
+//
+// WARNING: The package is currently experimental.
+//
+// Every step checks the current state of the account before changing it, so that running a batch again after a
+// failure, or an interruption, resumes it: the steps already done are skipped. Users are processed concurrently,
+// and the outcome of each of them is reported separately, a failure for one user does not stop the others.
+package onboarding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	"go.temporal.io/cloud-sdk/internal/asyncop"
+	"go.temporal.io/cloud-sdk/internal/paging"
+	"go.temporal.io/cloud-sdk/internal/resources"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultConcurrency is the number of users processed at the same time when Options.Concurrency is not set.
+const DefaultConcurrency = 4
+
+type (
+	// User is a user to onboard.
+	User struct {
+		Email string
+		// The account role of the user, used when the user is created.
+		// If not provided, ROLE_READ is used. The role of an existing user is left unchanged.
+		AccountRole identityv1.AccountAccess_Role
+		// The permissions of the user on namespaces, by namespace.
+		NamespaceAccesses map[string]identityv1.NamespaceAccess_Permission
+		// The ids of the user groups to add the user to.
+		// Only groups managed by Temporal Cloud accept members.
+		Groups []string
+	}
+
+	// Options configure a batch.
+	Options struct {
+		// The number of users processed at the same time.
+		// If not provided, DefaultConcurrency is used.
+		Concurrency int
+
+		// Whether offboarding deletes the API keys of the users.
+		// If false, the API keys are disabled, and deleted along with the users that own them.
+		DeleteAPIKeys bool
+
+		// The interval between two checks of an async operation.
+		// If not provided, the interval suggested by the server is used.
+		PollInterval time.Duration
+	}
+
+	// Result is the outcome of a batch for a user.
+	Result struct {
+		Email string
+		// The id of the user, empty if the user was not found or not created.
+		UserID string
+		// The changes made to the account, in order, such as "created user" or "added to group g1".
+		// Empty if the user was already in the expected state.
+		Actions []string
+		// The error that stopped the processing of the user, nil on success.
+		Err error
+	}
+
+	// Report lists the outcome of a batch for every user, in the order of the input.
+	Report struct {
+		Results []Result
+	}
+
+	batch struct {
+		client  cloudservicev1.CloudServiceClient
+		options Options
+	}
+)
+
+// Onboard creates the users that do not exist yet, grants them their namespace accesses and adds them to their groups.
+// Existing users keep their account role, and only the namespace accesses that differ are updated.
+//
+// The returned error is only set if the batch could not start, the failures of each user are in the report.
+func Onboard(ctx context.Context, client cloudservicev1.CloudServiceClient, users []User, options Options) (*Report, error) {
+	for i, u := range users {
+		if u.Email == "" {
+			return nil, fmt.Errorf("user %d: missing email", i)
+		}
+	}
+	b := &batch{client: client, options: options}
+	return b.run(ctx, len(users), func(ctx context.Context, i int, r *Result) error {
+		r.Email = users[i].Email
+		return b.onboard(ctx, users[i], r)
+	}), nil
+}
+
+// Offboard disables or deletes the API keys of the users with the given emails, removes them from every group
+// managed by Temporal Cloud, and deletes them. Users that do not exist, or are already being deleted, are reported as
+// successful, without actions.
+//
+// The returned error is only set if the batch could not start, the failures of each user are in the report.
+func Offboard(ctx context.Context, client cloudservicev1.CloudServiceClient, emails []string, options Options) (*Report, error) {
+	b := &batch{client: client, options: options}
+	groups, err := b.groupsByMember(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return b.run(ctx, len(emails), func(ctx context.Context, i int, r *Result) error {
+		r.Email = emails[i]
+		return b.offboard(ctx, groups, r)
+	}), nil
+}
+
+// Failed returns the results of the users that failed.
+func (r *Report) Failed() []Result {
+	var failed []Result
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err returns the errors of the users that failed joined together, nil if none did.
+func (r *Report) Err() error {
+	var errs []error
+	for _, result := range r.Failed() {
+		errs = append(errs, fmt.Errorf("%s: %w", result.Email, result.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// run processes n users with at most Options.Concurrency of them at the same time.
+func (b *batch) run(ctx context.Context, n int, process func(ctx context.Context, i int, r *Result) error) *Report {
+	concurrency := b.options.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	report := &Report{Results: make([]Result, n)}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			r := &report.Results[i]
+			if err := ctx.Err(); err != nil {
+				r.Err = err
+			} else {
+				r.Err = process(ctx, i, r)
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+func (b *batch) onboard(ctx context.Context, u User, r *Result) error {
+	user, err := b.userByEmail(ctx, u.Email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		role := u.AccountRole
+		if role == identityv1.AccountAccess_ROLE_UNSPECIFIED {
+			role = identityv1.AccountAccess_ROLE_READ
+		}
+		spec := &identityv1.UserSpec{
+			Email: u.Email,
+			Access: &identityv1.Access{
+				AccountAccess:     &identityv1.AccountAccess{Role: role},
+				NamespaceAccesses: map[string]*identityv1.NamespaceAccess{},
+			},
+		}
+		for ns, permission := range u.NamespaceAccesses {
+			spec.Access.NamespaceAccesses[ns] = &identityv1.NamespaceAccess{Permission: permission}
+		}
+		resp, err := b.client.CreateUser(ctx, &cloudservicev1.CreateUserRequest{Spec: spec})
+		if err != nil {
+			return fmt.Errorf("failed to create the user: %w", err)
+		}
+		r.UserID = resp.GetUserId()
+		if err := b.wait(ctx, resp.GetAsyncOperation().GetId()); err != nil {
+			return fmt.Errorf("failed to create the user: %w", err)
+		}
+		r.Actions = append(r.Actions, "created user")
+	} else {
+		r.UserID = user.GetId()
+		current := user.GetSpec().GetAccess().GetNamespaceAccesses()
+		for _, ns := range slices.Sorted(maps.Keys(u.NamespaceAccesses)) {
+			permission := u.NamespaceAccesses[ns]
+			if current[ns].GetPermission() == permission {
+				continue
+			}
+			if err := b.setNamespaceAccess(ctx, r.UserID, ns, permission); err != nil {
+				return err
+			}
+			r.Actions = append(r.Actions, fmt.Sprintf("set %s access to namespace %s", strings.TrimPrefix(permission.String(), "PERMISSION_"), ns))
+		}
+	}
+
+	for _, group := range u.Groups {
+		resp, err := b.client.AddUserGroupMember(ctx, &cloudservicev1.AddUserGroupMemberRequest{
+			GroupId:  group,
+			MemberId: memberID(r.UserID),
+		})
+		if status.Code(err) == codes.AlreadyExists {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to add the user to group %q: %w", group, err)
+		}
+		if err := b.wait(ctx, resp.GetAsyncOperation().GetId()); err != nil {
+			return fmt.Errorf("failed to add the user to group %q: %w", group, err)
+		}
+		r.Actions = append(r.Actions, "added to group "+group)
+	}
+	return nil
+}
+
+func (b *batch) offboard(ctx context.Context, groups map[string][]string, r *Result) error {
+	user, err := b.userByEmail(ctx, r.Email)
+	if err != nil || user == nil {
+		return err
+	}
+	r.UserID = user.GetId()
+	if resources.Deleted(user.GetState()) {
+		// a previous run deleted the user already.
+		return nil
+	}
+
+	keys, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.ApiKey, string, error) {
+		resp, err := b.client.GetApiKeys(ctx, &cloudservicev1.GetApiKeysRequest{
+			OwnerId:   r.UserID,
+			OwnerType: identityv1.OwnerType_OWNER_TYPE_USER,
+			PageToken: pageToken,
+		})
+		return resp.GetApiKeys(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the API keys: %w", err)
+	}
+	for _, key := range keys {
+		if resources.Deleted(key.GetState()) {
+			continue
+		}
+		if err := b.retireAPIKey(ctx, key, r); err != nil {
+			return err
+		}
+	}
+
+	for _, group := range groups[r.UserID] {
+		resp, err := b.client.RemoveUserGroupMember(ctx, &cloudservicev1.RemoveUserGroupMemberRequest{
+			GroupId:  group,
+			MemberId: memberID(r.UserID),
+		})
+		if status.Code(err) == codes.NotFound {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to remove the user from group %q: %w", group, err)
+		}
+		if err := b.wait(ctx, resp.GetAsyncOperation().GetId()); err != nil {
+			return fmt.Errorf("failed to remove the user from group %q: %w", group, err)
+		}
+		r.Actions = append(r.Actions, "removed from group "+group)
+	}
+
+	// The group changes may have bumped the version of the user.
+	latest, err := b.client.GetUser(ctx, &cloudservicev1.GetUserRequest{UserId: r.UserID})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get the user: %w", err)
+	}
+	resp, err := b.client.DeleteUser(ctx, &cloudservicev1.DeleteUserRequest{
+		UserId:          r.UserID,
+		ResourceVersion: latest.GetUser().GetResourceVersion(),
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete the user: %w", err)
+	}
+	if err := b.wait(ctx, resp.GetAsyncOperation().GetId()); err != nil {
+		return fmt.Errorf("failed to delete the user: %w", err)
+	}
+	r.Actions = append(r.Actions, "deleted user")
+	return nil
+}
+
+// retireAPIKey disables or deletes an API key, depending on Options.DeleteAPIKeys.
+func (b *batch) retireAPIKey(ctx context.Context, key *identityv1.ApiKey, r *Result) error {
+	if b.options.DeleteAPIKeys {
+		resp, err := b.client.DeleteApiKey(ctx, &cloudservicev1.DeleteApiKeyRequest{
+			KeyId:           key.GetId(),
+			ResourceVersion: key.GetResourceVersion(),
+		})
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to delete API key %q: %w", key.GetId(), err)
+		}
+		if err := b.wait(ctx, resp.GetAsyncOperation().GetId()); err != nil {
+			return fmt.Errorf("failed to delete API key %q: %w", key.GetId(), err)
+		}
+		r.Actions = append(r.Actions, "deleted API key "+key.GetId())
+		return nil
+	}
+	if key.GetSpec().GetDisabled() {
+		return nil
+	}
+	spec := proto.Clone(key.GetSpec()).(*identityv1.ApiKeySpec)
+	spec.Disabled = true
+	resp, err := b.client.UpdateApiKey(ctx, &cloudservicev1.UpdateApiKeyRequest{
+		KeyId:           key.GetId(),
+		Spec:            spec,
+		ResourceVersion: key.GetResourceVersion(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to disable API key %q: %w", key.GetId(), err)
+	}
+	if err := b.wait(ctx, resp.GetAsyncOperation().GetId()); err != nil {
+		return fmt.Errorf("failed to disable API key %q: %w", key.GetId(), err)
+	}
+	r.Actions = append(r.Actions, "disabled API key "+key.GetId())
+	return nil
+}
+
+// groupsByMember returns the ids of the groups managed by Temporal Cloud, by the id of their member users.
+// Members of the other groups are managed by the identity provider of the group.
+func (b *batch) groupsByMember(ctx context.Context) (map[string][]string, error) {
+	groups, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.UserGroup, string, error) {
+		resp, err := b.client.GetUserGroups(ctx, &cloudservicev1.GetUserGroupsRequest{PageToken: pageToken})
+		return resp.GetGroups(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the user groups: %w", err)
+	}
+	byMember := map[string][]string{}
+	for _, group := range groups {
+		if group.GetSpec().GetCloudGroup() == nil {
+			continue
+		}
+		members, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.UserGroupMember, string, error) {
+			resp, err := b.client.GetUserGroupMembers(ctx, &cloudservicev1.GetUserGroupMembersRequest{
+				GroupId:   group.GetId(),
+				PageToken: pageToken,
+			})
+			return resp.GetMembers(), resp.GetNextPageToken(), err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the members of group %q: %w", group.GetId(), err)
+		}
+		for _, m := range members {
+			if id := m.GetMemberId().GetUserId(); id != "" {
+				byMember[id] = append(byMember[id], group.GetId())
+			}
+		}
+	}
+	return byMember, nil
+}
+
+// userByEmail returns the user with the given email, compared case insensitively, nil if there is none.
+func (b *batch) userByEmail(ctx context.Context, email string) (*identityv1.User, error) {
+	resp, err := b.client.GetUsers(ctx, &cloudservicev1.GetUsersRequest{Email: email})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up the user: %w", err)
+	}
+	for _, u := range resp.GetUsers() {
+		if strings.EqualFold(u.GetSpec().GetEmail(), email) {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (b *batch) setNamespaceAccess(ctx context.Context, userID, namespace string, permission identityv1.NamespaceAccess_Permission) error {
+	user, err := b.client.GetUser(ctx, &cloudservicev1.GetUserRequest{UserId: userID})
+	if err != nil {
+		return fmt.Errorf("failed to get the user: %w", err)
+	}
+	resp, err := b.client.SetUserNamespaceAccess(ctx, &cloudservicev1.SetUserNamespaceAccessRequest{
+		Namespace:       namespace,
+		UserId:          userID,
+		Access:          &identityv1.NamespaceAccess{Permission: permission},
+		ResourceVersion: user.GetUser().GetResourceVersion(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set the access to namespace %q: %w", namespace, err)
+	}
+	if err := b.wait(ctx, resp.GetAsyncOperation().GetId()); err != nil {
+		return fmt.Errorf("failed to set the access to namespace %q: %w", namespace, err)
+	}
+	return nil
+}
+
+func (b *batch) wait(ctx context.Context, id string) error {
+	_, err := asyncop.Wait(ctx, b.client, id, b.options.PollInterval)
+	return err
+}
+
+func memberID(userID string) *identityv1.UserGroupMemberId {
+	return &identityv1.UserGroupMemberId{MemberType: &identityv1.UserGroupMemberId_UserId{UserId: userID}}
+}
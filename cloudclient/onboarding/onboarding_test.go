@@ -0,0 +1,332 @@
+package onboarding
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	resourcev1 "go.temporal.io/cloud-sdk/api/resource/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/cloudservicemock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeCloudService keeps users, group members and API keys in memory. Its operations complete synchronously.
+type fakeCloudService struct {
+	mu      sync.Mutex
+	users   map[string]*identityv1.User
+	members map[string]map[string]bool
+	keys    []*identityv1.ApiKey
+	failFor string
+	// the ids of the resources deleted since they were listed
+	gone map[string]bool
+}
+
+func newFakeCloudService() *fakeCloudService {
+	return &fakeCloudService{
+		users:   map[string]*identityv1.User{},
+		members: map[string]map[string]bool{"g1": {}, "g2": {}},
+	}
+}
+
+func (s *fakeCloudService) GetUsers(ctx context.Context, req *cloudservicev1.GetUsersRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUsersResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if req.GetEmail() == s.failFor {
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	}
+	for _, u := range s.users {
+		if strings.EqualFold(u.GetSpec().GetEmail(), req.GetEmail()) {
+			return &cloudservicev1.GetUsersResponse{Users: []*identityv1.User{u}}, nil
+		}
+	}
+	return &cloudservicev1.GetUsersResponse{}, nil
+}
+
+func (s *fakeCloudService) GetUser(ctx context.Context, req *cloudservicev1.GetUserRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUserResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.gone[req.GetUserId()] {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &cloudservicev1.GetUserResponse{User: s.users[req.GetUserId()]}, nil
+}
+
+func (s *fakeCloudService) CreateUser(ctx context.Context, req *cloudservicev1.CreateUserRequest, opts ...grpc.CallOption) (*cloudservicev1.CreateUserResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := fmt.Sprintf("u%d", len(s.users)+1)
+	s.users[id] = &identityv1.User{Id: id, Spec: req.GetSpec(), ResourceVersion: "1"}
+	return &cloudservicev1.CreateUserResponse{UserId: id}, nil
+}
+
+func (s *fakeCloudService) SetUserNamespaceAccess(ctx context.Context, req *cloudservicev1.SetUserNamespaceAccessRequest, opts ...grpc.CallOption) (*cloudservicev1.SetUserNamespaceAccessResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u := s.users[req.GetUserId()]
+	if u.GetResourceVersion() != req.GetResourceVersion() {
+		return nil, status.Error(codes.FailedPrecondition, "stale resource version")
+	}
+	u.Spec.Access.NamespaceAccesses[req.GetNamespace()] = req.GetAccess()
+	u.ResourceVersion += "+"
+	return &cloudservicev1.SetUserNamespaceAccessResponse{}, nil
+}
+
+func (s *fakeCloudService) DeleteUser(ctx context.Context, req *cloudservicev1.DeleteUserRequest, opts ...grpc.CallOption) (*cloudservicev1.DeleteUserResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.users[req.GetUserId()].GetResourceVersion() != req.GetResourceVersion() {
+		return nil, status.Error(codes.FailedPrecondition, "stale resource version")
+	}
+	delete(s.users, req.GetUserId())
+	return &cloudservicev1.DeleteUserResponse{}, nil
+}
+
+func (s *fakeCloudService) AddUserGroupMember(ctx context.Context, req *cloudservicev1.AddUserGroupMemberRequest, opts ...grpc.CallOption) (*cloudservicev1.AddUserGroupMemberResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.members[req.GetGroupId()][req.GetMemberId().GetUserId()] {
+		return nil, status.Error(codes.AlreadyExists, "already a member")
+	}
+	s.members[req.GetGroupId()][req.GetMemberId().GetUserId()] = true
+	return &cloudservicev1.AddUserGroupMemberResponse{}, nil
+}
+
+func (s *fakeCloudService) RemoveUserGroupMember(ctx context.Context, req *cloudservicev1.RemoveUserGroupMemberRequest, opts ...grpc.CallOption) (*cloudservicev1.RemoveUserGroupMemberResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.members[req.GetGroupId()], req.GetMemberId().GetUserId())
+	return &cloudservicev1.RemoveUserGroupMemberResponse{}, nil
+}
+
+func (s *fakeCloudService) GetUserGroups(ctx context.Context, req *cloudservicev1.GetUserGroupsRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUserGroupsResponse, error) {
+	cloud := &identityv1.UserGroupSpec{GroupType: &identityv1.UserGroupSpec_CloudGroup{CloudGroup: &identityv1.CloudGroupSpec{}}}
+	google := &identityv1.UserGroupSpec{GroupType: &identityv1.UserGroupSpec_GoogleGroup{GoogleGroup: &identityv1.GoogleGroupSpec{}}}
+	return &cloudservicev1.GetUserGroupsResponse{Groups: []*identityv1.UserGroup{
+		{Id: "g1", Spec: cloud},
+		{Id: "g2", Spec: cloud},
+		{Id: "google", Spec: google},
+	}}, nil
+}
+
+func (s *fakeCloudService) GetUserGroupMembers(ctx context.Context, req *cloudservicev1.GetUserGroupMembersRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUserGroupMembersResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var members []*identityv1.UserGroupMember
+	for id := range s.members[req.GetGroupId()] {
+		members = append(members, &identityv1.UserGroupMember{MemberId: memberID(id)})
+	}
+	return &cloudservicev1.GetUserGroupMembersResponse{Members: members}, nil
+}
+
+func (s *fakeCloudService) GetApiKeys(ctx context.Context, req *cloudservicev1.GetApiKeysRequest, opts ...grpc.CallOption) (*cloudservicev1.GetApiKeysResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []*identityv1.ApiKey
+	for _, k := range s.keys {
+		if k.GetSpec().GetOwnerId() == req.GetOwnerId() && k.GetSpec().GetOwnerType() == req.GetOwnerType() {
+			keys = append(keys, k)
+		}
+	}
+	return &cloudservicev1.GetApiKeysResponse{ApiKeys: keys}, nil
+}
+
+func (s *fakeCloudService) UpdateApiKey(ctx context.Context, req *cloudservicev1.UpdateApiKeyRequest, opts ...grpc.CallOption) (*cloudservicev1.UpdateApiKeyResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, k := range s.keys {
+		if k.GetId() == req.GetKeyId() {
+			k.Spec = req.GetSpec()
+		}
+	}
+	return &cloudservicev1.UpdateApiKeyResponse{}, nil
+}
+
+func (s *fakeCloudService) DeleteApiKey(ctx context.Context, req *cloudservicev1.DeleteApiKeyRequest, opts ...grpc.CallOption) (*cloudservicev1.DeleteApiKeyResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.gone[req.GetKeyId()] {
+		return nil, status.Error(codes.NotFound, "api key not found")
+	}
+	for i, k := range s.keys {
+		if k.GetId() != req.GetKeyId() {
+			continue
+		}
+		if k.GetState() == resourcev1.ResourceState_RESOURCE_STATE_DELETING {
+			return nil, status.Error(codes.FailedPrecondition, "api key is being deleted")
+		}
+		s.keys = append(s.keys[:i], s.keys[i+1:]...)
+		return &cloudservicev1.DeleteApiKeyResponse{}, nil
+	}
+	return nil, status.Error(codes.NotFound, "api key not found")
+}
+
+func TestOnboard(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeCloudService()
+	fake.users["u0"] = &identityv1.User{Id: "u0", ResourceVersion: "1", Spec: &identityv1.UserSpec{
+		Email: "bob@example.com",
+		Access: &identityv1.Access{
+			AccountAccess:     &identityv1.AccountAccess{Role: identityv1.AccountAccess_ROLE_ADMIN},
+			NamespaceAccesses: map[string]*identityv1.NamespaceAccess{"prod.a1b2c": {Permission: identityv1.NamespaceAccess_PERMISSION_READ}},
+		},
+	}}
+	fake.members["g1"]["u0"] = true
+	fake.failFor = "carol@example.com"
+
+	users, err := ReadUsers(strings.NewReader(`email,account_role,namespaces,groups
+alice@example.com,developer,prod.a1b2c=write;dev.a1b2c=admin,g1;g2
+Bob@Example.com,,prod.a1b2c=write;dev.a1b2c=read,g1
+carol@example.com,read,,
+`))
+	if err != nil {
+		t.Fatalf("ReadUsers() error = %v", err)
+	}
+	report, err := Onboard(ctx, cloudservicemock.NewFakeCloudServiceClient(t, fake), users, Options{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("Onboard() error = %v", err)
+	}
+
+	alice, bob, carol := report.Results[0], report.Results[1], report.Results[2]
+	if alice.Err != nil || strings.Join(alice.Actions, ";") != "created user;added to group g1;added to group g2" {
+		t.Errorf("Onboard() alice = %+v", alice)
+	}
+	created := fake.users[alice.UserID].GetSpec().GetAccess()
+	if created.GetAccountAccess().GetRole() != identityv1.AccountAccess_ROLE_DEVELOPER ||
+		created.GetNamespaceAccesses()["dev.a1b2c"].GetPermission() != identityv1.NamespaceAccess_PERMISSION_ADMIN {
+		t.Errorf("Onboard() alice access = %v", created)
+	}
+	if bob.Err != nil || strings.Join(bob.Actions, ";") != "set READ access to namespace dev.a1b2c;set WRITE access to namespace prod.a1b2c" {
+		t.Errorf("Onboard() bob = %+v", bob)
+	}
+	if fake.users["u0"].GetSpec().GetAccess().GetAccountAccess().GetRole() != identityv1.AccountAccess_ROLE_ADMIN {
+		t.Errorf("Onboard() changed the account role of bob")
+	}
+	if carol.Err == nil || len(report.Failed()) != 1 || !strings.Contains(report.Err().Error(), "carol@example.com: failed to look up the user") {
+		t.Errorf("Onboard() carol = %+v, err = %v", carol, report.Err())
+	}
+
+	// Running the batch again resumes it: only the failed user is processed.
+	fake.failFor = ""
+	report, err = Onboard(ctx, cloudservicemock.NewFakeCloudServiceClient(t, fake), users, Options{})
+	if err != nil {
+		t.Fatalf("Onboard() error = %v", err)
+	}
+	var out strings.Builder
+	if err := report.WriteCSV(&out); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	want := `email,user_id,status,actions,error
+alice@example.com,u2,ok,,
+Bob@Example.com,u0,ok,,
+carol@example.com,u3,ok,created user,
+`
+	if out.String() != want {
+		t.Errorf("WriteCSV() = %q, want %q", out.String(), want)
+	}
+}
+
+func TestOffboard(t *testing.T) {
+	fake := newFakeCloudService()
+	fake.users["u1"] = &identityv1.User{Id: "u1", ResourceVersion: "1", Spec: &identityv1.UserSpec{Email: "alice@example.com"}}
+	fake.members["g1"]["u1"] = true
+	fake.members["g2"]["u2"] = true
+	fake.keys = []*identityv1.ApiKey{
+		{Id: "k1", Spec: &identityv1.ApiKeySpec{OwnerId: "u1", OwnerType: identityv1.OwnerType_OWNER_TYPE_USER}},
+		{Id: "k2", Spec: &identityv1.ApiKeySpec{OwnerId: "u1", OwnerType: identityv1.OwnerType_OWNER_TYPE_USER, Disabled: true}},
+		{Id: "k3", Spec: &identityv1.ApiKeySpec{OwnerId: "sa1", OwnerType: identityv1.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT}},
+	}
+
+	listed := fake.keys[0].GetSpec()
+
+	emails, err := ReadEmails(strings.NewReader("name,email\nAlice,alice@example.com\nDan,dan@example.com\n"))
+	if err != nil {
+		t.Fatalf("ReadEmails() error = %v", err)
+	}
+	report, err := Offboard(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), emails, Options{})
+	if err != nil {
+		t.Fatalf("Offboard() error = %v", err)
+	}
+	if err := report.Err(); err != nil {
+		t.Fatalf("Offboard() report error = %v", err)
+	}
+	alice, dan := report.Results[0], report.Results[1]
+	if strings.Join(alice.Actions, ";") != "disabled API key k1;removed from group g1;deleted user" {
+		t.Errorf("Offboard() alice = %+v", alice)
+	}
+	if dan.UserID != "" || len(dan.Actions) != 0 {
+		t.Errorf("Offboard() dan = %+v, want no actions", dan)
+	}
+	if _, ok := fake.users["u1"]; ok || fake.members["g1"]["u1"] || !fake.members["g2"]["u2"] ||
+		!fake.keys[0].GetSpec().GetDisabled() || fake.keys[2].GetSpec().GetDisabled() {
+		t.Errorf("Offboard() left users = %v, members = %v, keys = %v", fake.users, fake.members, fake.keys)
+	}
+	if listed.GetDisabled() {
+		t.Errorf("Offboard() modified the spec of the listed API key")
+	}
+}
+
+func TestOnboardDefaultRole(t *testing.T) {
+	fake := newFakeCloudService()
+	report, err := Onboard(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), []User{{Email: "dan@example.com"}}, Options{})
+	if err != nil {
+		t.Fatalf("Onboard() error = %v", err)
+	}
+	if err := report.Err(); err != nil {
+		t.Fatalf("Onboard() report error = %v", err)
+	}
+	if got := fake.users[report.Results[0].UserID].GetSpec().GetAccess().GetAccountAccess().GetRole(); got != identityv1.AccountAccess_ROLE_READ {
+		t.Errorf("Onboard() account role = %v, want ROLE_READ", got)
+	}
+}
+
+func TestOffboardResumed(t *testing.T) {
+	fake := newFakeCloudService()
+	// The previous run deleted alice, and was interrupted while deleting the API keys of bob.
+	deleting := resourcev1.ResourceState_RESOURCE_STATE_DELETING
+	fake.users["u1"] = &identityv1.User{Id: "u1", ResourceVersion: "1", State: deleting, Spec: &identityv1.UserSpec{Email: "alice@example.com"}}
+	fake.users["u2"] = &identityv1.User{Id: "u2", ResourceVersion: "1", Spec: &identityv1.UserSpec{Email: "bob@example.com"}}
+	fake.keys = []*identityv1.ApiKey{
+		{Id: "k1", State: deleting, Spec: &identityv1.ApiKeySpec{OwnerId: "u1", OwnerType: identityv1.OwnerType_OWNER_TYPE_USER}},
+		{Id: "k2", State: deleting, Spec: &identityv1.ApiKeySpec{OwnerId: "u2", OwnerType: identityv1.OwnerType_OWNER_TYPE_USER}},
+		{Id: "k3", Spec: &identityv1.ApiKeySpec{OwnerId: "u2", OwnerType: identityv1.OwnerType_OWNER_TYPE_USER}},
+		{Id: "k4", Spec: &identityv1.ApiKeySpec{OwnerId: "u2", OwnerType: identityv1.OwnerType_OWNER_TYPE_USER}},
+	}
+	fake.gone = map[string]bool{"k4": true}
+
+	report, err := Offboard(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), []string{"Alice@Example.com", "bob@example.com"}, Options{DeleteAPIKeys: true})
+	if err != nil {
+		t.Fatalf("Offboard() error = %v", err)
+	}
+	if err := report.Err(); err != nil {
+		t.Fatalf("Offboard() report error = %v", err)
+	}
+	alice, bob := report.Results[0], report.Results[1]
+	if alice.UserID != "u1" || len(alice.Actions) != 0 {
+		t.Errorf("Offboard() alice = %+v, want no actions", alice)
+	}
+	if strings.Join(bob.Actions, ";") != "deleted API key k3;deleted user" {
+		t.Errorf("Offboard() bob = %+v", bob)
+	}
+}
+
+func TestReadUsersErrors(t *testing.T) {
+	for _, tc := range []struct {
+		csv, want string
+	}{
+		{"name\nalice\n", "missing email column"},
+		{"", "missing header"},
+		{"email,account_role\nalice@example.com,root\n", `line 2: invalid account role: unknown value "root", expected one of`},
+		{"email,namespaces\nalice@example.com,prod.a1b2c\n", `line 2: invalid namespace access "prod.a1b2c"`},
+		{"email,namespaces\nalice@example.com,prod.a1b2c=all\n", `line 2: invalid permission on namespace "prod.a1b2c"`},
+	} {
+		if _, err := ReadUsers(strings.NewReader(tc.csv)); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("ReadUsers(%q) error = %v, want %s", tc.csv, err, tc.want)
+		}
+	}
+}
@@ -74,6 +74,10 @@ type Options struct {
 	// If not provided, the user-agent header will contain product and version information for this SDK and grpc.
 	UserAgent string
 
+	// Enable the read-through cache of the get and list operations.
+	// If not provided, responses are not cached.
+	Cache *CacheOptions
+
 	// Add additional gRPC dial options.
 	// This can be used to set custom timeouts, interceptors, etc.
 	GRPCDialOptions []grpc.DialOption
@@ -99,6 +103,7 @@ func (r staticAPIKeyReader) GetAPIKey(ctx context.Context) (string, error) {
 func (o *Options) compute() (
 	hostPort string,
 	grpcDialOptions []grpc.DialOption,
+	cache *responseCache,
 	err error,
 ) {
 	hostPort = o.HostPort
@@ -124,7 +129,7 @@ func (o *Options) compute() (
 	)
 
 	if o.APIKey != "" && o.APIKeyReader != nil {
-		return "", nil, errors.New("only one of APIKey and APIKeyReader can be provided")
+		return "", nil, nil, errors.New("only one of APIKey and APIKeyReader can be provided")
 	}
 	// setup the api key credentials
 	creds := apikeyCreds{
@@ -136,7 +141,7 @@ func (o *Options) compute() (
 		creds.reader = o.APIKeyReader
 	}
 	if creds.reader == nil {
-		return "", nil, errors.New("either APIKey or APIKeyReader must be provided")
+		return "", nil, nil, errors.New("either APIKey or APIKeyReader must be provided")
 	} else {
 		grpcDialOptions = append(grpcDialOptions,
 			grpc.WithPerRPCCredentials(creds),
@@ -170,6 +175,12 @@ func (o *Options) compute() (
 		},
 	))
 
+	if o.Cache != nil {
+		// serve the cached responses before retrying, so that a cache hit never reaches the server.
+		cache = newResponseCache(*o.Cache)
+		grpcDialOptions = append(grpcDialOptions, grpc.WithChainUnaryInterceptor(cache.intercept))
+	}
+
 	if !o.DisableRetry {
 		// setup the default retry policy
 		retryOpts := []retry.CallOption{
@@ -188,5 +199,5 @@ func (o *Options) compute() (
 	}
 
 	grpcDialOptions = append(grpcDialOptions, o.GRPCDialOptions...)
-	return hostPort, grpcDialOptions, nil
+	return hostPort, grpcDialOptions, cache, nil
 }
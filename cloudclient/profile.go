@@ -0,0 +1,271 @@
+package cloudclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	configFileEnvVar    = "TEMPORAL_CLOUD_CONFIG_FILE"
+	profileEnvVar       = "TEMPORAL_CLOUD_PROFILE"
+	apiKeyEnvVar        = "TEMPORAL_CLOUD_API_KEY"
+	hostPortEnvVar      = "TEMPORAL_CLOUD_HOST_PORT"
+	apiVersionEnvVar    = "TEMPORAL_CLOUD_API_VERSION"
+	caFileEnvVar        = "TEMPORAL_CLOUD_CA_FILE"
+	allowInsecureEnvVar = "TEMPORAL_CLOUD_ALLOW_INSECURE"
+	userAgentEnvVar     = "TEMPORAL_CLOUD_USER_AGENT"
+
+	defaultProfileName = "default"
+)
+
+type (
+	// Config is the content of the configuration file, a set of named profiles to connect to several accounts.
+	//
+	// An example configuration file:
+	//
+	//	default_profile: staging
+	//	profiles:
+	//	  production:
+	//	    api_key:
+	//	      env: PRODUCTION_TEMPORAL_CLOUD_API_KEY
+	//	  staging:
+	//	    api_key:
+	//	      command: ["op", "read", "op://ops/temporal-cloud-staging/credential"]
+	//	      cache_duration: 10m
+	//	  local:
+	//	    host_port: localhost:7243
+	//	    allow_insecure: true
+	//	    api_key:
+	//	      file: /tmp/temporal-cloud-api-key
+	//
+	// WARNING: The configuration file format is currently experimental.
+	Config struct {
+		// The profile to use when none is selected.
+		// If not provided, the profile named "default" is used, if any.
+		DefaultProfile string `yaml:"default_profile"`
+
+		// The profiles by name.
+		Profiles map[string]Profile `yaml:"profiles"`
+	}
+
+	// Profile holds the options to connect to one account.
+	// The API key itself never lives in the configuration file, only where to read it from.
+	Profile struct {
+		// See Options.HostPort.
+		HostPort string `yaml:"host_port"`
+
+		// See Options.APIVersion.
+		APIVersion string `yaml:"api_version"`
+
+		// Where to read the API key from.
+		APIKey APIKeySource `yaml:"api_key"`
+
+		// The PEM file of the certificate authorities to verify the server certificate with.
+		// If not provided, the system certificate authorities are used.
+		CAFile string `yaml:"ca_file"`
+
+		// The name to verify the server certificate with.
+		// If not provided, the host of HostPort is used.
+		TLSServerName string `yaml:"tls_server_name"`
+
+		// See Options.AllowInsecure.
+		AllowInsecure bool `yaml:"allow_insecure"`
+
+		// See Options.UserAgent.
+		UserAgent string `yaml:"user_agent"`
+	}
+
+	// APIKeySource tells where to read the API key from, exactly one of Env, File and Command must be provided.
+	APIKeySource struct {
+		// The environment variable holding the API key, see EnvAPIKeyReader.
+		Env string `yaml:"env"`
+
+		// The file holding the API key, see FileAPIKeyReader.
+		File string `yaml:"file"`
+
+		// The command printing the API key, see CommandAPIKeyReader.
+		Command []string `yaml:"command"`
+
+		// How long to reuse the API key printed by Command, see CommandAPIKeyReader.CacheDuration.
+		CacheDuration time.Duration `yaml:"cache_duration"`
+	}
+)
+
+// DefaultConfigFile returns the path of the configuration file, $TEMPORAL_CLOUD_CONFIG_FILE if set,
+// temporalcloud/config.yaml in the user config directory of os.UserConfigDir otherwise: $XDG_CONFIG_HOME or
+// ~/.config on Unix, ~/Library/Application Support on macOS and %AppData% on Windows.
+func DefaultConfigFile() (string, error) {
+	if path := os.Getenv(configFileEnvVar); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the user config directory: %w", err)
+	}
+	return filepath.Join(dir, "temporalcloud", "config.yaml"), nil
+}
+
+// LoadConfig reads the configuration file at path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the config file: %w", err)
+	}
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse the config file %q: %w", path, err)
+	}
+	return &config, nil
+}
+
+// NewFromProfile creates a client from a profile of the default configuration file, see DefaultConfigFile.
+// If name is empty, the profile is selected as described in Config.Options.
+// A missing configuration file is not an error, the options are then only read from the environment variables.
+func NewFromProfile(name string) (*Client, error) {
+	path, err := DefaultConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	config, err := LoadConfig(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		config, err = &Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	options, err := config.Options(name)
+	if err != nil {
+		return nil, err
+	}
+	return New(options)
+}
+
+// Options returns the client options of a profile, overridden by the environment variables.
+//
+// If name is empty, the profile named by $TEMPORAL_CLOUD_PROFILE is used, then the default profile.
+// It is not an error to have no profile at all, only a profile that is named but missing.
+//
+// The environment variables overriding the profile are TEMPORAL_CLOUD_API_KEY, TEMPORAL_CLOUD_HOST_PORT,
+// TEMPORAL_CLOUD_API_VERSION, TEMPORAL_CLOUD_CA_FILE, TEMPORAL_CLOUD_ALLOW_INSECURE and TEMPORAL_CLOUD_USER_AGENT.
+func (c *Config) Options(name string) (Options, error) {
+	profile, err := c.profile(name)
+	if err != nil {
+		return Options{}, err
+	}
+
+	// override the profile with the environment variables
+	if v := os.Getenv(hostPortEnvVar); v != "" {
+		profile.HostPort = v
+	}
+	if v := os.Getenv(apiVersionEnvVar); v != "" {
+		profile.APIVersion = v
+	}
+	if v := os.Getenv(caFileEnvVar); v != "" {
+		profile.CAFile = v
+	}
+	if v := os.Getenv(userAgentEnvVar); v != "" {
+		profile.UserAgent = v
+	}
+	if v := os.Getenv(allowInsecureEnvVar); v != "" {
+		allowInsecure, err := strconv.ParseBool(v)
+		if err != nil {
+			return Options{}, fmt.Errorf("invalid %s: %w", allowInsecureEnvVar, err)
+		}
+		profile.AllowInsecure = allowInsecure
+	}
+	if v := os.Getenv(apiKeyEnvVar); v != "" {
+		profile.APIKey = APIKeySource{Env: apiKeyEnvVar}
+	}
+
+	return profile.Options()
+}
+
+func (c *Config) profile(name string) (Profile, error) {
+	if name == "" {
+		name = os.Getenv(profileEnvVar)
+	}
+	if name == "" {
+		name = c.DefaultProfile
+	}
+	if name == "" {
+		// the default profile is optional
+		return c.Profiles[defaultProfileName], nil
+	}
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("profile %q not found", name)
+	}
+	return profile, nil
+}
+
+// Options returns the client options of the profile.
+func (p Profile) Options() (Options, error) {
+	options := Options{
+		HostPort:      p.HostPort,
+		APIVersion:    p.APIVersion,
+		AllowInsecure: p.AllowInsecure,
+		UserAgent:     p.UserAgent,
+	}
+
+	if !p.APIKey.IsZero() {
+		reader, err := p.APIKey.Reader()
+		if err != nil {
+			return Options{}, err
+		}
+		options.APIKeyReader = reader
+	}
+
+	if p.CAFile != "" || p.TLSServerName != "" {
+		options.TLSConfig = &tls.Config{ServerName: p.TLSServerName}
+	}
+	if p.CAFile != "" {
+		pem, err := os.ReadFile(p.CAFile)
+		if err != nil {
+			return Options{}, fmt.Errorf("failed to read the CA file: %w", err)
+		}
+		options.TLSConfig.RootCAs = x509.NewCertPool()
+		if !options.TLSConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return Options{}, fmt.Errorf("no certificate found in the CA file %q", p.CAFile)
+		}
+	}
+	return options, nil
+}
+
+// IsZero reports whether no source is provided.
+func (s APIKeySource) IsZero() bool {
+	return s.Env == "" && s.File == "" && len(s.Command) == 0
+}
+
+// Reader returns the APIKeyReader reading the API key from the source.
+func (s APIKeySource) Reader() (APIKeyReader, error) {
+	var reader APIKeyReader
+	for _, r := range []struct {
+		set    bool
+		reader APIKeyReader
+	}{
+		{s.Env != "", EnvAPIKeyReader{Name: s.Env}},
+		{s.File != "", FileAPIKeyReader{Path: s.File}},
+		{len(s.Command) > 0, &CommandAPIKeyReader{Command: s.Command, CacheDuration: s.CacheDuration}},
+	} {
+		if !r.set {
+			continue
+		}
+		if reader != nil {
+			return nil, errors.New("only one of env, file and command can be provided as the API key source")
+		}
+		reader = r.reader
+	}
+	if reader == nil {
+		return nil, errors.New("one of env, file and command must be provided as the API key source")
+	}
+	return reader, nil
+}
@@ -0,0 +1,153 @@
+package cloudclient
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testConfig = `
+default_profile: staging
+profiles:
+  production:
+    api_version: 2024-10-01-00
+    api_key:
+      env: PRODUCTION_KEY
+  staging:
+    host_port: saas-api.staging.example.com:443
+    user_agent: ops-tool
+    api_key:
+      file: %KEY_FILE%
+  local:
+    host_port: localhost:7243
+    allow_insecure: true
+    api_key:
+      command: ["echo", "local-key"]
+  invalid:
+    api_key:
+      env: PRODUCTION_KEY
+      file: /dev/null
+`
+
+func loadTestConfig(t *testing.T) *Config {
+	t.Helper()
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "staging-key")
+	if err := os.WriteFile(keyFile, []byte("staging-key\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte(strings.ReplaceAll(testConfig, "%KEY_FILE%", keyFile)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	return config
+}
+
+func TestConfigOptions(t *testing.T) {
+	for _, env := range []string{profileEnvVar, apiKeyEnvVar, hostPortEnvVar, apiVersionEnvVar, caFileEnvVar, allowInsecureEnvVar, userAgentEnvVar} {
+		t.Setenv(env, "")
+	}
+	t.Setenv("PRODUCTION_KEY", "production-key")
+	config := loadTestConfig(t)
+
+	apiKey := func(t *testing.T, options Options) string {
+		t.Helper()
+		if options.APIKeyReader == nil {
+			t.Fatal("Options() has no APIKeyReader")
+		}
+		key, err := options.APIKeyReader.GetAPIKey(context.Background())
+		if err != nil {
+			t.Fatalf("GetAPIKey() error = %v", err)
+		}
+		return key
+	}
+
+	t.Run("Default Profile", func(t *testing.T) {
+		options, err := config.Options("")
+		if err != nil {
+			t.Fatalf("Options() error = %v", err)
+		}
+		if options.HostPort != "saas-api.staging.example.com:443" || options.UserAgent != "ops-tool" {
+			t.Errorf("Options() = %+v", options)
+		}
+		if key := apiKey(t, options); key != "staging-key" {
+			t.Errorf("GetAPIKey() = %q, want the trimmed file content", key)
+		}
+	})
+
+	t.Run("Named Profile", func(t *testing.T) {
+		options, err := config.Options("production")
+		if err != nil {
+			t.Fatalf("Options() error = %v", err)
+		}
+		if options.APIVersion != "2024-10-01-00" || options.HostPort != "" {
+			t.Errorf("Options() = %+v", options)
+		}
+		if key := apiKey(t, options); key != "production-key" {
+			t.Errorf("GetAPIKey() = %q", key)
+		}
+	})
+
+	t.Run("Command", func(t *testing.T) {
+		options, err := config.Options("local")
+		if err != nil {
+			t.Fatalf("Options() error = %v", err)
+		}
+		if !options.AllowInsecure {
+			t.Errorf("Options() = %+v, want AllowInsecure", options)
+		}
+		if key := apiKey(t, options); key != "local-key" {
+			t.Errorf("GetAPIKey() = %q", key)
+		}
+	})
+
+	t.Run("Environment Overrides", func(t *testing.T) {
+		t.Setenv(profileEnvVar, "local")
+		t.Setenv(hostPortEnvVar, "localhost:9999")
+		t.Setenv(allowInsecureEnvVar, "false")
+		t.Setenv(apiKeyEnvVar, "env-key")
+		options, err := config.Options("")
+		if err != nil {
+			t.Fatalf("Options() error = %v", err)
+		}
+		if options.HostPort != "localhost:9999" || options.AllowInsecure {
+			t.Errorf("Options() = %+v", options)
+		}
+		if key := apiKey(t, options); key != "env-key" {
+			t.Errorf("GetAPIKey() = %q", key)
+		}
+	})
+
+	t.Run("Missing Profile", func(t *testing.T) {
+		if _, err := config.Options("missing"); err == nil || !strings.Contains(err.Error(), `profile "missing" not found`) {
+			t.Errorf("Options() error = %v, want a missing profile error", err)
+		}
+	})
+
+	t.Run("Several API Key Sources", func(t *testing.T) {
+		if _, err := config.Options("invalid"); err == nil || !strings.Contains(err.Error(), "only one of") {
+			t.Errorf("Options() error = %v, want an API key source error", err)
+		}
+	})
+}
+
+func TestNewFromProfile(t *testing.T) {
+	t.Setenv(configFileEnvVar, filepath.Join(t.TempDir(), "missing.yaml"))
+	t.Setenv(profileEnvVar, "")
+	t.Setenv(apiKeyEnvVar, "env-key")
+	client, err := NewFromProfile("")
+	if err != nil {
+		t.Fatalf("NewFromProfile() error = %v", err)
+	}
+	client.Close()
+
+	if _, err := NewFromProfile("production"); err == nil {
+		t.Error("NewFromProfile() error = nil, want a missing profile error")
+	}
+}
@@ -0,0 +1,160 @@
+package cloudclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cloudservice "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identity "go.temporal.io/cloud-sdk/api/identity/v1"
+	namespace "go.temporal.io/cloud-sdk/api/namespace/v1"
+	operation "go.temporal.io/cloud-sdk/api/operation/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeCloudService struct {
+	cloudservice.CloudServiceClient
+
+	namespacePages map[string]*cloudservice.GetNamespacesResponse
+	apiKey         *identity.ApiKey
+	updateKey      *cloudservice.UpdateApiKeyRequest
+	deleteUser     *cloudservice.DeleteUserRequest
+	operation      *operation.AsyncOperation
+}
+
+func (f *fakeCloudService) GetNamespaces(ctx context.Context, req *cloudservice.GetNamespacesRequest, opts ...grpc.CallOption) (*cloudservice.GetNamespacesResponse, error) {
+	return f.namespacePages[req.GetPageToken()], nil
+}
+
+func (f *fakeCloudService) GetNamespace(ctx context.Context, req *cloudservice.GetNamespaceRequest, opts ...grpc.CallOption) (*cloudservice.GetNamespaceResponse, error) {
+	return nil, status.Errorf(codes.NotFound, "namespace %q not found", req.GetNamespace())
+}
+
+func (f *fakeCloudService) GetApiKey(ctx context.Context, req *cloudservice.GetApiKeyRequest, opts ...grpc.CallOption) (*cloudservice.GetApiKeyResponse, error) {
+	return &cloudservice.GetApiKeyResponse{ApiKey: f.apiKey}, nil
+}
+
+func (f *fakeCloudService) UpdateApiKey(ctx context.Context, req *cloudservice.UpdateApiKeyRequest, opts ...grpc.CallOption) (*cloudservice.UpdateApiKeyResponse, error) {
+	f.updateKey = req
+	return &cloudservice.UpdateApiKeyResponse{AsyncOperation: f.operation}, nil
+}
+
+func (f *fakeCloudService) GetUser(ctx context.Context, req *cloudservice.GetUserRequest, opts ...grpc.CallOption) (*cloudservice.GetUserResponse, error) {
+	return &cloudservice.GetUserResponse{User: &identity.User{Id: req.GetUserId(), ResourceVersion: "rv-latest"}}, nil
+}
+
+func (f *fakeCloudService) DeleteUser(ctx context.Context, req *cloudservice.DeleteUserRequest, opts ...grpc.CallOption) (*cloudservice.DeleteUserResponse, error) {
+	f.deleteUser = req
+	return &cloudservice.DeleteUserResponse{AsyncOperation: f.operation}, nil
+}
+
+func (f *fakeCloudService) GetAsyncOperation(ctx context.Context, req *cloudservice.GetAsyncOperationRequest, opts ...grpc.CallOption) (*cloudservice.GetAsyncOperationResponse, error) {
+	return &cloudservice.GetAsyncOperationResponse{AsyncOperation: f.operation}, nil
+}
+
+func TestResourceClients(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("List Follows Pages", func(t *testing.T) {
+		client := &Client{cloudServiceClient: &fakeCloudService{
+			namespacePages: map[string]*cloudservice.GetNamespacesResponse{
+				"": {
+					Namespaces:    []*namespace.Namespace{{Namespace: "a"}},
+					NextPageToken: "next",
+				},
+				"next": {
+					Namespaces: []*namespace.Namespace{{Namespace: "b"}},
+				},
+			},
+		}}
+		namespaces, err := client.Namespaces().List(ctx)
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if len(namespaces) != 2 || namespaces[0].GetNamespace() != "a" || namespaces[1].GetNamespace() != "b" {
+			t.Errorf("List() = %v, want namespaces a and b", namespaces)
+		}
+	})
+
+	t.Run("Errors Match Codes", func(t *testing.T) {
+		client := &Client{cloudServiceClient: &fakeCloudService{}}
+		_, err := client.Namespaces().Get(ctx, "missing")
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get() error = %v, want ErrNotFound", err)
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != codes.NotFound {
+			t.Errorf("Get() error = %v, want an APIError with code NotFound", err)
+		}
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("status.Code() = %v, want NotFound", status.Code(err))
+		}
+	})
+
+	t.Run("Disable Keeps Spec", func(t *testing.T) {
+		fake := &fakeCloudService{
+			apiKey: &identity.ApiKey{
+				Id:              "key",
+				ResourceVersion: "rv",
+				Spec:            &identity.ApiKeySpec{DisplayName: "ci", OwnerId: "sa"},
+			},
+		}
+		client := &Client{cloudServiceClient: fake}
+		if _, err := client.APIKeys().Disable(ctx, "key"); err != nil {
+			t.Fatalf("Disable() error = %v", err)
+		}
+		if !fake.updateKey.GetSpec().GetDisabled() || fake.updateKey.GetSpec().GetDisplayName() != "ci" {
+			t.Errorf("Disable() updated spec = %v", fake.updateKey.GetSpec())
+		}
+		if fake.updateKey.GetResourceVersion() != "rv" {
+			t.Errorf("Disable() resource version = %q, want rv", fake.updateKey.GetResourceVersion())
+		}
+		if fake.apiKey.GetSpec().GetDisabled() {
+			t.Errorf("Disable() modified the retrieved key")
+		}
+	})
+
+	t.Run("Disable Without Spec", func(t *testing.T) {
+		fake := &fakeCloudService{apiKey: &identity.ApiKey{Id: "key", ResourceVersion: "rv"}}
+		client := &Client{cloudServiceClient: fake}
+		if _, err := client.APIKeys().Disable(ctx, "key"); err == nil {
+			t.Error("Disable() of a key without spec succeeded, want an error")
+		}
+		if fake.updateKey != nil {
+			t.Errorf("Disable() updated %v", fake.updateKey)
+		}
+	})
+
+	t.Run("Delete Uses Latest Version", func(t *testing.T) {
+		fake := &fakeCloudService{}
+		client := &Client{cloudServiceClient: fake}
+		if _, err := client.Users().Delete(ctx, "user", ""); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if fake.deleteUser.GetResourceVersion() != "rv-latest" {
+			t.Errorf("Delete() resource version = %q, want rv-latest", fake.deleteUser.GetResourceVersion())
+		}
+	})
+
+	t.Run("Wait Failed Operation", func(t *testing.T) {
+		fake := &fakeCloudService{
+			operation: &operation.AsyncOperation{
+				Id:            "op",
+				State:         operation.AsyncOperation_STATE_FAILED,
+				FailureReason: "boom",
+			},
+		}
+		client := &Client{cloudServiceClient: fake}
+		op, err := client.Users().Delete(ctx, "user", "rv")
+		if err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		err = op.Wait(ctx)
+		var opErr *AsyncOperationError
+		if !errors.As(err, &opErr) || opErr.Operation.GetFailureReason() != "boom" {
+			t.Errorf("Wait() error = %v, want an AsyncOperationError", err)
+		}
+	})
+}
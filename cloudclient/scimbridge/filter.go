@@ -0,0 +1,403 @@
+package scimbridge
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type (
+	// filter is a parsed SCIM filter, see RFC 7644 section 3.4.2.2.
+	filter interface {
+		match(resource map[string]any) bool
+		// attributes appends the attribute paths referenced by the filter.
+		attributes(paths []string) []string
+	}
+
+	logicalFilter struct {
+		and         bool
+		left, right filter
+	}
+
+	notFilter struct {
+		filter filter
+	}
+
+	// compareFilter compares the values of an attribute, or checks their presence with the "pr" operator.
+	compareFilter struct {
+		path  string
+		op    string
+		value any
+	}
+
+	// valuePathFilter matches the resources with an element of a multi-valued attribute matching a filter,
+	// such as emails[type eq "work"].
+	valuePathFilter struct {
+		path   string
+		filter filter
+	}
+
+	filterParser struct {
+		tokens []string
+		pos    int
+	}
+)
+
+// parseFilter parses a SCIM filter. The attribute names are case insensitive, and so are the string comparisons.
+func parseFilter(s string) (filter, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %w", s, err)
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("invalid filter %q: unexpected %q", s, p.tokens[p.pos])
+	}
+	return f, nil
+}
+
+// tokenize splits a filter into parentheses, brackets, JSON strings and words.
+func tokenize(s string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ':
+			i++
+		case strings.IndexByte("()[]", c) >= 0:
+			tokens = append(tokens, string(c))
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("invalid filter %q: unterminated string", s)
+			}
+			tokens = append(tokens, s[i:j+1])
+			i = j + 1
+		default:
+			j := i
+			for j < len(s) && s[j] != ' ' && strings.IndexByte("()[]\"", s[j]) < 0 {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+func (p *filterParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *filterParser) next() (string, error) {
+	if p.pos >= len(p.tokens) {
+		return "", fmt.Errorf("unexpected end")
+	}
+	p.pos++
+	return p.tokens[p.pos-1], nil
+}
+
+func (p *filterParser) expect(token string) error {
+	t, err := p.next()
+	if err != nil {
+		return fmt.Errorf("expected %q: %w", token, err)
+	}
+	if t != token {
+		return fmt.Errorf("expected %q, got %q", token, t)
+	}
+	return nil
+}
+
+func (p *filterParser) parseOr() (filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for strings.EqualFold(p.peek(), "or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for strings.EqualFold(p.peek(), "and") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filter, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(t, "not") {
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return &notFilter{filter: f}, p.expect(")")
+	}
+	if t == "(" {
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return f, p.expect(")")
+	}
+	if !isAttrPath(t) {
+		return nil, fmt.Errorf("expected an attribute, got %q", t)
+	}
+	if p.peek() == "[" {
+		p.pos++
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return &valuePathFilter{path: t, filter: f}, p.expect("]")
+	}
+	op, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	op = strings.ToLower(op)
+	switch op {
+	case "pr":
+		return &compareFilter{path: t, op: op}, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, fmt.Errorf("unknown operator %q", op)
+	}
+	v, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	value, err := parseValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return &compareFilter{path: t, op: op, value: value}, nil
+}
+
+// parseValue parses the value compared to, a JSON string, number, boolean or null.
+func parseValue(s string) (any, error) {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s", s)
+		}
+		return v, nil
+	case s == "true" || s == "false":
+		return s == "true", nil
+	case s == "null":
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q", s)
+	}
+	return v, nil
+}
+
+func isAttrPath(s string) bool {
+	return s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune(":.-_$", r)
+	}) < 0
+}
+
+func (f *logicalFilter) match(resource map[string]any) bool {
+	if f.and {
+		return f.left.match(resource) && f.right.match(resource)
+	}
+	return f.left.match(resource) || f.right.match(resource)
+}
+
+func (f *logicalFilter) attributes(paths []string) []string {
+	return f.right.attributes(f.left.attributes(paths))
+}
+
+func (f *notFilter) match(resource map[string]any) bool {
+	return !f.filter.match(resource)
+}
+
+func (f *notFilter) attributes(paths []string) []string {
+	return f.filter.attributes(paths)
+}
+
+func (f *compareFilter) match(resource map[string]any) bool {
+	values := lookup(resource, f.path)
+	if f.op == "pr" {
+		return len(values) > 0
+	}
+	if f.op == "ne" {
+		return !(&compareFilter{path: f.path, op: "eq", value: f.value}).match(resource)
+	}
+	for _, v := range values {
+		if compare(v, f.op, f.value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *compareFilter) attributes(paths []string) []string {
+	return append(paths, f.path)
+}
+
+func (f *valuePathFilter) match(resource map[string]any) bool {
+	for _, v := range lookup(resource, f.path) {
+		if element, ok := v.(map[string]any); ok && f.filter.match(element) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *valuePathFilter) attributes(paths []string) []string {
+	return append(paths, f.path)
+}
+
+// compare applies a comparison operator, other than pr and ne, to a value of a resource.
+func compare(v any, op string, value any) bool {
+	switch v := v.(type) {
+	case string:
+		s, ok := value.(string)
+		if !ok {
+			return false
+		}
+		v, s = strings.ToLower(v), strings.ToLower(s)
+		switch op {
+		case "eq":
+			return v == s
+		case "co":
+			return strings.Contains(v, s)
+		case "sw":
+			return strings.HasPrefix(v, s)
+		case "ew":
+			return strings.HasSuffix(v, s)
+		case "gt":
+			return v > s
+		case "ge":
+			return v >= s
+		case "lt":
+			return v < s
+		case "le":
+			return v <= s
+		}
+	case float64:
+		n, ok := value.(float64)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "eq":
+			return v == n
+		case "gt":
+			return v > n
+		case "ge":
+			return v >= n
+		case "lt":
+			return v < n
+		case "le":
+			return v <= n
+		}
+	default:
+		return op == "eq" && v == value
+	}
+	return false
+}
+
+// splitPath splits an attribute path into its schema, if any, and its attribute names,
+// such as "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:manager.value".
+func splitPath(path string) (schema string, names []string) {
+	if strings.HasPrefix(strings.ToLower(path), "urn:") {
+		i := strings.LastIndex(path, ":")
+		schema, path = path[:i], path[i+1:]
+	}
+	return schema, strings.Split(path, ".")
+}
+
+// topLevel returns the top level attribute of a path, with its schema if any.
+func topLevel(path string) string {
+	schema, names := splitPath(path)
+	if schema != "" {
+		return schema + ":" + names[0]
+	}
+	return names[0]
+}
+
+// lookup returns the values of the attribute of a resource, flattening multi-valued attributes.
+func lookup(resource map[string]any, path string) []any {
+	schema, names := splitPath(path)
+	values := []any{resource}
+	if schema != "" {
+		if v, ok := get(resource, schema); ok {
+			values = []any{v}
+		} else if !strings.EqualFold(schema, UserSchema) && !strings.EqualFold(schema, GroupSchema) {
+			return nil
+		}
+	}
+	for _, name := range names {
+		var next []any
+		for _, v := range values {
+			object, ok := v.(map[string]any)
+			if !ok {
+				continue
+			}
+			if child, ok := get(object, name); ok {
+				if children, ok := child.([]any); ok {
+					next = append(next, children...)
+				} else if child != nil {
+					next = append(next, child)
+				}
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+// get returns the attribute of an object, by case insensitive name.
+func get(object map[string]any, name string) (any, bool) {
+	if v, ok := object[name]; ok {
+		return v, true
+	}
+	for k, v := range object {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return nil, false
+}
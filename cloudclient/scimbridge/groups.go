@@ -0,0 +1,272 @@
+package scimbridge
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func (s *Server) listGroups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	groups, err := s.cloudGroups(ctx)
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	// Listing the members of every group is expensive, identity providers usually exclude them.
+	withMembers := !slices.ContainsFunc(strings.Split(r.URL.Query().Get("excludedAttributes"), ","), func(name string) bool {
+		return strings.EqualFold(strings.TrimSpace(name), "members")
+	})
+	resources := make([]map[string]any, 0, len(groups))
+	for _, g := range groups {
+		var members []string
+		if withMembers {
+			if members, err = s.members(ctx, g.GetId()); err != nil {
+				s.error(w, err)
+				return
+			}
+		}
+		resources = append(resources, s.groupResource(g, members))
+	}
+	s.writeList(w, r, resources)
+}
+
+func (s *Server) getGroup(w http.ResponseWriter, r *http.Request) {
+	group, members, err := s.cloudGroup(r.Context(), r.PathValue("id"))
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	writeResource(w, r, http.StatusOK, s.groupResource(group, members))
+}
+
+func (s *Server) createGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var resource map[string]any
+	if err := readResource(r, &resource); err != nil {
+		s.error(w, err)
+		return
+	}
+	displayName := stringAttribute(resource, "displayName")
+	if displayName == "" {
+		s.error(w, badRequest("invalidValue", "missing displayName"))
+		return
+	}
+	members, err := memberIDs(resource)
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	groups, err := s.cloudGroups(ctx)
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	if slices.ContainsFunc(groups, func(g *identityv1.UserGroup) bool { return g.GetSpec().GetDisplayName() == displayName }) {
+		s.error(w, &scimError{status: http.StatusConflict, scimType: "uniqueness", detail: "a group named " + displayName + " already exists"})
+		return
+	}
+
+	id, op, err := s.client.UserGroups().Create(ctx, &identityv1.UserGroupSpec{
+		DisplayName: displayName,
+		Access:      s.options.GroupAccess.access(s.groupRules, resource),
+		GroupType:   &identityv1.UserGroupSpec_CloudGroup{CloudGroup: &identityv1.CloudGroupSpec{}},
+	})
+	if err == nil {
+		err = op.Wait(ctx)
+	}
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	group, current, err := s.cloudGroup(ctx, id)
+	if err == nil {
+		current, err = s.updateMembers(ctx, id, current, members)
+	}
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	writeResource(w, r, http.StatusCreated, s.groupResource(group, current))
+}
+
+func (s *Server) replaceGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var resource map[string]any
+	if err := readResource(r, &resource); err != nil {
+		s.error(w, err)
+		return
+	}
+	group, members, err := s.cloudGroup(ctx, r.PathValue("id"))
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	s.convergeGroup(ctx, w, r, group, members, resource, replaceUpdatesAccess(s.groupRules, resource))
+}
+
+func (s *Server) patchGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var patch patchRequest
+	if err := readResource(r, &patch); err != nil {
+		s.error(w, err)
+		return
+	}
+	group, members, err := s.cloudGroup(ctx, r.PathValue("id"))
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	resource := s.groupResource(group, members)
+	known := slices.Collect(maps.Keys(resource))
+	changed, err := applyPatch(resource, patch.Operations)
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	s.convergeGroup(ctx, w, r, group, members, resource, patchUpdatesAccess(s.groupRules, resource, known, changed))
+}
+
+func (s *Server) deleteGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	group, _, err := s.cloudGroup(ctx, r.PathValue("id"))
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	op, err := s.client.UserGroups().Delete(ctx, group.GetId(), group.GetResourceVersion())
+	if err == nil {
+		err = op.Wait(ctx)
+	}
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// convergeGroup updates the group and its members to match the resource, and writes the resulting resource.
+// The access of the group is only computed from the resource if updateAccess is set.
+func (s *Server) convergeGroup(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	group *identityv1.UserGroup,
+	members []string,
+	resource map[string]any,
+	updateAccess bool,
+) {
+	desired, err := memberIDs(resource)
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	spec := proto.Clone(group.GetSpec()).(*identityv1.UserGroupSpec)
+	if displayName := stringAttribute(resource, "displayName"); displayName != "" {
+		spec.DisplayName = displayName
+	}
+	if updateAccess {
+		spec.Access = s.options.GroupAccess.access(s.groupRules, resource)
+	}
+	if !proto.Equal(spec, group.GetSpec()) {
+		op, err := s.client.UserGroups().Update(ctx, &identityv1.UserGroup{
+			Id:              group.GetId(),
+			ResourceVersion: group.GetResourceVersion(),
+			Spec:            spec,
+		})
+		if err == nil {
+			err = op.Wait(ctx)
+		}
+		if err == nil {
+			group, err = s.client.UserGroups().Get(ctx, group.GetId())
+		}
+		if err != nil {
+			s.error(w, err)
+			return
+		}
+	}
+	if members, err = s.updateMembers(ctx, group.GetId(), members, desired); err != nil {
+		s.error(w, err)
+		return
+	}
+	writeResource(w, r, http.StatusOK, s.groupResource(group, members))
+}
+
+// updateMembers adds and removes members of the group so that its members go from current to desired,
+// and returns the desired members.
+func (s *Server) updateMembers(ctx context.Context, groupID string, current, desired []string) ([]string, error) {
+	for _, id := range desired {
+		if slices.Contains(current, id) {
+			continue
+		}
+		op, err := s.client.UserGroups().AddMember(ctx, groupID, id)
+		if err == nil {
+			err = op.Wait(ctx)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range current {
+		if slices.Contains(desired, id) {
+			continue
+		}
+		op, err := s.client.UserGroups().RemoveMember(ctx, groupID, id)
+		if err == nil {
+			err = op.Wait(ctx)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return desired, nil
+}
+
+// cloudGroups returns the user groups managed by Temporal Cloud, the only ones exposed as SCIM groups.
+func (s *Server) cloudGroups(ctx context.Context) ([]*identityv1.UserGroup, error) {
+	groups, err := s.client.UserGroups().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(groups, func(g *identityv1.UserGroup) bool {
+		return g.GetSpec().GetCloudGroup() == nil
+	}), nil
+}
+
+// cloudGroup returns the user group with the given id, and the ids of its member users.
+// A group not managed by Temporal Cloud is reported as not found.
+func (s *Server) cloudGroup(ctx context.Context, id string) (*identityv1.UserGroup, []string, error) {
+	group, err := s.client.UserGroups().Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if group.GetSpec().GetCloudGroup() == nil {
+		return nil, nil, notFound("group", id)
+	}
+	members, err := s.members(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return group, members, nil
+}
+
+// members returns the ids of the member users of a group, sorted.
+func (s *Server) members(ctx context.Context, groupID string) ([]string, error) {
+	members, err := s.client.UserGroups().ListMembers(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, m := range members {
+		if id := m.GetMemberId().GetUserId(); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
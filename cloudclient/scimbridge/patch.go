@@ -0,0 +1,234 @@
+package scimbridge
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+type (
+	// patchRequest is the body of a PATCH request, see RFC 7644 section 3.5.2.
+	patchRequest struct {
+		Schemas    []string         `json:"schemas"`
+		Operations []patchOperation `json:"Operations"`
+	}
+
+	patchOperation struct {
+		Op    string `json:"op"`
+		Path  string `json:"path,omitempty"`
+		Value any    `json:"value,omitempty"`
+	}
+)
+
+// applyPatch applies the operations to the resource in order, and returns the top level attributes they changed.
+//
+// Besides the operations of RFC 7644, a remove operation on a multi-valued attribute can list the elements to
+// remove in its value, as some identity providers do to remove group members.
+func applyPatch(resource map[string]any, operations []patchOperation) ([]string, error) {
+	var changed []string
+	for i, op := range operations {
+		kind := strings.ToLower(op.Op)
+		if kind != "add" && kind != "replace" && kind != "remove" {
+			return nil, badRequest("invalidSyntax", "operation %d: unknown op %q", i, op.Op)
+		}
+		if op.Path == "" {
+			if kind == "remove" {
+				return nil, badRequest("noTarget", "operation %d: a remove operation requires a path", i)
+			}
+			values, ok := op.Value.(map[string]any)
+			if !ok {
+				return nil, badRequest("invalidValue", "operation %d: the value of an operation without path must be an object", i)
+			}
+			for _, name := range slices.Sorted(maps.Keys(values)) {
+				// The attributes of an extension schema are nested in an object named after the schema.
+				extension, isExtension := values[name].(map[string]any)
+				if !strings.HasPrefix(strings.ToLower(name), "urn:") || !isExtension {
+					if err := applyOperation(resource, kind, name, values[name]); err != nil {
+						return nil, badRequest("invalidPath", "operation %d: %v", i, err)
+					}
+					changed = append(changed, topLevel(name))
+					continue
+				}
+				for _, attr := range slices.Sorted(maps.Keys(extension)) {
+					path := name + ":" + attr
+					if err := applyOperation(resource, kind, path, extension[attr]); err != nil {
+						return nil, badRequest("invalidPath", "operation %d: %v", i, err)
+					}
+					changed = append(changed, topLevel(path))
+				}
+			}
+			continue
+		}
+		if err := applyOperation(resource, kind, op.Path, op.Value); err != nil {
+			return nil, badRequest("invalidPath", "operation %d: %v", i, err)
+		}
+		changed = append(changed, topLevel(op.Path))
+	}
+	return changed, nil
+}
+
+// applyOperation applies an operation to the attribute at the path, which can select elements of a multi-valued
+// attribute with a filter, such as members[value eq "u1"], and one of their sub-attributes.
+func applyOperation(resource map[string]any, kind, path string, value any) error {
+	attrPath, selector, sub := path, "", ""
+	if i := strings.IndexByte(path, '['); i >= 0 {
+		j := strings.LastIndexByte(path, ']')
+		if j < i {
+			return fmt.Errorf("invalid path %q", path)
+		}
+		attrPath, selector = path[:i], path[i+1:j]
+		sub = strings.TrimPrefix(path[j+1:], ".")
+	}
+	parent, name, err := resolve(resource, attrPath, kind != "remove")
+	if err != nil || parent == nil {
+		return err
+	}
+	current, _ := get(parent, name)
+
+	if selector == "" {
+		switch kind {
+		case "remove":
+			elements, isArray := current.([]any)
+			removed, hasValue := value.([]any)
+			if isArray && hasValue {
+				set(parent, name, slices.DeleteFunc(elements, func(e any) bool {
+					return slices.ContainsFunc(removed, func(r any) bool { return sameElement(e, r) })
+				}))
+				return nil
+			}
+			del(parent, name)
+		case "add":
+			if elements, ok := current.([]any); ok {
+				added, ok := value.([]any)
+				if !ok {
+					added = []any{value}
+				}
+				for _, a := range added {
+					if !slices.ContainsFunc(elements, func(e any) bool { return sameElement(e, a) }) {
+						elements = append(elements, a)
+					}
+				}
+				set(parent, name, elements)
+				return nil
+			}
+			fallthrough
+		case "replace":
+			object, isObject := current.(map[string]any)
+			values, hasObject := value.(map[string]any)
+			if isObject && hasObject {
+				for k, v := range values {
+					set(object, k, v)
+				}
+				return nil
+			}
+			set(parent, name, value)
+		}
+		return nil
+	}
+
+	f, err := parseFilter(selector)
+	if err != nil {
+		return err
+	}
+	elements, _ := current.([]any)
+	matched := false
+	for i := 0; i < len(elements); i++ {
+		element, ok := elements[i].(map[string]any)
+		if !ok || !f.match(element) {
+			continue
+		}
+		matched = true
+		switch {
+		case kind == "remove" && sub == "":
+			elements = slices.Delete(elements, i, i+1)
+			i--
+		case kind == "remove":
+			del(element, sub)
+		case sub != "":
+			set(element, sub, value)
+		default:
+			values, ok := value.(map[string]any)
+			if !ok {
+				return fmt.Errorf("the value replacing the elements of %q must be an object", attrPath)
+			}
+			for k, v := range values {
+				set(element, k, v)
+			}
+		}
+	}
+	if !matched && kind != "remove" {
+		return fmt.Errorf("no element of %q matches %q", attrPath, selector)
+	}
+	set(parent, name, elements)
+	return nil
+}
+
+// resolve returns the object holding the attribute at the path, and the name of the attribute in it.
+// If create is set, the missing objects are created, otherwise a nil object is returned.
+func resolve(resource map[string]any, path string, create bool) (map[string]any, string, error) {
+	schema, names := splitPath(path)
+	parent := resource
+	if schema != "" && !strings.EqualFold(schema, UserSchema) && !strings.EqualFold(schema, GroupSchema) {
+		names = append([]string{schema}, names...)
+	}
+	for _, name := range names[:len(names)-1] {
+		child, ok := get(parent, name)
+		if !ok || child == nil {
+			if !create {
+				return nil, "", nil
+			}
+			child = map[string]any{}
+			set(parent, name, child)
+		}
+		object, ok := child.(map[string]any)
+		if !ok {
+			return nil, "", fmt.Errorf("attribute %q of path %q is not an object", name, path)
+		}
+		parent = object
+	}
+	name := names[len(names)-1]
+	if name == "" {
+		return nil, "", fmt.Errorf("invalid path %q", path)
+	}
+	return parent, name, nil
+}
+
+// set sets the attribute of an object, replacing the attribute with the same case insensitive name if any.
+func set(object map[string]any, name string, value any) {
+	for k := range object {
+		if strings.EqualFold(k, name) {
+			object[k] = value
+			return
+		}
+	}
+	object[name] = value
+}
+
+// del deletes the attribute of an object, by case insensitive name.
+func del(object map[string]any, name string) {
+	for k := range object {
+		if strings.EqualFold(k, name) {
+			delete(object, k)
+		}
+	}
+}
+
+// sameElement reports whether two elements of a multi-valued attribute are the same, comparing the value
+// sub-attribute of complex elements.
+func sameElement(a, b any) bool {
+	ma, okA := a.(map[string]any)
+	mb, okB := b.(map[string]any)
+	if okA && okB {
+		va, _ := get(ma, "value")
+		vb, _ := get(mb, "value")
+		return va != nil && reflect.DeepEqual(va, vb)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func badRequest(scimType, format string, args ...any) *scimError {
+	return &scimError{status: http.StatusBadRequest, scimType: scimType, detail: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,261 @@
+package scimbridge
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxBodySize is the maximum size of the body of a request.
+const maxBodySize = 1 << 20
+
+// scimError is an error reported to the identity provider, see RFC 7644 section 3.12.
+type scimError struct {
+	status   int
+	scimType string
+	detail   string
+}
+
+func (e *scimError) Error() string {
+	return e.detail
+}
+
+// userResource returns the SCIM resource of a user.
+func (s *Server) userResource(u *identityv1.User) map[string]any {
+	email := u.GetSpec().GetEmail()
+	return map[string]any{
+		"schemas":  []any{UserSchema},
+		"id":       u.GetId(),
+		"userName": email,
+		"emails":   []any{map[string]any{"value": email, "type": "work", "primary": true}},
+		"active":   true,
+		"meta":     s.meta("User", "/Users/", u.GetId(), u.GetResourceVersion(), u.GetCreatedTime(), u.GetLastModifiedTime()),
+	}
+}
+
+// groupResource returns the SCIM resource of a user group, with the ids of its member users.
+func (s *Server) groupResource(g *identityv1.UserGroup, memberIDs []string) map[string]any {
+	members := []any{}
+	for _, id := range memberIDs {
+		members = append(members, map[string]any{"value": id})
+	}
+	resource := map[string]any{
+		"schemas":     []any{GroupSchema},
+		"id":          g.GetId(),
+		"displayName": g.GetSpec().GetDisplayName(),
+		"members":     members,
+		"meta":        s.meta("Group", "/Groups/", g.GetId(), g.GetResourceVersion(), g.GetCreatedTime(), g.GetLastModifiedTime()),
+	}
+	return resource
+}
+
+func (s *Server) meta(resourceType, path, id, version string, created, lastModified *timestamppb.Timestamp) map[string]any {
+	meta := map[string]any{"resourceType": resourceType}
+	if version != "" {
+		meta["version"] = `W/"` + version + `"`
+	}
+	if created != nil {
+		meta["created"] = created.AsTime().Format(time.RFC3339)
+		meta["lastModified"] = meta["created"]
+	}
+	if lastModified != nil {
+		meta["lastModified"] = lastModified.AsTime().Format(time.RFC3339)
+	}
+	if s.options.BaseURL != "" {
+		meta["location"] = s.options.BaseURL + path + id
+	}
+	return meta
+}
+
+// writeList writes the resources matching the filter of the request, paginated with its startIndex and count
+// parameters, see RFC 7644 section 3.4.2.
+func (s *Server) writeList(w http.ResponseWriter, r *http.Request, resources []map[string]any) {
+	query := r.URL.Query()
+	if expr := query.Get("filter"); expr != "" {
+		f, err := parseFilter(expr)
+		if err != nil {
+			writeError(w, badRequest("invalidFilter", "%v", err))
+			return
+		}
+		resources = slices.DeleteFunc(resources, func(resource map[string]any) bool {
+			return !f.match(resource)
+		})
+	}
+	total := len(resources)
+	startIndex, count := 1, s.options.MaxResults
+	if v := query.Get("startIndex"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, badRequest("invalidValue", "invalid startIndex %q", v))
+			return
+		}
+		startIndex = max(n, 1)
+	}
+	if v := query.Get("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, badRequest("invalidValue", "invalid count %q", v))
+			return
+		}
+		count = min(max(n, 0), s.options.MaxResults)
+	}
+	// The bounds are clamped before they are added, a huge startIndex would overflow.
+	start := min(startIndex-1, total)
+	end := start + min(total-start, count)
+	page := resources[start:end]
+	for _, resource := range page {
+		project(resource, query.Get("attributes"), query.Get("excludedAttributes"))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"schemas":      []string{ListResponseSchema},
+		"totalResults": total,
+		"startIndex":   startIndex,
+		"itemsPerPage": len(page),
+		"Resources":    page,
+	})
+}
+
+// writeResource writes a resource, with the attributes requested by the attributes and excludedAttributes
+// parameters of the request.
+func writeResource(w http.ResponseWriter, r *http.Request, status int, resource map[string]any) {
+	project(resource, r.URL.Query().Get("attributes"), r.URL.Query().Get("excludedAttributes"))
+	if location, ok := resource["meta"].(map[string]any)["location"].(string); ok {
+		w.Header().Set("Location", location)
+	}
+	writeJSON(w, status, resource)
+}
+
+// project keeps the top level attributes listed in attributes, if any, and removes the ones listed in
+// excludedAttributes, both comma separated. The id, schemas and meta attributes are always kept.
+func project(resource map[string]any, attributes, excludedAttributes string) {
+	list := func(s string) []string {
+		var names []string
+		for _, name := range strings.Split(s, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, strings.ToLower(topLevel(name)))
+			}
+		}
+		return names
+	}
+	kept, excluded := list(attributes), list(excludedAttributes)
+	for name := range resource {
+		switch name {
+		case "id", "schemas", "meta":
+			continue
+		}
+		lower := strings.ToLower(name)
+		if (len(kept) > 0 && !slices.Contains(kept, lower)) || slices.Contains(excluded, lower) {
+			delete(resource, name)
+		}
+	}
+}
+
+// readResource reads the JSON object in the body of a request.
+func readResource(r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBodySize))
+	if err := dec.Decode(v); err != nil {
+		return badRequest("invalidSyntax", "invalid request body: %v", err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err *scimError) {
+	body := map[string]any{
+		"schemas": []string{ErrorSchema},
+		"status":  strconv.Itoa(err.status),
+		"detail":  err.detail,
+	}
+	if err.scimType != "" {
+		body["scimType"] = err.scimType
+	}
+	writeJSON(w, err.status, body)
+}
+
+// emailOf returns the email of a SCIM user: its userName if it is an email, or else its primary email.
+func emailOf(resource map[string]any) (string, error) {
+	for _, v := range lookup(resource, "userName") {
+		if userName, ok := v.(string); ok && strings.Contains(userName, "@") {
+			return userName, nil
+		}
+	}
+	var first string
+	for _, v := range lookup(resource, "emails") {
+		email, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		value, _ := email["value"].(string)
+		if primary, _ := email["primary"].(bool); primary && value != "" {
+			return value, nil
+		}
+		if first == "" {
+			first = value
+		}
+	}
+	if first == "" {
+		return "", badRequest("invalidValue", "the user has no email: its userName is not an email and it has no emails")
+	}
+	return first, nil
+}
+
+// isActive reports whether a SCIM user is active, which it is unless its active attribute is false.
+func isActive(resource map[string]any) bool {
+	for _, v := range lookup(resource, "active") {
+		switch v := v.(type) {
+		case bool:
+			return v
+		case string:
+			// Some identity providers send booleans as strings.
+			return !strings.EqualFold(v, "false")
+		}
+	}
+	return true
+}
+
+// memberIDs returns the user ids of the members of a SCIM group.
+func memberIDs(resource map[string]any) ([]string, error) {
+	var ids []string
+	for _, v := range lookup(resource, "members") {
+		member, ok := v.(map[string]any)
+		if !ok {
+			return nil, badRequest("invalidValue", "invalid member %v", v)
+		}
+		id, _ := member["value"].(string)
+		if id == "" {
+			return nil, badRequest("invalidValue", "member without value")
+		}
+		if memberType, _ := member["type"].(string); memberType != "" && !strings.EqualFold(memberType, "User") {
+			return nil, badRequest("invalidValue", "member %s: only users can be members of a group, not %s", id, memberType)
+		}
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids), nil
+}
+
+// stringAttribute returns the value of a string attribute of a resource, empty if it is not set.
+func stringAttribute(resource map[string]any, path string) string {
+	for _, v := range lookup(resource, path) {
+		if s, ok := v.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+func notFound(resourceType, id string) *scimError {
+	return &scimError{status: http.StatusNotFound, detail: fmt.Sprintf("%s %q not found", resourceType, id)}
+}
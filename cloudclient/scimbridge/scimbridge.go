@@ -0,0 +1,295 @@
+// Package scimbridge serves the SCIM 2.0 Users and Groups endpoints (RFC 7643 and RFC 7644) on top of the cloud
+// operations API, so that an identity provider can provision the users of a Temporal Cloud account, and the
+// members of its user groups, through SCIM.
+//
+// WARNING: The package is currently experimental.
+//
+// The bridge is stateless: every request is translated into calls to the cloud operations API, and every
+// resource it returns is built from the users and user groups of the account. A SCIM user is a Temporal Cloud
+// user, identified by its email, and its access is computed from its SCIM attributes by an AccessMapping, the
+// attributes themselves are not stored. A SCIM group is a user group managed by Temporal Cloud, with a
+// CloudGroupSpec. Deactivating a user, by setting active to false, deletes it.
+//
+// Every mutation waits for its async operation to complete before responding, so that the identity provider
+// reads its own writes.
+package scimbridge
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	"go.temporal.io/cloud-sdk/cloudclient"
+)
+
+// The schemas of the resources and messages of the server.
+const (
+	UserSchema                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	GroupSchema                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ListResponseSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	PatchOpSchema               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ErrorSchema                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	ServiceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+// contentType is the media type of the SCIM requests and responses.
+const contentType = "application/scim+json"
+
+type (
+	// Options configure a Server.
+	Options struct {
+		// The bearer token the identity provider authenticates with.
+		// Required, unless AllowUnauthenticated is set.
+		Token string
+
+		// Whether the server may be created without a Token, to serve requests that are authenticated by a
+		// middleware instead.
+		AllowUnauthenticated bool
+
+		// The URL the server is reachable at, such as "https://scim.example.com/scim/v2", used to set the
+		// meta.location of the resources.
+		// If not provided, meta.location is omitted.
+		BaseURL string
+
+		// The mapping from the attributes of SCIM users to the access of Temporal Cloud users.
+		// If not provided, users are given the ROLE_READ account role.
+		UserAccess AccessMapping
+
+		// The mapping from the attributes of SCIM groups to the access of Temporal Cloud user groups.
+		// If not provided, groups are given the ROLE_READ account role.
+		GroupAccess AccessMapping
+
+		// Whether the user access mapping may lower the account role of the users with the ROLE_OWNER or
+		// ROLE_ADMIN account role, which are typically granted outside of the identity provider.
+		// If not set, their account role is kept, and only their namespace access is updated.
+		LowerAdminRoles bool
+
+		// The maximum number of resources returned by a list request.
+		// If not provided, DefaultMaxResults is used.
+		MaxResults int
+
+		// Called with the errors of the cloud operations API that are reported as internal errors to the
+		// identity provider, which only gets a generic detail.
+		// If not provided, the errors are not reported.
+		OnError func(error)
+	}
+
+	// AccessMapping computes the access of a user, or of a group, from its SCIM attributes.
+	//
+	// The access is evaluated against the attributes of the request: POST requests set the access from the
+	// attributes they carry, PUT requests from the ones they carry too, and PATCH requests only carry the ones they
+	// change. The access is only updated by a PUT or a PATCH that carries an attribute referenced by the filter of
+	// a rule, and only if the attributes referenced by the rules up to the first matching one are known: carried
+	// by the request, or, for a PATCH, kept by Temporal Cloud, that is userName, emails and active for users, and
+	// displayName and members for groups. Otherwise the access is left unchanged, since the attributes a request
+	// does not carry are not stored by the bridge.
+	//
+	// The account role of a user with the ROLE_OWNER or ROLE_ADMIN account role is never lowered by the mapping,
+	// unless Options.LowerAdminRoles is set.
+	AccessMapping struct {
+		// The rules, the access of the first rule whose filter matches the resource is used.
+		Rules []AccessRule
+		// The access used when no rule matches.
+		// If not provided, the ROLE_READ account role without namespace access is used.
+		Default *identityv1.Access
+	}
+
+	// AccessRule gives an access to the resources matching a filter.
+	AccessRule struct {
+		// A SCIM filter, such as `title eq "SRE"` or `roles[value eq "admin"]`.
+		Filter string
+		Access *identityv1.Access
+	}
+
+	// Server serves the SCIM endpoints. It is an http.Handler, to be mounted at the root of the SCIM base URL.
+	Server struct {
+		client  *cloudclient.Client
+		options Options
+		mux     *http.ServeMux
+
+		userRules  []compiledRule
+		groupRules []compiledRule
+	}
+
+	compiledRule struct {
+		filter filter
+		access *identityv1.Access
+	}
+)
+
+// DefaultMaxResults is the maximum number of resources returned by a list request when Options.MaxResults is not set.
+const DefaultMaxResults = 1000
+
+// New returns a server translating the SCIM requests into calls to the cloud operations API made with the client.
+func New(client *cloudclient.Client, options Options) (*Server, error) {
+	if options.Token == "" && !options.AllowUnauthenticated {
+		return nil, errors.New("missing token, set AllowUnauthenticated if requests are authenticated by a middleware")
+	}
+	if options.MaxResults <= 0 {
+		options.MaxResults = DefaultMaxResults
+	}
+	options.BaseURL = strings.TrimSuffix(options.BaseURL, "/")
+	s := &Server{client: client, options: options, mux: http.NewServeMux()}
+	var err error
+	if s.userRules, err = options.UserAccess.compile(); err != nil {
+		return nil, fmt.Errorf("invalid user access mapping: %w", err)
+	}
+	if s.groupRules, err = options.GroupAccess.compile(); err != nil {
+		return nil, fmt.Errorf("invalid group access mapping: %w", err)
+	}
+
+	s.mux.HandleFunc("GET /ServiceProviderConfig", s.serviceProviderConfig)
+	s.mux.HandleFunc("GET /Users", s.listUsers)
+	s.mux.HandleFunc("POST /Users", s.createUser)
+	s.mux.HandleFunc("GET /Users/{id}", s.getUser)
+	s.mux.HandleFunc("PUT /Users/{id}", s.replaceUser)
+	s.mux.HandleFunc("PATCH /Users/{id}", s.patchUser)
+	s.mux.HandleFunc("DELETE /Users/{id}", s.deleteUser)
+	s.mux.HandleFunc("GET /Groups", s.listGroups)
+	s.mux.HandleFunc("POST /Groups", s.createGroup)
+	s.mux.HandleFunc("GET /Groups/{id}", s.getGroup)
+	s.mux.HandleFunc("PUT /Groups/{id}", s.replaceGroup)
+	s.mux.HandleFunc("PATCH /Groups/{id}", s.patchGroup)
+	s.mux.HandleFunc("DELETE /Groups/{id}", s.deleteGroup)
+	return s, nil
+}
+
+// ServeHTTP authenticates the request and dispatches it to its endpoint.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.options.Token != "" {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(s.options.Token)) != 1 {
+			writeError(w, &scimError{status: http.StatusUnauthorized, detail: "invalid bearer token"})
+			return
+		}
+	}
+	if _, pattern := s.mux.Handler(r); pattern == "" {
+		writeError(w, &scimError{status: http.StatusNotFound, detail: fmt.Sprintf("no endpoint for %s %s", r.Method, r.URL.Path)})
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) serviceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	supported := func(ok bool) map[string]any { return map[string]any{"supported": ok} }
+	writeJSON(w, http.StatusOK, map[string]any{
+		"schemas":        []string{ServiceProviderConfigSchema},
+		"patch":          supported(true),
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": s.options.MaxResults},
+		"changePassword": supported(false),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication with a bearer token",
+		}},
+	})
+}
+
+// error writes the SCIM error matching an error of the cloud operations API, or of the request.
+func (s *Server) error(w http.ResponseWriter, err error) {
+	var se *scimError
+	switch {
+	case errors.As(err, &se):
+	case errors.Is(err, cloudclient.ErrNotFound):
+		se = &scimError{status: http.StatusNotFound, detail: err.Error()}
+	case errors.Is(err, cloudclient.ErrAlreadyExists):
+		se = &scimError{status: http.StatusConflict, scimType: "uniqueness", detail: err.Error()}
+	case errors.Is(err, cloudclient.ErrInvalidArgument):
+		se = &scimError{status: http.StatusBadRequest, scimType: "invalidValue", detail: err.Error()}
+	case errors.Is(err, cloudclient.ErrFailedPrecondition):
+		se = &scimError{status: http.StatusPreconditionFailed, detail: err.Error()}
+	case errors.Is(err, cloudclient.ErrRateLimited):
+		se = &scimError{status: http.StatusTooManyRequests, detail: err.Error()}
+	default:
+		if s.options.OnError != nil {
+			s.options.OnError(err)
+		}
+		// The error may reveal details of the account or of the bridge, it is only reported to OnError.
+		se = &scimError{status: http.StatusInternalServerError, detail: "internal error"}
+	}
+	writeError(w, se)
+}
+
+func (m AccessMapping) compile() ([]compiledRule, error) {
+	var rules []compiledRule
+	for i, rule := range m.Rules {
+		f, err := parseFilter(rule.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		rules = append(rules, compiledRule{filter: f, access: rule.Access})
+	}
+	return rules, nil
+}
+
+// access returns the access of the resource, the one of the first matching rule or the default one.
+func (m AccessMapping) access(rules []compiledRule, resource map[string]any) *identityv1.Access {
+	for _, rule := range rules {
+		if rule.filter.match(resource) {
+			return rule.access
+		}
+	}
+	if m.Default != nil {
+		return m.Default
+	}
+	return &identityv1.Access{AccountAccess: &identityv1.AccountAccess{Role: identityv1.AccountAccess_ROLE_READ}}
+}
+
+// patchUpdatesAccess reports whether a PATCH, which changed some attributes of the resource, updates its access.
+// The known attributes are the ones of the resource before the PATCH, which are all kept by Temporal Cloud,
+// and the changed ones, the other attributes referenced by the rules are unknown.
+func patchUpdatesAccess(rules []compiledRule, resource map[string]any, known, changed []string) bool {
+	if !references(rules, changed) {
+		return false
+	}
+	known = append(known, changed...)
+	for _, rule := range rules {
+		for _, path := range rule.filter.attributes(nil) {
+			if !slices.ContainsFunc(known, func(a string) bool { return strings.EqualFold(topLevel(path), topLevel(a)) }) {
+				return false
+			}
+		}
+		if rule.filter.match(resource) {
+			return true
+		}
+	}
+	return true
+}
+
+// replaceUpdatesAccess reports whether a PUT, which replaces the resource, updates its access. The known attributes
+// are the ones the PUT carries.
+func replaceUpdatesAccess(rules []compiledRule, resource map[string]any) bool {
+	var carried []string
+	for name, value := range resource {
+		// The attributes of an extension schema are nested in an object named after the schema.
+		if extension, ok := value.(map[string]any); ok && strings.HasPrefix(strings.ToLower(name), "urn:") {
+			for attr := range extension {
+				carried = append(carried, topLevel(name+":"+attr))
+			}
+			continue
+		}
+		carried = append(carried, topLevel(name))
+	}
+	return patchUpdatesAccess(rules, resource, nil, carried)
+}
+
+// references reports whether the filter of a rule references one of the attributes.
+func references(rules []compiledRule, attributes []string) bool {
+	for _, rule := range rules {
+		for _, path := range rule.filter.attributes(nil) {
+			for _, a := range attributes {
+				if strings.EqualFold(topLevel(path), topLevel(a)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
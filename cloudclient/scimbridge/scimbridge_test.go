@@ -0,0 +1,512 @@
+package scimbridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	operationv1 "go.temporal.io/cloud-sdk/api/operation/v1"
+	"go.temporal.io/cloud-sdk/cloudclient"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const enterpriseSchema = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+
+// fakeCloudService keeps the users and user groups of an account in memory. Its operations complete synchronously.
+type fakeCloudService struct {
+	cloudservicev1.UnimplementedCloudServiceServer
+
+	mu      sync.Mutex
+	nextID  int
+	users   map[string]*identityv1.User
+	groups  map[string]*identityv1.UserGroup
+	members map[string][]string
+	// the error of GetUsers
+	usersErr error
+}
+
+func (s *fakeCloudService) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s%d", prefix, s.nextID)
+}
+
+func (s *fakeCloudService) operation() *operationv1.AsyncOperation {
+	return &operationv1.AsyncOperation{Id: s.newID("op"), State: operationv1.AsyncOperation_STATE_FULFILLED}
+}
+
+func (s *fakeCloudService) GetAsyncOperation(ctx context.Context, req *cloudservicev1.GetAsyncOperationRequest) (*cloudservicev1.GetAsyncOperationResponse, error) {
+	return &cloudservicev1.GetAsyncOperationResponse{AsyncOperation: &operationv1.AsyncOperation{
+		Id:    req.GetAsyncOperationId(),
+		State: operationv1.AsyncOperation_STATE_FULFILLED,
+	}}, nil
+}
+
+func (s *fakeCloudService) GetUsers(ctx context.Context, req *cloudservicev1.GetUsersRequest) (*cloudservicev1.GetUsersResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.usersErr != nil {
+		return nil, s.usersErr
+	}
+	resp := &cloudservicev1.GetUsersResponse{}
+	for _, id := range slices.Sorted(maps.Keys(s.users)) {
+		if u := s.users[id]; req.GetEmail() == "" || u.GetSpec().GetEmail() == req.GetEmail() {
+			resp.Users = append(resp.Users, u)
+		}
+	}
+	return resp, nil
+}
+
+func (s *fakeCloudService) GetUser(ctx context.Context, req *cloudservicev1.GetUserRequest) (*cloudservicev1.GetUserResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[req.GetUserId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &cloudservicev1.GetUserResponse{User: u}, nil
+}
+
+func (s *fakeCloudService) CreateUser(ctx context.Context, req *cloudservicev1.CreateUserRequest) (*cloudservicev1.CreateUserResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.newID("u")
+	s.users[id] = &identityv1.User{Id: id, ResourceVersion: "1", Spec: req.GetSpec()}
+	return &cloudservicev1.CreateUserResponse{UserId: id, AsyncOperation: s.operation()}, nil
+}
+
+func (s *fakeCloudService) UpdateUser(ctx context.Context, req *cloudservicev1.UpdateUserRequest) (*cloudservicev1.UpdateUserResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[req.GetUserId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if u.GetResourceVersion() != req.GetResourceVersion() {
+		return nil, status.Error(codes.FailedPrecondition, "stale resource version")
+	}
+	s.users[u.GetId()] = &identityv1.User{Id: u.GetId(), ResourceVersion: u.GetResourceVersion() + "+", Spec: req.GetSpec()}
+	return &cloudservicev1.UpdateUserResponse{AsyncOperation: s.operation()}, nil
+}
+
+func (s *fakeCloudService) DeleteUser(ctx context.Context, req *cloudservicev1.DeleteUserRequest) (*cloudservicev1.DeleteUserResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.users[req.GetUserId()]; !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	delete(s.users, req.GetUserId())
+	return &cloudservicev1.DeleteUserResponse{AsyncOperation: s.operation()}, nil
+}
+
+func (s *fakeCloudService) GetUserGroups(ctx context.Context, req *cloudservicev1.GetUserGroupsRequest) (*cloudservicev1.GetUserGroupsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := &cloudservicev1.GetUserGroupsResponse{}
+	for _, id := range slices.Sorted(maps.Keys(s.groups)) {
+		resp.Groups = append(resp.Groups, s.groups[id])
+	}
+	return resp, nil
+}
+
+func (s *fakeCloudService) GetUserGroup(ctx context.Context, req *cloudservicev1.GetUserGroupRequest) (*cloudservicev1.GetUserGroupResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, ok := s.groups[req.GetGroupId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "group not found")
+	}
+	return &cloudservicev1.GetUserGroupResponse{Group: g}, nil
+}
+
+func (s *fakeCloudService) CreateUserGroup(ctx context.Context, req *cloudservicev1.CreateUserGroupRequest) (*cloudservicev1.CreateUserGroupResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.newID("g")
+	s.groups[id] = &identityv1.UserGroup{Id: id, ResourceVersion: "1", Spec: req.GetSpec()}
+	return &cloudservicev1.CreateUserGroupResponse{GroupId: id, AsyncOperation: s.operation()}, nil
+}
+
+func (s *fakeCloudService) UpdateUserGroup(ctx context.Context, req *cloudservicev1.UpdateUserGroupRequest) (*cloudservicev1.UpdateUserGroupResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, ok := s.groups[req.GetGroupId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "group not found")
+	}
+	if g.GetResourceVersion() != req.GetResourceVersion() {
+		return nil, status.Error(codes.FailedPrecondition, "stale resource version")
+	}
+	s.groups[g.GetId()] = &identityv1.UserGroup{Id: g.GetId(), ResourceVersion: g.GetResourceVersion() + "+", Spec: req.GetSpec()}
+	return &cloudservicev1.UpdateUserGroupResponse{AsyncOperation: s.operation()}, nil
+}
+
+func (s *fakeCloudService) DeleteUserGroup(ctx context.Context, req *cloudservicev1.DeleteUserGroupRequest) (*cloudservicev1.DeleteUserGroupResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.groups, req.GetGroupId())
+	delete(s.members, req.GetGroupId())
+	return &cloudservicev1.DeleteUserGroupResponse{AsyncOperation: s.operation()}, nil
+}
+
+func (s *fakeCloudService) GetUserGroupMembers(ctx context.Context, req *cloudservicev1.GetUserGroupMembersRequest) (*cloudservicev1.GetUserGroupMembersResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := &cloudservicev1.GetUserGroupMembersResponse{}
+	for _, id := range s.members[req.GetGroupId()] {
+		resp.Members = append(resp.Members, &identityv1.UserGroupMember{
+			MemberId: &identityv1.UserGroupMemberId{MemberType: &identityv1.UserGroupMemberId_UserId{UserId: id}},
+		})
+	}
+	return resp, nil
+}
+
+func (s *fakeCloudService) AddUserGroupMember(ctx context.Context, req *cloudservicev1.AddUserGroupMemberRequest) (*cloudservicev1.AddUserGroupMemberResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := req.GetMemberId().GetUserId()
+	if _, ok := s.users[id]; !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	s.members[req.GetGroupId()] = append(s.members[req.GetGroupId()], id)
+	return &cloudservicev1.AddUserGroupMemberResponse{AsyncOperation: s.operation()}, nil
+}
+
+func (s *fakeCloudService) RemoveUserGroupMember(ctx context.Context, req *cloudservicev1.RemoveUserGroupMemberRequest) (*cloudservicev1.RemoveUserGroupMemberResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.members[req.GetGroupId()] = slices.DeleteFunc(s.members[req.GetGroupId()], func(id string) bool {
+		return id == req.GetMemberId().GetUserId()
+	})
+	return &cloudservicev1.RemoveUserGroupMemberResponse{AsyncOperation: s.operation()}, nil
+}
+
+// startFakeCloudService serves an empty account on a local port, and returns a client connected to it.
+func startFakeCloudService(t *testing.T) (*fakeCloudService, *cloudclient.Client) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	fake := &fakeCloudService{
+		users:   map[string]*identityv1.User{},
+		groups:  map[string]*identityv1.UserGroup{},
+		members: map[string][]string{},
+	}
+	server := grpc.NewServer()
+	cloudservicev1.RegisterCloudServiceServer(server, fake)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	client, err := cloudclient.New(cloudclient.Options{
+		APIKey:        "test-key",
+		HostPort:      listener.Addr().String(),
+		AllowInsecure: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create the client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return fake, client
+}
+
+func role(r identityv1.AccountAccess_Role) *identityv1.Access {
+	return &identityv1.Access{AccountAccess: &identityv1.AccountAccess{Role: r}}
+}
+
+type scimClient struct {
+	t      *testing.T
+	server *httptest.Server
+}
+
+// do sends a request with a JSON body, if not nil, and decodes the JSON response, if any.
+func (c scimClient) do(method, path string, body any) (int, map[string]any) {
+	c.t.Helper()
+	var reader *strings.Reader
+	if s, ok := body.(string); ok {
+		reader = strings.NewReader(s)
+	} else {
+		data, err := json.Marshal(body)
+		if err != nil {
+			c.t.Fatal(err)
+		}
+		reader = strings.NewReader(string(data))
+	}
+	req, err := http.NewRequest(method, c.server.URL+path, reader)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Content-Type", contentType)
+	resp, err := c.server.Client().Do(req)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var result map[string]any
+	if resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			c.t.Fatalf("%s %s: failed to decode the response: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode, result
+}
+
+func TestServer(t *testing.T) {
+	fake, client := startFakeCloudService(t)
+	var internalErrs []error
+	bridge, err := New(client, Options{
+		OnError: func(err error) { internalErrs = append(internalErrs, err) },
+		Token:   "secret",
+		BaseURL: "https://scim.example.com/v2/",
+		UserAccess: AccessMapping{
+			Rules: []AccessRule{
+				{Filter: `roles[value eq "admin"]`, Access: role(identityv1.AccountAccess_ROLE_ADMIN)},
+				{Filter: enterpriseSchema + `:department eq "Engineering" or title sw "SRE"`, Access: role(identityv1.AccountAccess_ROLE_DEVELOPER)},
+			},
+		},
+		GroupAccess: AccessMapping{Default: role(identityv1.AccountAccess_ROLE_READ)},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	server := httptest.NewServer(bridge)
+	defer server.Close()
+	scim := scimClient{t: t, server: server}
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		resp, err := server.Client().Get(server.URL + "/Users")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("GET /Users status = %d, want 401", resp.StatusCode)
+		}
+	})
+
+	var aliceID, bobID string
+	t.Run("Create Users", func(t *testing.T) {
+		code, alice := scim.do("POST", "/Users", map[string]any{
+			"schemas":        []string{UserSchema, enterpriseSchema},
+			"userName":       "alice@example.com",
+			"active":         true,
+			enterpriseSchema: map[string]any{"department": "engineering"},
+		})
+		if code != http.StatusCreated || alice["userName"] != "alice@example.com" ||
+			alice["meta"].(map[string]any)["location"] != "https://scim.example.com/v2/Users/"+alice["id"].(string) {
+			t.Fatalf("POST /Users = %d %v", code, alice)
+		}
+		aliceID = alice["id"].(string)
+		if got := fake.users[aliceID].GetSpec().GetAccess(); !proto.Equal(got, role(identityv1.AccountAccess_ROLE_DEVELOPER)) {
+			t.Errorf("POST /Users access = %v, want developer", got)
+		}
+
+		code, bob := scim.do("POST", "/Users", map[string]any{
+			"userName": "bob",
+			"emails":   []any{map[string]any{"value": "bob@home.com"}, map[string]any{"value": "bob@example.com", "primary": true}},
+		})
+		if code != http.StatusCreated || bob["userName"] != "bob@example.com" {
+			t.Fatalf("POST /Users = %d %v", code, bob)
+		}
+		bobID = bob["id"].(string)
+		if got := fake.users[bobID].GetSpec().GetAccess(); !proto.Equal(got, role(identityv1.AccountAccess_ROLE_READ)) {
+			t.Errorf("POST /Users access = %v, want read", got)
+		}
+
+		code, conflict := scim.do("POST", "/Users", map[string]any{"userName": "alice@example.com"})
+		if code != http.StatusConflict || conflict["scimType"] != "uniqueness" || conflict["status"] != "409" {
+			t.Errorf("POST /Users = %d %v, want a uniqueness conflict", code, conflict)
+		}
+	})
+
+	t.Run("List Users", func(t *testing.T) {
+		code, list := scim.do("GET", `/Users?filter=userName+eq+%22ALICE@example.com%22`, nil)
+		if code != http.StatusOK || list["totalResults"] != 1.0 || list["Resources"].([]any)[0].(map[string]any)["id"] != aliceID {
+			t.Errorf("GET /Users = %d %v", code, list)
+		}
+		code, list = scim.do("GET", "/Users?startIndex=2&count=5&attributes=userName", nil)
+		resources := list["Resources"].([]any)
+		if code != http.StatusOK || list["totalResults"] != 2.0 || len(resources) != 1 ||
+			resources[0].(map[string]any)["emails"] != nil || resources[0].(map[string]any)["userName"] != "bob@example.com" {
+			t.Errorf("GET /Users = %d %v", code, list)
+		}
+		code, list = scim.do("GET", "/Users?startIndex=9223372036854775807&count=5", nil)
+		if code != http.StatusOK || list["totalResults"] != 2.0 || len(list["Resources"].([]any)) != 0 {
+			t.Errorf("GET /Users = %d %v, want an empty page", code, list)
+		}
+		code, invalid := scim.do("GET", `/Users?filter=userName+eq`, nil)
+		if code != http.StatusBadRequest || invalid["scimType"] != "invalidFilter" {
+			t.Errorf("GET /Users = %d %v, want an invalid filter", code, invalid)
+		}
+	})
+
+	t.Run("Internal Error", func(t *testing.T) {
+		fake.mu.Lock()
+		fake.usersErr = status.Error(codes.Internal, "database at 10.0.0.1 unreachable")
+		fake.mu.Unlock()
+		defer func() {
+			fake.mu.Lock()
+			fake.usersErr = nil
+			fake.mu.Unlock()
+		}()
+		code, resp := scim.do("GET", "/Users", nil)
+		if code != http.StatusInternalServerError || resp["detail"] != "internal error" {
+			t.Errorf("GET /Users = %d %v, want a generic internal error", code, resp)
+		}
+		if len(internalErrs) != 1 || !strings.Contains(internalErrs[0].Error(), "10.0.0.1") {
+			t.Errorf("OnError() called with %v, want the error of the cloud operations API", internalErrs)
+		}
+	})
+
+	t.Run("Patch User", func(t *testing.T) {
+		code, _ := scim.do("PATCH", "/Users/"+bobID, map[string]any{
+			"schemas":    []string{PatchOpSchema},
+			"Operations": []any{map[string]any{"op": "add", "path": "roles", "value": []any{map[string]any{"value": "admin"}}}},
+		})
+		if got := fake.users[bobID].GetSpec().GetAccess(); code != http.StatusOK || !proto.Equal(got, role(identityv1.AccountAccess_ROLE_ADMIN)) {
+			t.Errorf("PATCH /Users = %d, access = %v, want admin", code, got)
+		}
+		// An attribute not referenced by the rules leaves the access unchanged.
+		code, _ = scim.do("PATCH", "/Users/"+bobID, map[string]any{
+			"schemas":    []string{PatchOpSchema},
+			"Operations": []any{map[string]any{"op": "replace", "value": map[string]any{"displayName": "Bob"}}},
+		})
+		if got := fake.users[bobID].GetSpec().GetAccess(); code != http.StatusOK || !proto.Equal(got, role(identityv1.AccountAccess_ROLE_ADMIN)) {
+			t.Errorf("PATCH /Users = %d, access = %v, want admin", code, got)
+		}
+		// The roles the user was granted admin by are not carried by a PATCH of its title, which leaves the
+		// access unchanged rather than evaluating the rules without them.
+		code, _ = scim.do("PATCH", "/Users/"+bobID, map[string]any{
+			"schemas":    []string{PatchOpSchema},
+			"Operations": []any{map[string]any{"op": "replace", "path": "title", "value": "Engineer"}},
+		})
+		if got := fake.users[bobID].GetSpec().GetAccess(); code != http.StatusOK || !proto.Equal(got, role(identityv1.AccountAccess_ROLE_ADMIN)) {
+			t.Errorf("PATCH /Users = %d, access = %v, want admin", code, got)
+		}
+	})
+
+	t.Run("Replace User", func(t *testing.T) {
+		// The roles the user was granted admin by are not carried by the PUT, which leaves the access unchanged.
+		code, _ := scim.do("PUT", "/Users/"+bobID, map[string]any{"userName": "bob@example.com", "title": "Engineer"})
+		if got := fake.users[bobID].GetSpec().GetAccess(); code != http.StatusOK || !proto.Equal(got, role(identityv1.AccountAccess_ROLE_ADMIN)) {
+			t.Errorf("PUT /Users = %d, access = %v, want admin", code, got)
+		}
+		// The account role of an admin is not lowered by the mapping.
+		code, _ = scim.do("PUT", "/Users/"+bobID, map[string]any{
+			"userName":       "bob@example.com",
+			"title":          "Engineer",
+			"roles":          []any{},
+			enterpriseSchema: map[string]any{"department": "Sales"},
+		})
+		if got := fake.users[bobID].GetSpec().GetAccess(); code != http.StatusOK || !proto.Equal(got, role(identityv1.AccountAccess_ROLE_ADMIN)) {
+			t.Errorf("PUT /Users = %d, access = %v, want admin", code, got)
+		}
+		// The one of other users is.
+		code, _ = scim.do("PUT", "/Users/"+aliceID, map[string]any{
+			"userName":       "alice@example.com",
+			"title":          "Manager",
+			"roles":          []any{},
+			enterpriseSchema: map[string]any{"department": "Sales"},
+		})
+		if got := fake.users[aliceID].GetSpec().GetAccess(); code != http.StatusOK || !proto.Equal(got, role(identityv1.AccountAccess_ROLE_READ)) {
+			t.Errorf("PUT /Users = %d, access = %v, want read", code, got)
+		}
+	})
+
+	var groupID string
+	t.Run("Groups", func(t *testing.T) {
+		code, group := scim.do("POST", "/Groups", map[string]any{
+			"displayName": "developers",
+			"members":     []any{map[string]any{"value": aliceID}},
+		})
+		if code != http.StatusCreated || len(group["members"].([]any)) != 1 {
+			t.Fatalf("POST /Groups = %d %v", code, group)
+		}
+		groupID = group["id"].(string)
+		if g := fake.groups[groupID]; g.GetSpec().GetCloudGroup() == nil || !proto.Equal(g.GetSpec().GetAccess(), role(identityv1.AccountAccess_ROLE_READ)) {
+			t.Errorf("POST /Groups spec = %v", g.GetSpec())
+		}
+
+		code, group = scim.do("PATCH", "/Groups/"+groupID, map[string]any{
+			"schemas": []string{PatchOpSchema},
+			"Operations": []any{
+				map[string]any{"op": "Add", "path": "members", "value": []any{map[string]any{"value": bobID}}},
+				map[string]any{"op": "Remove", "path": fmt.Sprintf(`members[value eq "%s"]`, aliceID)},
+				map[string]any{"op": "Replace", "path": "displayName", "value": "engineers"},
+			},
+		})
+		if code != http.StatusOK || group["displayName"] != "engineers" || !slices.Equal(fake.members[groupID], []string{bobID}) {
+			t.Errorf("PATCH /Groups = %d %v, members = %v", code, group, fake.members[groupID])
+		}
+
+		code, group = scim.do("PUT", "/Groups/"+groupID, map[string]any{
+			"displayName": "engineers",
+			"members":     []any{map[string]any{"value": aliceID}, map[string]any{"value": bobID}},
+		})
+		if code != http.StatusOK || len(fake.members[groupID]) != 2 {
+			t.Errorf("PUT /Groups = %d %v, members = %v", code, group, fake.members[groupID])
+		}
+
+		code, list := scim.do("GET", "/Groups?excludedAttributes=members&filter=displayName+eq+%22engineers%22", nil)
+		resources := list["Resources"].([]any)
+		if code != http.StatusOK || len(resources) != 1 || resources[0].(map[string]any)["members"] != nil {
+			t.Errorf("GET /Groups = %d %v", code, list)
+		}
+	})
+
+	t.Run("Deactivate User", func(t *testing.T) {
+		code, user := scim.do("PATCH", "/Users/"+aliceID, map[string]any{
+			"schemas":    []string{PatchOpSchema},
+			"Operations": []any{map[string]any{"op": "Replace", "path": "active", "value": "False"}},
+		})
+		if _, ok := fake.users[aliceID]; code != http.StatusOK || user["active"] != false || ok {
+			t.Errorf("PATCH /Users = %d %v, want the user deleted", code, user)
+		}
+		code, notFound := scim.do("GET", "/Users/"+aliceID, nil)
+		if code != http.StatusNotFound || notFound["status"] != "404" {
+			t.Errorf("GET /Users = %d %v, want 404", code, notFound)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if code, _ := scim.do("DELETE", "/Groups/"+groupID, nil); code != http.StatusNoContent || fake.groups[groupID] != nil {
+			t.Errorf("DELETE /Groups = %d", code)
+		}
+		if code, _ := scim.do("DELETE", "/Users/"+bobID, nil); code != http.StatusNoContent || fake.users[bobID] != nil {
+			t.Errorf("DELETE /Users = %d", code)
+		}
+	})
+
+	t.Run("Service Provider Config", func(t *testing.T) {
+		code, config := scim.do("GET", "/ServiceProviderConfig", nil)
+		if code != http.StatusOK || config["patch"].(map[string]any)["supported"] != true {
+			t.Errorf("GET /ServiceProviderConfig = %d %v", code, config)
+		}
+	})
+}
+
+func TestNew(t *testing.T) {
+	_, client := startFakeCloudService(t)
+	if _, err := New(client, Options{}); err == nil {
+		t.Error("New() without a token succeeded, want an error")
+	}
+	if _, err := New(client, Options{AllowUnauthenticated: true}); err != nil {
+		t.Errorf("New() with AllowUnauthenticated error = %v", err)
+	}
+	if _, err := New(client, Options{Token: "secret", UserAccess: AccessMapping{Rules: []AccessRule{{Filter: "title eq"}}}}); err == nil {
+		t.Error("New() with an invalid filter succeeded, want an error")
+	}
+}
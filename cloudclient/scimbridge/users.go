@@ -0,0 +1,203 @@
+package scimbridge
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	"go.temporal.io/cloud-sdk/cloudclient"
+	"google.golang.org/protobuf/proto"
+)
+
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := s.client.Users().List(r.Context())
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	resources := make([]map[string]any, 0, len(users))
+	for _, u := range users {
+		resources = append(resources, s.userResource(u))
+	}
+	s.writeList(w, r, resources)
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	user, err := s.client.Users().Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	writeResource(w, r, http.StatusOK, s.userResource(user))
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var resource map[string]any
+	if err := readResource(r, &resource); err != nil {
+		s.error(w, err)
+		return
+	}
+	email, err := emailOf(resource)
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	if !isActive(resource) {
+		s.error(w, badRequest("invalidValue", "an inactive user cannot be created"))
+		return
+	}
+	_, err = s.client.Users().GetByEmail(ctx, email)
+	switch {
+	case err == nil:
+		s.error(w, &scimError{status: http.StatusConflict, scimType: "uniqueness", detail: "a user with email " + email + " already exists"})
+		return
+	case !errors.Is(err, cloudclient.ErrNotFound):
+		s.error(w, err)
+		return
+	}
+
+	id, op, err := s.client.Users().Create(ctx, &identityv1.UserSpec{
+		Email:  email,
+		Access: s.options.UserAccess.access(s.userRules, resource),
+	})
+	if err == nil {
+		err = op.Wait(ctx)
+	}
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	user, err := s.client.Users().Get(ctx, id)
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	writeResource(w, r, http.StatusCreated, s.userResource(user))
+}
+
+func (s *Server) replaceUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var resource map[string]any
+	if err := readResource(r, &resource); err != nil {
+		s.error(w, err)
+		return
+	}
+	user, err := s.client.Users().Get(ctx, r.PathValue("id"))
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	s.convergeUser(ctx, w, r, user, resource, replaceUpdatesAccess(s.userRules, resource))
+}
+
+func (s *Server) patchUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var patch patchRequest
+	if err := readResource(r, &patch); err != nil {
+		s.error(w, err)
+		return
+	}
+	user, err := s.client.Users().Get(ctx, r.PathValue("id"))
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	resource := s.userResource(user)
+	known := slices.Collect(maps.Keys(resource))
+	changed, err := applyPatch(resource, patch.Operations)
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	s.convergeUser(ctx, w, r, user, resource, patchUpdatesAccess(s.userRules, resource, known, changed))
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	op, err := s.client.Users().Delete(r.Context(), r.PathValue("id"), "")
+	if err == nil {
+		err = op.Wait(r.Context())
+	}
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// convergeUser updates the user to match the resource, or deletes it if the resource is inactive, and writes the
+// resulting resource. The access of the user is only computed from the resource if updateAccess is set.
+func (s *Server) convergeUser(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	user *identityv1.User,
+	resource map[string]any,
+	updateAccess bool,
+) {
+	if !isActive(resource) {
+		op, err := s.client.Users().Delete(ctx, user.GetId(), user.GetResourceVersion())
+		if err == nil {
+			err = op.Wait(ctx)
+		}
+		if err != nil {
+			s.error(w, err)
+			return
+		}
+		deleted := s.userResource(user)
+		deleted["active"] = false
+		writeResource(w, r, http.StatusOK, deleted)
+		return
+	}
+
+	email, err := emailOf(resource)
+	if err != nil {
+		s.error(w, err)
+		return
+	}
+	spec := proto.Clone(user.GetSpec()).(*identityv1.UserSpec)
+	spec.Email = email
+	if updateAccess {
+		spec.Access = s.options.UserAccess.access(s.userRules, resource)
+		current := user.GetSpec().GetAccess().GetAccountAccess()
+		if !s.options.LowerAdminRoles && lowersAdminRole(current.GetRole(), spec.GetAccess().GetAccountAccess().GetRole()) {
+			// The mapped access is shared by the users of its rule, it is cloned before keeping the account role.
+			spec.Access = proto.Clone(spec.GetAccess()).(*identityv1.Access)
+			spec.Access.AccountAccess = proto.Clone(current).(*identityv1.AccountAccess)
+		}
+	}
+	if !proto.Equal(spec, user.GetSpec()) {
+		op, err := s.client.Users().Update(ctx, &identityv1.User{
+			Id:              user.GetId(),
+			ResourceVersion: user.GetResourceVersion(),
+			Spec:            spec,
+		})
+		if err == nil {
+			err = op.Wait(ctx)
+		}
+		if err == nil {
+			user, err = s.client.Users().Get(ctx, user.GetId())
+		}
+		if err != nil {
+			s.error(w, err)
+			return
+		}
+	}
+	writeResource(w, r, http.StatusOK, s.userResource(user))
+}
+
+// lowersAdminRole reports whether replacing the current account role of a user by the mapped one lowers the role of
+// an owner or of an admin.
+func lowersAdminRole(current, mapped identityv1.AccountAccess_Role) bool {
+	switch current {
+	case identityv1.AccountAccess_ROLE_OWNER:
+		return mapped != identityv1.AccountAccess_ROLE_OWNER
+	case identityv1.AccountAccess_ROLE_ADMIN:
+		return mapped != identityv1.AccountAccess_ROLE_OWNER && mapped != identityv1.AccountAccess_ROLE_ADMIN
+	default:
+		return false
+	}
+}
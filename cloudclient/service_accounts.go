@@ -0,0 +1,112 @@
+package cloudclient
+
+import (
+	"context"
+
+	cloudservice "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identity "go.temporal.io/cloud-sdk/api/identity/v1"
+	"go.temporal.io/cloud-sdk/internal/paging"
+)
+
+type (
+	// ServiceAccountClient to manage the service accounts of the account.
+	ServiceAccountClient interface {
+		// Get returns the service account with the given id.
+		Get(ctx context.Context, id string) (*identity.ServiceAccount, error)
+		// List returns every service account of the account.
+		List(ctx context.Context) ([]*identity.ServiceAccount, error)
+		// Create starts the creation of a service account and returns its id.
+		Create(ctx context.Context, spec *identity.ServiceAccountSpec) (string, *AsyncOperation, error)
+		// Update replaces the spec of a service account with sa.Spec.
+		// The update is rejected if the service account changed since it was retrieved, see sa.ResourceVersion.
+		Update(ctx context.Context, sa *identity.ServiceAccount) (*AsyncOperation, error)
+		// SetNamespaceAccess sets the access of a service account to a namespace, a nil access removes it.
+		// If resourceVersion is empty, the latest version of the service account is updated.
+		SetNamespaceAccess(ctx context.Context, id string, namespace string, access *identity.NamespaceAccess, resourceVersion string) (*AsyncOperation, error)
+		// Delete deletes a service account.
+		// If resourceVersion is empty, the latest version of the service account is deleted.
+		Delete(ctx context.Context, id string, resourceVersion string) (*AsyncOperation, error)
+	}
+
+	serviceAccountClient struct {
+		client cloudservice.CloudServiceClient
+	}
+)
+
+func (c serviceAccountClient) Get(ctx context.Context, id string) (*identity.ServiceAccount, error) {
+	resp, err := c.client.GetServiceAccount(ctx, &cloudservice.GetServiceAccountRequest{
+		ServiceAccountId: id,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return resp.GetServiceAccount(), nil
+}
+
+func (c serviceAccountClient) List(ctx context.Context) ([]*identity.ServiceAccount, error) {
+	serviceAccounts, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identity.ServiceAccount, string, error) {
+		resp, err := c.client.GetServiceAccounts(ctx, &cloudservice.GetServiceAccountsRequest{PageToken: pageToken})
+		return resp.GetServiceAccount(), resp.GetNextPageToken(), err
+	})
+	return serviceAccounts, toAPIError(err)
+}
+
+func (c serviceAccountClient) Create(ctx context.Context, spec *identity.ServiceAccountSpec) (string, *AsyncOperation, error) {
+	resp, err := c.client.CreateServiceAccount(ctx, &cloudservice.CreateServiceAccountRequest{
+		Spec: spec,
+	})
+	if err != nil {
+		return "", nil, toAPIError(err)
+	}
+	return resp.GetServiceAccountId(), newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c serviceAccountClient) Update(ctx context.Context, sa *identity.ServiceAccount) (*AsyncOperation, error) {
+	resp, err := c.client.UpdateServiceAccount(ctx, &cloudservice.UpdateServiceAccountRequest{
+		ServiceAccountId: sa.GetId(),
+		Spec:             sa.GetSpec(),
+		ResourceVersion:  sa.GetResourceVersion(),
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c serviceAccountClient) SetNamespaceAccess(ctx context.Context, id string, namespace string, access *identity.NamespaceAccess, resourceVersion string) (*AsyncOperation, error) {
+	if resourceVersion == "" {
+		sa, err := c.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		resourceVersion = sa.GetResourceVersion()
+	}
+	resp, err := c.client.SetServiceAccountNamespaceAccess(ctx, &cloudservice.SetServiceAccountNamespaceAccessRequest{
+		ServiceAccountId: id,
+		Namespace:        namespace,
+		Access:           access,
+		ResourceVersion:  resourceVersion,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c serviceAccountClient) Delete(ctx context.Context, id string, resourceVersion string) (*AsyncOperation, error) {
+	if resourceVersion == "" {
+		sa, err := c.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		resourceVersion = sa.GetResourceVersion()
+	}
+	resp, err := c.client.DeleteServiceAccount(ctx, &cloudservice.DeleteServiceAccountRequest{
+		ServiceAccountId: id,
+		ResourceVersion:  resourceVersion,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
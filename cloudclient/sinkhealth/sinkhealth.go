@@ -0,0 +1,424 @@
+// Package sinkhealth monitors the audit log sinks of a Temporal Cloud account and the export sinks of all its
+// namespaces, and alerts on the sinks that are unhealthy or have not delivered data for too long.
+//
+// WARNING: The package is currently experimental.
+//
+// The health reported by the cloud operations API only tells that a sink is failing. To tell why, the monitor
+// validates the spec of the failing sinks with ValidateAccountAuditLogSink and ValidateNamespaceExportSink,
+// which test the delivery to the destination, and adds the validation error to the alert as a diagnosis.
+//
+// A check carries on when the export sinks of a namespace cannot be listed, or a sink cannot be validated: the
+// error is set on the status of the namespace, or of the sink, and the other sinks are still checked.
+package sinkhealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	accountv1 "go.temporal.io/cloud-sdk/api/account/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/notify"
+	"go.temporal.io/cloud-sdk/internal/paging"
+	"go.temporal.io/cloud-sdk/internal/rpcerrors"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultFreshnessThreshold is how long a sink can go without delivering data before it is stale,
+	// used when none is provided.
+	DefaultFreshnessThreshold = 6 * time.Hour
+
+	// DefaultPollInterval is the interval between two checks of Monitor.Run, used when none is provided.
+	DefaultPollInterval = 10 * time.Minute
+
+	notificationSource = "sinkhealth"
+)
+
+// The kinds of sinks.
+const (
+	KindAuditLogSink Kind = "audit_log_sink"
+	KindExportSink   Kind = "export_sink"
+)
+
+type (
+	// Kind is a kind of sink.
+	Kind string
+
+	// Status is the health of a sink, as of a check.
+	//
+	// When the export sinks of a namespace cannot be listed, the check returns a status of kind KindExportSink
+	// for the namespace, without a name, with the error.
+	Status struct {
+		Kind Kind
+		// The namespace of an export sink, empty for audit log sinks.
+		Namespace string
+		Name      string
+		Enabled   bool
+		// The health reported by the cloud operations API, such as OK or ERROR_USER_CONFIGURATION.
+		Health       string
+		ErrorMessage string
+		// The last time the sink delivered data, zero if it never did.
+		LastSucceededTime time.Time
+		// The last time the health of an export sink was checked by Temporal Cloud, zero for audit log sinks.
+		LastHealthCheckTime time.Time
+
+		// Whether the enabled sink is reported in error.
+		Unhealthy bool
+		// Whether the enabled sink has not delivered data within the freshness threshold.
+		Stale bool
+		// The validation error of the spec of the sink, when it was validated, empty if the validation passed.
+		Diagnosis string
+		// Whether the spec of the sink was validated.
+		Validated bool
+		// The error that prevented the check of the sink, or of the export sinks of the namespace: the listing of
+		// the export sinks failed, or the validation could not be run, such as when the API was unavailable.
+		Err error
+
+		auditLogSink *accountv1.AuditLogSink
+		exportSink   *namespacev1.ExportSink
+	}
+
+	// Options to configure a monitor.
+	Options struct {
+		// How long an enabled sink can go without delivering data before it is stale.
+		// A sink that never delivered data is not stale, since it may have just been created.
+		// If not provided, DefaultFreshnessThreshold is used.
+		FreshnessThreshold time.Duration
+
+		// Whether to skip the validation of the unhealthy and stale sinks.
+		SkipValidation bool
+
+		// Where to send the alerts of Monitor.Run.
+		// If not provided, the alerts are not sent.
+		Notifier notify.Notifier
+
+		// The interval between two checks of Monitor.Run.
+		// If not provided, DefaultPollInterval is used.
+		PollInterval time.Duration
+
+		// Called when a check of Monitor.Run fails, partially or fully, or an alert cannot be sent.
+		// Monitor.Run carries on.
+		// If not provided, errors are ignored.
+		OnError func(error)
+	}
+
+	// Monitor checks the health of the sinks of an account.
+	Monitor struct {
+		client  cloudservicev1.CloudServiceClient
+		options Options
+		now     func() time.Time
+
+		mu sync.Mutex
+		// the problem last alerted on, by sink, for Run to only alert on changes
+		alerted map[string]string
+	}
+)
+
+// NewMonitor creates a monitor.
+func NewMonitor(client cloudservicev1.CloudServiceClient, options Options) *Monitor {
+	if options.FreshnessThreshold <= 0 {
+		options.FreshnessThreshold = DefaultFreshnessThreshold
+	}
+	if options.PollInterval <= 0 {
+		options.PollInterval = DefaultPollInterval
+	}
+	return &Monitor{
+		client:  client,
+		options: options,
+		now:     time.Now,
+		alerted: map[string]string{},
+	}
+}
+
+// Check returns the status of every audit log sink of the account and every export sink of its namespaces.
+// The unhealthy and stale sinks are validated, unless Options.SkipValidation is set.
+//
+// An error is only returned if the audit log sinks or the namespaces cannot be listed, or ctx is done. The errors
+// listing the export sinks of a namespace, or validating a sink, are set on the statuses, see Status.Err.
+func (m *Monitor) Check(ctx context.Context) ([]Status, error) {
+	auditLogSinks, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*accountv1.AuditLogSink, string, error) {
+		resp, err := m.client.GetAccountAuditLogSinks(ctx, &cloudservicev1.GetAccountAuditLogSinksRequest{
+			PageToken: pageToken,
+		})
+		return resp.GetSinks(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the audit log sinks: %w", err)
+	}
+	namespaces, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*namespacev1.Namespace, string, error) {
+		resp, err := m.client.GetNamespaces(ctx, &cloudservicev1.GetNamespacesRequest{
+			PageToken: pageToken,
+		})
+		return resp.GetNamespaces(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the namespaces: %w", err)
+	}
+
+	now := m.now()
+	var statuses []Status
+	for _, sink := range auditLogSinks {
+		statuses = append(statuses, m.auditLogSinkStatus(sink, now))
+	}
+	for _, ns := range namespaces {
+		sinks, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*namespacev1.ExportSink, string, error) {
+			resp, err := m.client.GetNamespaceExportSinks(ctx, &cloudservicev1.GetNamespaceExportSinksRequest{
+				Namespace: ns.GetNamespace(),
+				PageToken: pageToken,
+			})
+			return resp.GetSinks(), resp.GetNextPageToken(), err
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			statuses = append(statuses, Status{
+				Kind:      KindExportSink,
+				Namespace: ns.GetNamespace(),
+				Err:       fmt.Errorf("failed to list the export sinks of namespace %q: %w", ns.GetNamespace(), err),
+			})
+			continue
+		}
+		for _, sink := range sinks {
+			statuses = append(statuses, m.exportSinkStatus(ns.GetNamespace(), sink, now))
+		}
+	}
+
+	if !m.options.SkipValidation {
+		for i := range statuses {
+			if !statuses[i].Unhealthy && !statuses[i].Stale {
+				continue
+			}
+			if err := m.Validate(ctx, &statuses[i]); err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				statuses[i].Err = err
+			}
+		}
+	}
+	return statuses, nil
+}
+
+// Validate validates the spec of the sink and records the validation error as the diagnosis of the status.
+// An error is only returned if the validation could not be run, such as when the API is unavailable or the
+// deadline is exceeded, in which case the status is left unchanged.
+func (m *Monitor) Validate(ctx context.Context, s *Status) error {
+	var err error
+	switch s.Kind {
+	case KindAuditLogSink:
+		_, err = m.client.ValidateAccountAuditLogSink(ctx, &cloudservicev1.ValidateAccountAuditLogSinkRequest{
+			Spec: s.auditLogSink.GetSpec(),
+		})
+	case KindExportSink:
+		_, err = m.client.ValidateNamespaceExportSink(ctx, &cloudservicev1.ValidateNamespaceExportSinkRequest{
+			Namespace: s.Namespace,
+			Spec:      s.exportSink.GetSpec(),
+		})
+	default:
+		return fmt.Errorf("unknown sink kind %q", s.Kind)
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if rpcerrors.IsTransport(err) {
+		return fmt.Errorf("failed to validate %s: %w", s, err)
+	}
+	s.Validated = true
+	s.Diagnosis = ""
+	if err != nil {
+		s.Diagnosis = status.Convert(err).Message()
+	}
+	return nil
+}
+
+// Run checks the sinks every poll interval until ctx is done, and sends an alert when a sink becomes unhealthy or
+// stale, when its problem changes, and when it recovers.
+func (m *Monitor) Run(ctx context.Context) error {
+	for {
+		if err := m.checkAndNotify(ctx); err != nil && m.options.OnError != nil && ctx.Err() == nil {
+			m.options.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(m.options.PollInterval):
+		}
+	}
+}
+
+func (m *Monitor) checkAndNotify(ctx context.Context) error {
+	statuses, err := m.Check(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var errs []error
+	seen := map[string]bool{}
+	// the namespaces whose export sinks could not be listed, their previous alerts are kept
+	unlisted := map[string]bool{}
+	for _, s := range statuses {
+		if s.Err != nil {
+			errs = append(errs, s.Err)
+		}
+		if s.Name == "" {
+			unlisted[s.Namespace] = true
+			continue
+		}
+		key := s.key()
+		seen[key] = true
+		problem := s.problem()
+		if problem == m.alerted[key] {
+			continue
+		}
+		n := s.Notification()
+		if problem == "" {
+			n.Title = fmt.Sprintf("%s recovered", s)
+		}
+		if m.options.Notifier != nil {
+			if err := m.options.Notifier.Notify(ctx, n); err != nil {
+				errs = append(errs, fmt.Errorf("failed to send the alert of %s: %w", s, err))
+				continue
+			}
+		}
+		m.alerted[key] = problem
+	}
+	for key := range m.alerted {
+		if rest, ok := strings.CutPrefix(key, string(KindExportSink)+"/"); ok {
+			if namespace, _, _ := strings.Cut(rest, "/"); unlisted[namespace] {
+				continue
+			}
+		}
+		if !seen[key] {
+			// the sink was deleted
+			delete(m.alerted, key)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// String returns the kind and the name of the sink, such as export sink prod.a1b2c/archive, or the export sinks
+// of the namespace for the status of a namespace whose export sinks could not be listed.
+func (s Status) String() string {
+	if s.Kind == KindExportSink && s.Name == "" {
+		return fmt.Sprintf("export sinks of namespace %s", s.Namespace)
+	}
+	if s.Kind == KindExportSink {
+		return fmt.Sprintf("export sink %s/%s", s.Namespace, s.Name)
+	}
+	return fmt.Sprintf("audit log sink %s", s.Name)
+}
+
+// Notification returns the alert of the sink.
+func (s Status) Notification() notify.Notification {
+	n := notify.Notification{
+		Source:   notificationSource,
+		Severity: notify.SeverityInfo,
+		Title:    fmt.Sprintf("%s is healthy", s),
+		Time:     s.LastSucceededTime,
+		Fields: map[string]string{
+			"kind":   string(s.Kind),
+			"name":   s.Name,
+			"health": s.Health,
+		},
+	}
+	if s.Namespace != "" {
+		n.Fields["namespace"] = s.Namespace
+	}
+	if problem := s.problem(); problem != "" {
+		n.Title = fmt.Sprintf("%s is %s", s, problem)
+		n.Severity = notify.SeverityWarning
+		if s.Unhealthy {
+			n.Severity = notify.SeverityCritical
+		}
+	}
+
+	var msg []string
+	if s.ErrorMessage != "" {
+		msg = append(msg, "Error: "+s.ErrorMessage)
+	}
+	if !s.LastSucceededTime.IsZero() {
+		msg = append(msg, "Last delivery: "+s.LastSucceededTime.UTC().Format(time.RFC3339))
+	}
+	if s.Validated {
+		diagnosis := s.Diagnosis
+		if diagnosis == "" {
+			diagnosis = "the validation of the sink passed"
+		}
+		msg = append(msg, "Diagnosis: "+diagnosis)
+	} else if s.Err != nil {
+		msg = append(msg, "Diagnosis unavailable: "+s.Err.Error())
+	}
+	n.Message = strings.Join(msg, "\n")
+	return n
+}
+
+// problem describes what is wrong with the sink, empty if nothing is.
+func (s Status) problem() string {
+	switch {
+	case s.Unhealthy:
+		return "unhealthy: " + s.Health
+	case s.Stale:
+		return "stale"
+	}
+	return ""
+}
+
+func (s Status) key() string {
+	return string(s.Kind) + "/" + s.Namespace + "/" + s.Name
+}
+
+func (m *Monitor) auditLogSinkStatus(sink *accountv1.AuditLogSink, now time.Time) Status {
+	s := Status{
+		Kind:         KindAuditLogSink,
+		Name:         sink.GetName(),
+		Enabled:      sink.GetSpec().GetEnabled(),
+		Health:       strings.TrimPrefix(sink.GetHealth().String(), "HEALTH_"),
+		ErrorMessage: sink.GetErrorMessage(),
+		auditLogSink: sink,
+	}
+	switch sink.GetHealth() {
+	case accountv1.AuditLogSink_HEALTH_ERROR_INTERNAL, accountv1.AuditLogSink_HEALTH_ERROR_USER_CONFIGURATION:
+		s.Unhealthy = s.Enabled
+	}
+	if t := sink.GetLastSucceededTime(); t != nil {
+		s.LastSucceededTime = t.AsTime()
+	}
+	s.Stale = m.stale(s, now)
+	return s
+}
+
+func (m *Monitor) exportSinkStatus(namespace string, sink *namespacev1.ExportSink, now time.Time) Status {
+	s := Status{
+		Kind:         KindExportSink,
+		Namespace:    namespace,
+		Name:         sink.GetName(),
+		Enabled:      sink.GetSpec().GetEnabled(),
+		Health:       strings.TrimPrefix(sink.GetHealth().String(), "HEALTH_"),
+		ErrorMessage: sink.GetErrorMessage(),
+		exportSink:   sink,
+	}
+	switch sink.GetHealth() {
+	case namespacev1.ExportSink_HEALTH_ERROR_INTERNAL, namespacev1.ExportSink_HEALTH_ERROR_USER_CONFIGURATION:
+		s.Unhealthy = s.Enabled
+	}
+	if t := sink.GetLatestDataExportTime(); t != nil {
+		s.LastSucceededTime = t.AsTime()
+	}
+	if t := sink.GetLastHealthCheckTime(); t != nil {
+		s.LastHealthCheckTime = t.AsTime()
+	}
+	s.Stale = m.stale(s, now)
+	return s
+}
+
+func (m *Monitor) stale(s Status, now time.Time) bool {
+	return s.Enabled && !s.LastSucceededTime.IsZero() && now.Sub(s.LastSucceededTime) > m.options.FreshnessThreshold
+}
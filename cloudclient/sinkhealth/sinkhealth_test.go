@@ -0,0 +1,233 @@
+package sinkhealth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	accountv1 "go.temporal.io/cloud-sdk/api/account/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/cloudservicemock"
+	"go.temporal.io/cloud-sdk/cloudclient/notify"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+type fakeCloudService struct {
+	auditLogSinks []*accountv1.AuditLogSink
+	exportSinks   map[string][]*namespacev1.ExportSink
+	validated     []string
+	// the errors listing the export sinks, by namespace
+	listErrs map[string]error
+	// the error of ValidateAccountAuditLogSink
+	validateErr error
+}
+
+func (s *fakeCloudService) GetAccountAuditLogSinks(ctx context.Context, req *cloudservicev1.GetAccountAuditLogSinksRequest, opts ...grpc.CallOption) (*cloudservicev1.GetAccountAuditLogSinksResponse, error) {
+	return &cloudservicev1.GetAccountAuditLogSinksResponse{Sinks: s.auditLogSinks}, nil
+}
+
+func (s *fakeCloudService) GetNamespaces(ctx context.Context, req *cloudservicev1.GetNamespacesRequest, opts ...grpc.CallOption) (*cloudservicev1.GetNamespacesResponse, error) {
+	resp := &cloudservicev1.GetNamespacesResponse{}
+	for _, ns := range []string{"dev.a1b2c", "prod.a1b2c"} {
+		resp.Namespaces = append(resp.Namespaces, &namespacev1.Namespace{Namespace: ns})
+	}
+	return resp, nil
+}
+
+func (s *fakeCloudService) GetNamespaceExportSinks(ctx context.Context, req *cloudservicev1.GetNamespaceExportSinksRequest, opts ...grpc.CallOption) (*cloudservicev1.GetNamespaceExportSinksResponse, error) {
+	if err := s.listErrs[req.GetNamespace()]; err != nil {
+		return nil, err
+	}
+	return &cloudservicev1.GetNamespaceExportSinksResponse{Sinks: s.exportSinks[req.GetNamespace()]}, nil
+}
+
+func (s *fakeCloudService) ValidateAccountAuditLogSink(ctx context.Context, req *cloudservicev1.ValidateAccountAuditLogSinkRequest, opts ...grpc.CallOption) (*cloudservicev1.ValidateAccountAuditLogSinkResponse, error) {
+	s.validated = append(s.validated, req.GetSpec().GetName())
+	if s.validateErr != nil {
+		return nil, s.validateErr
+	}
+	return &cloudservicev1.ValidateAccountAuditLogSinkResponse{}, nil
+}
+
+func (s *fakeCloudService) ValidateNamespaceExportSink(ctx context.Context, req *cloudservicev1.ValidateNamespaceExportSinkRequest, opts ...grpc.CallOption) (*cloudservicev1.ValidateNamespaceExportSinkResponse, error) {
+	s.validated = append(s.validated, req.GetNamespace()+"/"+req.GetSpec().GetName())
+	return nil, status.Error(codes.InvalidArgument, "access denied to bucket archive")
+}
+
+func newFake() *fakeCloudService {
+	return &fakeCloudService{
+		auditLogSinks: []*accountv1.AuditLogSink{
+			{
+				Name:              "kinesis",
+				Spec:              &accountv1.AuditLogSinkSpec{Name: "kinesis", Enabled: true},
+				Health:            accountv1.AuditLogSink_HEALTH_OK,
+				LastSucceededTime: timestamppb.New(now.Add(-7 * time.Hour)),
+			},
+		},
+		exportSinks: map[string][]*namespacev1.ExportSink{
+			"prod.a1b2c": {
+				{
+					Name:         "archive",
+					Spec:         &namespacev1.ExportSinkSpec{Name: "archive", Enabled: true},
+					Health:       namespacev1.ExportSink_HEALTH_ERROR_USER_CONFIGURATION,
+					ErrorMessage: "failed to write",
+				},
+				{
+					Name:                 "healthy",
+					Spec:                 &namespacev1.ExportSinkSpec{Name: "healthy", Enabled: true},
+					Health:               namespacev1.ExportSink_HEALTH_OK,
+					LatestDataExportTime: timestamppb.New(now.Add(-time.Hour)),
+				},
+				{
+					Name:   "disabled",
+					Spec:   &namespacev1.ExportSinkSpec{Name: "disabled"},
+					Health: namespacev1.ExportSink_HEALTH_ERROR_INTERNAL,
+				},
+			},
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	fake := newFake()
+	monitor := NewMonitor(cloudservicemock.NewFakeCloudServiceClient(t, fake), Options{})
+	monitor.now = func() time.Time { return now }
+
+	statuses, err := monitor.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(statuses) != 4 {
+		t.Fatalf("Check() = %+v, want the 4 sinks", statuses)
+	}
+	problems := map[string]string{}
+	for _, s := range statuses {
+		problems[s.String()] = s.problem()
+	}
+	want := map[string]string{
+		"audit log sink kinesis":          "stale",
+		"export sink prod.a1b2c/archive":  "unhealthy: ERROR_USER_CONFIGURATION",
+		"export sink prod.a1b2c/healthy":  "",
+		"export sink prod.a1b2c/disabled": "",
+	}
+	for sink, problem := range want {
+		if problems[sink] != problem {
+			t.Errorf("Check() problem of %s = %q, want %q", sink, problems[sink], problem)
+		}
+	}
+
+	if got := strings.Join(fake.validated, ","); got != "kinesis,prod.a1b2c/archive" {
+		t.Errorf("Check() validated %s, want the stale and unhealthy sinks", got)
+	}
+	n := statuses[1].Notification()
+	if n.Severity != notify.SeverityCritical || !strings.Contains(n.Message, "Diagnosis: access denied to bucket archive") {
+		t.Errorf("Notification() = %+v", n)
+	}
+	if n := statuses[0].Notification(); n.Severity != notify.SeverityWarning || !strings.Contains(n.Message, "validation of the sink passed") {
+		t.Errorf("Notification() = %+v", n)
+	}
+}
+
+func TestRunAlertsOnChanges(t *testing.T) {
+	fake := newFake()
+	var titles []string
+	monitor := NewMonitor(cloudservicemock.NewFakeCloudServiceClient(t, fake), Options{
+		SkipValidation: true,
+		Notifier: notify.NotifierFunc(func(ctx context.Context, n notify.Notification) error {
+			titles = append(titles, n.Title)
+			return nil
+		}),
+	})
+	monitor.now = func() time.Time { return now }
+
+	for range 2 {
+		if err := monitor.checkAndNotify(context.Background()); err != nil {
+			t.Fatalf("checkAndNotify() error = %v", err)
+		}
+	}
+	if len(titles) != 2 {
+		t.Fatalf("checkAndNotify() alerted %q, want the stale and unhealthy sinks once", titles)
+	}
+
+	fake.exportSinks["prod.a1b2c"][0].Health = namespacev1.ExportSink_HEALTH_OK
+	if err := monitor.checkAndNotify(context.Background()); err != nil {
+		t.Fatalf("checkAndNotify() error = %v", err)
+	}
+	if len(titles) != 3 || titles[2] != "export sink prod.a1b2c/archive recovered" {
+		t.Errorf("checkAndNotify() alerted %q, want a recovery", titles)
+	}
+}
+
+func TestCheckPartialFailures(t *testing.T) {
+	fake := newFake()
+	fake.exportSinks["dev.a1b2c"] = fake.exportSinks["prod.a1b2c"]
+	fake.listErrs = map[string]error{"prod.a1b2c": status.Error(codes.PermissionDenied, "denied")}
+	fake.validateErr = status.Error(codes.Unavailable, "connection reset")
+	monitor := NewMonitor(cloudservicemock.NewFakeCloudServiceClient(t, fake), Options{})
+	monitor.now = func() time.Time { return now }
+
+	statuses, err := monitor.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	var got []string
+	for _, s := range statuses {
+		got = append(got, s.String())
+	}
+	want := "audit log sink kinesis,export sink dev.a1b2c/archive,export sink dev.a1b2c/healthy,export sink dev.a1b2c/disabled,export sinks of namespace prod.a1b2c"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("Check() = %s, want %s", strings.Join(got, ","), want)
+	}
+	// the failed listing of prod does not abort the check of dev.
+	if err := statuses[4].Err; status.Code(errors.Unwrap(err)) != codes.PermissionDenied {
+		t.Errorf("Check() error of prod.a1b2c = %v, want the listing error", err)
+	}
+	// the unavailable validation is not a diagnosis.
+	kinesis := statuses[0]
+	if kinesis.Validated || kinesis.Diagnosis != "" || status.Code(errors.Unwrap(kinesis.Err)) != codes.Unavailable {
+		t.Errorf("Check() status of kinesis = %+v, want the validation error", kinesis)
+	}
+	if n := kinesis.Notification(); !strings.Contains(n.Message, "Diagnosis unavailable: failed to validate audit log sink kinesis: ") {
+		t.Errorf("Notification() = %+v", n)
+	}
+	if archive := statuses[1]; !archive.Validated || archive.Diagnosis != "access denied to bucket archive" || archive.Err != nil {
+		t.Errorf("Check() status of archive = %+v, want the diagnosis", archive)
+	}
+}
+
+func TestRunKeepsAlertsOfUnlistedNamespaces(t *testing.T) {
+	fake := newFake()
+	var titles []string
+	monitor := NewMonitor(cloudservicemock.NewFakeCloudServiceClient(t, fake), Options{
+		SkipValidation: true,
+		Notifier: notify.NotifierFunc(func(ctx context.Context, n notify.Notification) error {
+			titles = append(titles, n.Title)
+			return nil
+		}),
+	})
+	monitor.now = func() time.Time { return now }
+
+	if err := monitor.checkAndNotify(context.Background()); err != nil {
+		t.Fatalf("checkAndNotify() error = %v", err)
+	}
+	fake.listErrs = map[string]error{"prod.a1b2c": status.Error(codes.Unavailable, "unavailable")}
+	if err := monitor.checkAndNotify(context.Background()); err == nil || !strings.Contains(err.Error(), `namespace "prod.a1b2c"`) {
+		t.Fatalf("checkAndNotify() error = %v, want the listing error", err)
+	}
+	// once listed again, the sink still unhealthy is not alerted on again.
+	fake.listErrs = nil
+	if err := monitor.checkAndNotify(context.Background()); err != nil {
+		t.Fatalf("checkAndNotify() error = %v", err)
+	}
+	if len(titles) != 2 {
+		t.Errorf("checkAndNotify() alerted %q, want the stale and unhealthy sinks once", titles)
+	}
+}
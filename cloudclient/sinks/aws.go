@@ -0,0 +1,324 @@
+package sinks
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	accountv1 "go.temporal.io/cloud-sdk/api/account/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	sinkv1 "go.temporal.io/cloud-sdk/api/sink/v1"
+)
+
+const awsPolicyVersion = "2012-10-17"
+
+var (
+	awsRegionRE    = regexp.MustCompile(`^[a-z]{2}(-gov|-iso[a-z]*)?-[a-z]+-\d+$`)
+	awsAccountIDRE = regexp.MustCompile(`^\d{12}$`)
+	awsRoleNameRE  = regexp.MustCompile(`^[\w+=,.@-]{1,64}$`)
+	s3BucketRE     = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+	kinesisNameRE  = regexp.MustCompile(`^[\w.-]{1,128}$`)
+)
+
+type (
+	// S3 configures an export sink writing to an S3 bucket.
+	S3 struct {
+		// The name of the bucket, such as "temporal-history".
+		Bucket string
+		// The region of the bucket, such as "us-east-1".
+		Region string
+		// The IAM role Temporal Cloud assumes to write to the bucket, either its name or its ARN.
+		Role string
+		// The AWS account id of the bucket and the role.
+		// If not provided, the account id of the role ARN is used.
+		AccountID string
+		// The ARN of the KMS key encrypting the bucket, if any. The key must be in the region of the bucket.
+		KMSKeyARN string
+	}
+
+	// Kinesis configures an audit log sink writing to a Kinesis data stream.
+	Kinesis struct {
+		// The ARN of the stream, such as "arn:aws:kinesis:us-east-1:123456789012:stream/audit-logs".
+		StreamARN string
+		// The region of the stream.
+		// If not provided, the region of the stream ARN is used.
+		Region string
+		// The IAM role Temporal Cloud assumes to write to the stream, either its name or its ARN. A name is
+		// turned into the ARN of the role in the account of the stream.
+		Role string
+	}
+
+	// AWSPrincipal identifies Temporal Cloud in the trust policy of the role it assumes, as documented by
+	// Temporal Cloud for the region of the sink.
+	AWSPrincipal struct {
+		// The ARN of the principal assuming the role.
+		ARN string
+		// The external id the trust policy requires, if any.
+		ExternalID string
+	}
+
+	// AWSPolicy is an IAM policy document, to be marshaled to JSON.
+	AWSPolicy struct {
+		Version   string               `json:"Version"`
+		Statement []AWSPolicyStatement `json:"Statement"`
+	}
+
+	// AWSPolicyStatement is a statement of an IAM policy document.
+	AWSPolicyStatement struct {
+		Effect    string                       `json:"Effect"`
+		Principal map[string]string            `json:"Principal,omitempty"`
+		Action    []string                     `json:"Action"`
+		Resource  []string                     `json:"Resource,omitempty"`
+		Condition map[string]map[string]string `json:"Condition,omitempty"`
+	}
+
+	// arn is a parsed Amazon Resource Name.
+	arn struct {
+		partition, service, region, accountID, resource string
+	}
+)
+
+// Spec validates the configuration and returns the spec of the sink.
+// A role ARN is split into the role name and the account id, which must match AccountID if both are provided.
+func (s S3) Spec() (*sinkv1.S3Spec, error) {
+	var errs []error
+	if !s3BucketRE.MatchString(s.Bucket) || strings.Contains(s.Bucket, "..") {
+		errs = append(errs, fmt.Errorf("invalid bucket name %q: expected 3 to 63 lowercase letters, digits, dots and hyphens", s.Bucket))
+	}
+	if !awsRegionRE.MatchString(s.Region) {
+		errs = append(errs, fmt.Errorf("invalid region %q: expected a region such as us-east-1", s.Region))
+	}
+	roleName, accountID, err := parseRole(s.Role)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	switch {
+	case s.AccountID == "":
+	case !awsAccountIDRE.MatchString(s.AccountID):
+		errs = append(errs, fmt.Errorf("invalid account id %q: expected 12 digits", s.AccountID))
+	case accountID != "" && accountID != s.AccountID:
+		errs = append(errs, fmt.Errorf("the role %q is not in the account %s", s.Role, s.AccountID))
+	default:
+		accountID = s.AccountID
+	}
+	if accountID == "" && err == nil {
+		errs = append(errs, errors.New("missing account id: provide the account id or the role ARN"))
+	}
+	if s.KMSKeyARN != "" {
+		key, err := parseARN(s.KMSKeyARN, "kms")
+		switch {
+		case err != nil:
+			errs = append(errs, err)
+		case !strings.HasPrefix(key.resource, "key/") && !strings.HasPrefix(key.resource, "alias/"):
+			errs = append(errs, fmt.Errorf("invalid KMS key ARN %q: expected a key or an alias", s.KMSKeyARN))
+		case key.region != s.Region:
+			errs = append(errs, fmt.Errorf("the KMS key is in %s but the bucket is in %s", key.region, s.Region))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid S3 sink: %w", errors.Join(errs...))
+	}
+	return &sinkv1.S3Spec{
+		RoleName:     roleName,
+		BucketName:   s.Bucket,
+		Region:       s.Region,
+		KmsArn:       s.KMSKeyARN,
+		AwsAccountId: accountID,
+	}, nil
+}
+
+// ExportSinkSpec validates the configuration and returns the spec of an enabled export sink with the given name.
+func (s S3) ExportSinkSpec(name string) (*namespacev1.ExportSinkSpec, error) {
+	spec, err := s.Spec()
+	if err != nil {
+		return nil, err
+	}
+	return &namespacev1.ExportSinkSpec{Name: name, Enabled: true, S3: spec}, nil
+}
+
+// TrustPolicy returns the trust policy of the role, allowing Temporal Cloud to assume it.
+func (s S3) TrustPolicy(principal AWSPrincipal) (*AWSPolicy, error) {
+	if _, err := s.Spec(); err != nil {
+		return nil, err
+	}
+	return trustPolicy(principal)
+}
+
+// PermissionsPolicy returns the permissions policy of the role, allowing it to write to the bucket and to use
+// the KMS key, if any.
+func (s S3) PermissionsPolicy() (*AWSPolicy, error) {
+	if _, err := s.Spec(); err != nil {
+		return nil, err
+	}
+	bucketARN := fmt.Sprintf("arn:%s:s3:::%s", partition(s.Region), s.Bucket)
+	policy := &AWSPolicy{
+		Version: awsPolicyVersion,
+		Statement: []AWSPolicyStatement{
+			{
+				Effect:   "Allow",
+				Action:   []string{"s3:GetBucketLocation", "s3:ListBucket"},
+				Resource: []string{bucketARN},
+			},
+			{
+				Effect:   "Allow",
+				Action:   []string{"s3:PutObject", "s3:GetObject"},
+				Resource: []string{bucketARN + "/*"},
+			},
+		},
+	}
+	if s.KMSKeyARN != "" {
+		policy.Statement = append(policy.Statement, AWSPolicyStatement{
+			Effect:   "Allow",
+			Action:   []string{"kms:GenerateDataKey", "kms:Decrypt"},
+			Resource: []string{s.KMSKeyARN},
+		})
+	}
+	return policy, nil
+}
+
+// Spec validates the configuration and returns the spec of the sink.
+// The region must match the region of the stream, and a role ARN must be in the account of the stream.
+func (k Kinesis) Spec() (*sinkv1.KinesisSpec, error) {
+	var errs []error
+	stream, err := parseARN(k.StreamARN, "kinesis")
+	if err != nil {
+		errs = append(errs, err)
+	} else if name, ok := strings.CutPrefix(stream.resource, "stream/"); !ok || !kinesisNameRE.MatchString(name) {
+		errs = append(errs, fmt.Errorf("invalid stream ARN %q: expected a stream", k.StreamARN))
+	}
+	region := k.Region
+	switch {
+	case region == "":
+		region = stream.region
+	case !awsRegionRE.MatchString(region):
+		errs = append(errs, fmt.Errorf("invalid region %q: expected a region such as us-east-1", region))
+	case err == nil && region != stream.region:
+		errs = append(errs, fmt.Errorf("the stream is in %s but the region is %s", stream.region, region))
+	}
+	roleName, accountID, roleErr := parseRole(k.Role)
+	if roleErr != nil {
+		errs = append(errs, roleErr)
+	}
+	role := k.Role
+	if err == nil && roleErr == nil {
+		if accountID == "" {
+			role = fmt.Sprintf("arn:%s:iam::%s:role/%s", stream.partition, stream.accountID, roleName)
+		} else if accountID != stream.accountID {
+			errs = append(errs, fmt.Errorf("the role is in the account %s but the stream is in %s", accountID, stream.accountID))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid Kinesis sink: %w", errors.Join(errs...))
+	}
+	return &sinkv1.KinesisSpec{
+		RoleName:       role,
+		DestinationUri: k.StreamARN,
+		Region:         region,
+	}, nil
+}
+
+// AuditLogSinkSpec validates the configuration and returns the spec of an enabled audit log sink with the
+// given name.
+func (k Kinesis) AuditLogSinkSpec(name string) (*accountv1.AuditLogSinkSpec, error) {
+	spec, err := k.Spec()
+	if err != nil {
+		return nil, err
+	}
+	return &accountv1.AuditLogSinkSpec{
+		Name:     name,
+		Enabled:  true,
+		SinkType: &accountv1.AuditLogSinkSpec_KinesisSink{KinesisSink: spec},
+	}, nil
+}
+
+// TrustPolicy returns the trust policy of the role, allowing Temporal Cloud to assume it.
+func (k Kinesis) TrustPolicy(principal AWSPrincipal) (*AWSPolicy, error) {
+	if _, err := k.Spec(); err != nil {
+		return nil, err
+	}
+	return trustPolicy(principal)
+}
+
+// PermissionsPolicy returns the permissions policy of the role, allowing it to write to the stream.
+func (k Kinesis) PermissionsPolicy() (*AWSPolicy, error) {
+	if _, err := k.Spec(); err != nil {
+		return nil, err
+	}
+	return &AWSPolicy{
+		Version: awsPolicyVersion,
+		Statement: []AWSPolicyStatement{{
+			Effect:   "Allow",
+			Action:   []string{"kinesis:DescribeStream", "kinesis:PutRecord", "kinesis:PutRecords"},
+			Resource: []string{k.StreamARN},
+		}},
+	}, nil
+}
+
+func trustPolicy(principal AWSPrincipal) (*AWSPolicy, error) {
+	if _, err := parseARN(principal.ARN, "iam"); err != nil {
+		return nil, fmt.Errorf("invalid Temporal Cloud principal: %w", err)
+	}
+	statement := AWSPolicyStatement{
+		Effect:    "Allow",
+		Principal: map[string]string{"AWS": principal.ARN},
+		Action:    []string{"sts:AssumeRole"},
+	}
+	if principal.ExternalID != "" {
+		statement.Condition = map[string]map[string]string{
+			"StringEquals": {"sts:ExternalId": principal.ExternalID},
+		}
+	}
+	return &AWSPolicy{Version: awsPolicyVersion, Statement: []AWSPolicyStatement{statement}}, nil
+}
+
+// parseRole returns the name of a role given by name or ARN, and its account id if given by ARN.
+func parseRole(role string) (name, accountID string, err error) {
+	if !strings.HasPrefix(role, "arn:") {
+		if !awsRoleNameRE.MatchString(role) {
+			return "", "", fmt.Errorf("invalid role name %q", role)
+		}
+		return role, "", nil
+	}
+	a, err := parseARN(role, "iam")
+	if err != nil {
+		return "", "", err
+	}
+	path, ok := strings.CutPrefix(a.resource, "role/")
+	name = path[strings.LastIndex(path, "/")+1:]
+	if !ok || !awsRoleNameRE.MatchString(name) {
+		return "", "", fmt.Errorf("invalid role ARN %q: expected a role", role)
+	}
+	return name, a.accountID, nil
+}
+
+// parseARN parses an ARN of the given service, which must have an account id.
+func parseARN(s, service string) (arn, error) {
+	parts := strings.SplitN(s, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || !strings.HasPrefix(parts[1], "aws") || parts[5] == "" {
+		return arn{}, fmt.Errorf("invalid ARN %q", s)
+	}
+	a := arn{partition: parts[1], service: parts[2], region: parts[3], accountID: parts[4], resource: parts[5]}
+	if a.service != service {
+		return arn{}, fmt.Errorf("invalid ARN %q: expected a %s ARN", s, service)
+	}
+	if !awsAccountIDRE.MatchString(a.accountID) {
+		return arn{}, fmt.Errorf("invalid ARN %q: invalid account id %q", s, a.accountID)
+	}
+	// IAM is a global service, its ARNs have no region
+	if service != "iam" && !awsRegionRE.MatchString(a.region) {
+		return arn{}, fmt.Errorf("invalid ARN %q: invalid region %q", s, a.region)
+	}
+	return a, nil
+}
+
+// partition returns the AWS partition of a region.
+func partition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	}
+	return "aws"
+}
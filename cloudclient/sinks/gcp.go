@@ -0,0 +1,223 @@
+package sinks
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	accountv1 "go.temporal.io/cloud-sdk/api/account/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	sinkv1 "go.temporal.io/cloud-sdk/api/sink/v1"
+)
+
+const serviceAccountDomain = ".iam.gserviceaccount.com"
+
+var (
+	gcpProjectIDRE      = regexp.MustCompile(`^[a-z][a-z0-9-]{4,28}[a-z0-9]$`)
+	gcpServiceAccountRE = regexp.MustCompile(`^[a-z][a-z0-9-]{4,28}[a-z0-9]$`)
+	gcpRegionRE         = regexp.MustCompile(`^[a-z]+-[a-z]+\d+$`)
+	gcsBucketRE         = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{1,61}[a-z0-9]$`)
+	pubSubTopicRE       = regexp.MustCompile(`^[A-Za-z][\w.~+%-]{2,254}$`)
+)
+
+type (
+	// GCS configures an export sink writing to a Cloud Storage bucket.
+	GCS struct {
+		// The name of the bucket, such as "temporal-history".
+		Bucket string
+		// The region of the bucket, such as "us-central1".
+		Region string
+		// The service account Temporal Cloud impersonates to write to the bucket, either its id or its email.
+		ServiceAccount string
+		// The id of the project of the bucket and the service account.
+		// If not provided, the project of the service account email is used.
+		ProjectID string
+	}
+
+	// PubSub configures an audit log sink publishing to a Pub/Sub topic.
+	PubSub struct {
+		// The topic, either its name or its full name, such as "projects/my-project/topics/audit-logs".
+		Topic string
+		// The service account Temporal Cloud impersonates to publish to the topic, either its id or its email.
+		ServiceAccount string
+		// The id of the project of the topic and the service account.
+		// If not provided, the project of the full topic name or of the service account email is used.
+		ProjectID string
+	}
+
+	// GCPPolicy holds the IAM bindings to add to a resource, marshaled to JSON in the format of a GCP IAM policy.
+	GCPPolicy struct {
+		// The full name of the resource, such as "projects/_/buckets/temporal-history".
+		Resource string       `json:"-"`
+		Bindings []GCPBinding `json:"bindings"`
+	}
+
+	// GCPBinding grants a role to members.
+	GCPBinding struct {
+		Role    string   `json:"role"`
+		Members []string `json:"members"`
+	}
+)
+
+// Spec validates the configuration and returns the spec of the sink.
+func (g GCS) Spec() (*sinkv1.GCSSpec, error) {
+	var errs []error
+	if !gcsBucketRE.MatchString(g.Bucket) || strings.Contains(g.Bucket, "..") {
+		errs = append(errs, fmt.Errorf("invalid bucket name %q: expected 3 to 63 lowercase letters, digits, dots, underscores and hyphens", g.Bucket))
+	}
+	if !gcpRegionRE.MatchString(g.Region) {
+		errs = append(errs, fmt.Errorf("invalid region %q: expected a region such as us-central1", g.Region))
+	}
+	id, project, err := resolveServiceAccount(g.ServiceAccount, g.ProjectID)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid GCS sink: %w", errors.Join(errs...))
+	}
+	return &sinkv1.GCSSpec{
+		SaId:         id,
+		BucketName:   g.Bucket,
+		GcpProjectId: project,
+		Region:       g.Region,
+	}, nil
+}
+
+// ExportSinkSpec validates the configuration and returns the spec of an enabled export sink with the given name.
+func (g GCS) ExportSinkSpec(name string) (*namespacev1.ExportSinkSpec, error) {
+	spec, err := g.Spec()
+	if err != nil {
+		return nil, err
+	}
+	return &namespacev1.ExportSinkSpec{Name: name, Enabled: true, Gcs: spec}, nil
+}
+
+// IAMPolicies returns the bindings to add to the service account, allowing the Temporal Cloud service account to
+// impersonate it, and to the bucket, allowing the service account to write to it.
+func (g GCS) IAMPolicies(temporalServiceAccount string) ([]GCPPolicy, error) {
+	spec, err := g.Spec()
+	if err != nil {
+		return nil, err
+	}
+	sa, err := impersonationPolicy(spec.GetSaId(), spec.GetGcpProjectId(), temporalServiceAccount)
+	if err != nil {
+		return nil, err
+	}
+	return []GCPPolicy{sa, {
+		Resource: "projects/_/buckets/" + g.Bucket,
+		Bindings: []GCPBinding{{
+			Role:    "roles/storage.objectAdmin",
+			Members: []string{"serviceAccount:" + serviceAccountEmail(spec.GetSaId(), spec.GetGcpProjectId())},
+		}},
+	}}, nil
+}
+
+// Spec validates the configuration and returns the spec of the sink.
+// The project of a full topic name must match the project of the service account.
+func (p PubSub) Spec() (*sinkv1.PubSubSpec, error) {
+	var errs []error
+	project, topic := p.ProjectID, p.Topic
+	if rest, ok := strings.CutPrefix(topic, "projects/"); ok {
+		topicProject, name, ok := strings.Cut(rest, "/topics/")
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("invalid topic %q: expected projects/<project>/topics/<topic>", p.Topic))
+		case project != "" && project != topicProject:
+			errs = append(errs, fmt.Errorf("the topic is in the project %s but the project is %s", topicProject, project))
+		default:
+			project = topicProject
+		}
+		topic = name
+	}
+	if !pubSubTopicRE.MatchString(topic) || strings.HasPrefix(topic, "goog") {
+		errs = append(errs, fmt.Errorf("invalid topic name %q: expected 3 to 255 characters starting with a letter, and not with goog", topic))
+	}
+	id, project, err := resolveServiceAccount(p.ServiceAccount, project)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid Pub/Sub sink: %w", errors.Join(errs...))
+	}
+	return &sinkv1.PubSubSpec{
+		ServiceAccountId: id,
+		TopicName:        topic,
+		GcpProjectId:     project,
+	}, nil
+}
+
+// AuditLogSinkSpec validates the configuration and returns the spec of an enabled audit log sink with the
+// given name.
+func (p PubSub) AuditLogSinkSpec(name string) (*accountv1.AuditLogSinkSpec, error) {
+	spec, err := p.Spec()
+	if err != nil {
+		return nil, err
+	}
+	return &accountv1.AuditLogSinkSpec{
+		Name:     name,
+		Enabled:  true,
+		SinkType: &accountv1.AuditLogSinkSpec_PubSubSink{PubSubSink: spec},
+	}, nil
+}
+
+// IAMPolicies returns the bindings to add to the service account, allowing the Temporal Cloud service account to
+// impersonate it, and to the topic, allowing the service account to publish to it.
+func (p PubSub) IAMPolicies(temporalServiceAccount string) ([]GCPPolicy, error) {
+	spec, err := p.Spec()
+	if err != nil {
+		return nil, err
+	}
+	sa, err := impersonationPolicy(spec.GetServiceAccountId(), spec.GetGcpProjectId(), temporalServiceAccount)
+	if err != nil {
+		return nil, err
+	}
+	return []GCPPolicy{sa, {
+		Resource: fmt.Sprintf("projects/%s/topics/%s", spec.GetGcpProjectId(), spec.GetTopicName()),
+		Bindings: []GCPBinding{{
+			Role:    "roles/pubsub.publisher",
+			Members: []string{"serviceAccount:" + serviceAccountEmail(spec.GetServiceAccountId(), spec.GetGcpProjectId())},
+		}},
+	}}, nil
+}
+
+func impersonationPolicy(id, project, temporalServiceAccount string) (GCPPolicy, error) {
+	if !strings.HasSuffix(temporalServiceAccount, ".gserviceaccount.com") {
+		return GCPPolicy{}, fmt.Errorf("invalid Temporal Cloud service account %q: expected a service account email", temporalServiceAccount)
+	}
+	email := serviceAccountEmail(id, project)
+	return GCPPolicy{
+		Resource: fmt.Sprintf("projects/%s/serviceAccounts/%s", project, email),
+		Bindings: []GCPBinding{{
+			Role:    "roles/iam.serviceAccountTokenCreator",
+			Members: []string{"serviceAccount:" + temporalServiceAccount},
+		}},
+	}, nil
+}
+
+// resolveServiceAccount returns the id and the project of a service account given by id or email.
+// The project of an email must match the given project, if any.
+func resolveServiceAccount(serviceAccount, project string) (id, resolvedProject string, err error) {
+	id = serviceAccount
+	if local, domain, ok := strings.Cut(serviceAccount, "@"); ok {
+		emailProject, ok := strings.CutSuffix(domain, serviceAccountDomain)
+		switch {
+		case !ok:
+			return "", "", fmt.Errorf("invalid service account %q: expected <id>@<project>%s", serviceAccount, serviceAccountDomain)
+		case project != "" && project != emailProject:
+			return "", "", fmt.Errorf("the service account is in the project %s but the project is %s", emailProject, project)
+		}
+		id, project = local, emailProject
+	}
+	if !gcpServiceAccountRE.MatchString(id) {
+		return "", "", fmt.Errorf("invalid service account id %q: expected 6 to 30 lowercase letters, digits and hyphens", id)
+	}
+	if !gcpProjectIDRE.MatchString(project) {
+		return "", "", fmt.Errorf("invalid project id %q: expected 6 to 30 lowercase letters, digits and hyphens", project)
+	}
+	return id, project, nil
+}
+
+func serviceAccountEmail(id, project string) string {
+	return id + "@" + project + serviceAccountDomain
+}
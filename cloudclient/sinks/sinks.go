@@ -0,0 +1,130 @@
+// Package sinks builds the specs of the audit log sinks and export sinks of Temporal Cloud, validating locally
+// the cloud-specific formats they require, and creates the sinks once Temporal Cloud validated them.
+//
+// WARNING: The package is currently experimental.
+//
+// Export sinks write workflow histories to an S3 or a GCS bucket, audit log sinks write audit logs to a Kinesis
+// stream or a Pub/Sub topic. Temporal Cloud writes to them by assuming an IAM role, or by impersonating a GCP
+// service account, owned by the customer. The configuration types of this package return the spec of a sink
+// as well as the IAM policies to install for Temporal Cloud to be granted access:
+//
+//	s3 := sinks.S3{Bucket: "temporal-history", Region: "us-east-1", Role: "arn:aws:iam::123456789012:role/temporal"}
+//	trust, err := s3.TrustPolicy(sinks.AWSPrincipal{ARN: temporalPrincipal, ExternalID: externalID})
+//	...
+//	spec, err := s3.ExportSinkSpec("history")
+//	...
+//	err = sinks.CreateExportSink(ctx, client, "ns.a1b2c", spec, sinks.Options{})
+package sinks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	accountv1 "go.temporal.io/cloud-sdk/api/account/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	"go.temporal.io/cloud-sdk/internal/asyncop"
+	"go.temporal.io/cloud-sdk/internal/rpcerrors"
+	"google.golang.org/grpc/status"
+)
+
+type (
+	// Options to configure the creation of a sink.
+	Options struct {
+		// The id of the async operation creating the sink, to make the creation idempotent.
+		// If not provided, the server generates one.
+		AsyncOperationID string
+
+		// The interval between two polls of the async operation creating the sink.
+		// If not provided, the check duration suggested by the server is used.
+		PollInterval time.Duration
+	}
+
+	// ValidationError is returned when Temporal Cloud fails to write to a sink with the spec being created,
+	// typically because the IAM policies are missing or incorrect.
+	ValidationError struct {
+		// The name of the sink.
+		Sink string
+		// The reason of the failure, as reported by Temporal Cloud.
+		Reason string
+		// The error returned by the validation.
+		Err error
+	}
+)
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("validation of the sink %q failed: %s", e.Sink, e.Reason)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// CreateExportSink validates the spec with ValidateNamespaceExportSink, which tests the delivery to the
+// destination, then creates the export sink and waits for its creation to complete.
+// A *ValidationError is returned if the validation failed, in which case the sink is not created.
+// The errors of the validation call itself, e.g. when Temporal Cloud is unavailable, are returned as is.
+func CreateExportSink(
+	ctx context.Context,
+	client cloudservicev1.CloudServiceClient,
+	namespace string,
+	spec *namespacev1.ExportSinkSpec,
+	options Options,
+) error {
+	if _, err := client.ValidateNamespaceExportSink(ctx, &cloudservicev1.ValidateNamespaceExportSinkRequest{
+		Namespace: namespace,
+		Spec:      spec,
+	}); err != nil {
+		return validationError("export sink", spec.GetName(), err)
+	}
+	resp, err := client.CreateNamespaceExportSink(ctx, &cloudservicev1.CreateNamespaceExportSinkRequest{
+		Namespace:        namespace,
+		Spec:             spec,
+		AsyncOperationId: options.AsyncOperationID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create export sink %q: %w", spec.GetName(), err)
+	}
+	if _, err := asyncop.Wait(ctx, client, resp.GetAsyncOperation().GetId(), options.PollInterval); err != nil {
+		return fmt.Errorf("failed to create export sink %q: %w", spec.GetName(), err)
+	}
+	return nil
+}
+
+// CreateAuditLogSink validates the spec with ValidateAccountAuditLogSink, which tests the delivery to the
+// destination, then creates the audit log sink and waits for its creation to complete.
+// A *ValidationError is returned if the validation failed, in which case the sink is not created.
+// The errors of the validation call itself, e.g. when Temporal Cloud is unavailable, are returned as is.
+func CreateAuditLogSink(
+	ctx context.Context,
+	client cloudservicev1.CloudServiceClient,
+	spec *accountv1.AuditLogSinkSpec,
+	options Options,
+) error {
+	if _, err := client.ValidateAccountAuditLogSink(ctx, &cloudservicev1.ValidateAccountAuditLogSinkRequest{
+		Spec: spec,
+	}); err != nil {
+		return validationError("audit log sink", spec.GetName(), err)
+	}
+	resp, err := client.CreateAccountAuditLogSink(ctx, &cloudservicev1.CreateAccountAuditLogSinkRequest{
+		Spec:             spec,
+		AsyncOperationId: options.AsyncOperationID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create audit log sink %q: %w", spec.GetName(), err)
+	}
+	if _, err := asyncop.Wait(ctx, client, resp.GetAsyncOperation().GetId(), options.PollInterval); err != nil {
+		return fmt.Errorf("failed to create audit log sink %q: %w", spec.GetName(), err)
+	}
+	return nil
+}
+
+// validationError returns a *ValidationError for the failed validation of a sink, unless the error is about the
+// call rather than the sink.
+func validationError(kind, sink string, err error) error {
+	if rpcerrors.IsTransport(err) {
+		return fmt.Errorf("failed to validate %s %q: %w", kind, sink, err)
+	}
+	return &ValidationError{Sink: sink, Reason: status.Convert(err).Message(), Err: err}
+}
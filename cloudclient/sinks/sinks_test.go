@@ -0,0 +1,286 @@
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	operationv1 "go.temporal.io/cloud-sdk/api/operation/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/cloudservicemock"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSpecs(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		spec func() (any, error)
+		// the spec in JSON, or a substring of the error
+		want string
+	}{
+		{
+			name: "S3 Role ARN",
+			spec: func() (any, error) {
+				return S3{Bucket: "history", Region: "us-east-1", Role: "arn:aws:iam::123456789012:role/path/temporal"}.Spec()
+			},
+			want: `{"role_name":"temporal","bucket_name":"history","region":"us-east-1","aws_account_id":"123456789012"}`,
+		},
+		{
+			name: "S3 Role Name",
+			spec: func() (any, error) {
+				return S3{Bucket: "history", Region: "us-east-1", Role: "temporal", AccountID: "123456789012"}.Spec()
+			},
+			want: `{"role_name":"temporal","bucket_name":"history","region":"us-east-1","aws_account_id":"123456789012"}`,
+		},
+		{
+			name: "S3 Missing Account",
+			spec: func() (any, error) {
+				return S3{Bucket: "history", Region: "us-east-1", Role: "temporal"}.Spec()
+			},
+			want: "missing account id",
+		},
+		{
+			name: "S3 Account Mismatch",
+			spec: func() (any, error) {
+				return S3{Bucket: "history", Region: "us-east-1", Role: "arn:aws:iam::123456789012:role/temporal", AccountID: "210987654321"}.Spec()
+			},
+			want: "is not in the account 210987654321",
+		},
+		{
+			name: "S3 KMS Region Mismatch",
+			spec: func() (any, error) {
+				return S3{Bucket: "history", Region: "us-east-1", Role: "arn:aws:iam::123456789012:role/temporal", KMSKeyARN: "arn:aws:kms:eu-west-1:123456789012:key/abcd"}.Spec()
+			},
+			want: "the KMS key is in eu-west-1 but the bucket is in us-east-1",
+		},
+		{
+			name: "S3 Invalid Bucket",
+			spec: func() (any, error) {
+				return S3{Bucket: "History", Region: "us-east-1", Role: "arn:aws:iam::123456789012:role/temporal"}.Spec()
+			},
+			want: `invalid bucket name "History"`,
+		},
+		{
+			name: "Kinesis Role Name",
+			spec: func() (any, error) {
+				return Kinesis{StreamARN: "arn:aws:kinesis:us-west-2:123456789012:stream/audit", Role: "temporal"}.Spec()
+			},
+			want: `{"role_name":"arn:aws:iam::123456789012:role/temporal","destination_uri":"arn:aws:kinesis:us-west-2:123456789012:stream/audit","region":"us-west-2"}`,
+		},
+		{
+			name: "Kinesis Region Mismatch",
+			spec: func() (any, error) {
+				return Kinesis{StreamARN: "arn:aws:kinesis:us-west-2:123456789012:stream/audit", Region: "us-east-1", Role: "temporal"}.Spec()
+			},
+			want: "the stream is in us-west-2 but the region is us-east-1",
+		},
+		{
+			name: "GCS Service Account Email",
+			spec: func() (any, error) {
+				return GCS{Bucket: "history", Region: "us-central1", ServiceAccount: "temporal-sink@my-project.iam.gserviceaccount.com"}.Spec()
+			},
+			want: `{"sa_id":"temporal-sink","bucket_name":"history","gcp_project_id":"my-project","region":"us-central1"}`,
+		},
+		{
+			name: "GCS Invalid Project",
+			spec: func() (any, error) {
+				return GCS{Bucket: "history", Region: "us-central1", ServiceAccount: "temporal-sink", ProjectID: "My_Project"}.Spec()
+			},
+			want: `invalid project id "My_Project"`,
+		},
+		{
+			name: "Pub/Sub Full Topic Name",
+			spec: func() (any, error) {
+				return PubSub{Topic: "projects/my-project/topics/audit", ServiceAccount: "temporal-sink"}.Spec()
+			},
+			want: `{"service_account_id":"temporal-sink","topic_name":"audit","gcp_project_id":"my-project"}`,
+		},
+		{
+			name: "Pub/Sub Project Mismatch",
+			spec: func() (any, error) {
+				return PubSub{Topic: "audit", ServiceAccount: "temporal-sink@other-project.iam.gserviceaccount.com", ProjectID: "my-project"}.Spec()
+			},
+			want: "the service account is in the project other-project but the project is my-project",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			spec, err := tc.spec()
+			if err != nil {
+				if !strings.Contains(err.Error(), tc.want) {
+					t.Fatalf("Spec() error = %v, want %s", err, tc.want)
+				}
+				return
+			}
+			got, err := json.Marshal(spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tc.want {
+				t.Fatalf("Spec() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPolicies(t *testing.T) {
+	s3 := S3{
+		Bucket:    "history",
+		Region:    "us-east-1",
+		Role:      "arn:aws:iam::123456789012:role/temporal",
+		KMSKeyARN: "arn:aws:kms:us-east-1:123456789012:key/abcd",
+	}
+	trust, err := s3.TrustPolicy(AWSPrincipal{ARN: "arn:aws:iam::111111111111:role/temporal-cloud", ExternalID: "a1b2c"})
+	if err != nil {
+		t.Fatalf("TrustPolicy() error = %v", err)
+	}
+	got, _ := json.Marshal(trust)
+	want := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::111111111111:role/temporal-cloud"},"Action":["sts:AssumeRole"],"Condition":{"StringEquals":{"sts:ExternalId":"a1b2c"}}}]}`
+	if string(got) != want {
+		t.Errorf("TrustPolicy() = %s, want %s", got, want)
+	}
+	permissions, err := s3.PermissionsPolicy()
+	if err != nil {
+		t.Fatalf("PermissionsPolicy() error = %v", err)
+	}
+	if len(permissions.Statement) != 3 || permissions.Statement[1].Resource[0] != "arn:aws:s3:::history/*" {
+		t.Errorf("PermissionsPolicy() = %+v", permissions)
+	}
+
+	policies, err := PubSub{Topic: "audit", ServiceAccount: "temporal-sink", ProjectID: "my-project"}.IAMPolicies("temporal@cloud-project.iam.gserviceaccount.com")
+	if err != nil {
+		t.Fatalf("IAMPolicies() error = %v", err)
+	}
+	if len(policies) != 2 ||
+		policies[0].Resource != "projects/my-project/serviceAccounts/temporal-sink@my-project.iam.gserviceaccount.com" ||
+		policies[1].Resource != "projects/my-project/topics/audit" {
+		t.Fatalf("IAMPolicies() = %+v", policies)
+	}
+	got, _ = json.Marshal(policies[1])
+	want = `{"bindings":[{"role":"roles/pubsub.publisher","members":["serviceAccount:temporal-sink@my-project.iam.gserviceaccount.com"]}]}`
+	if string(got) != want {
+		t.Errorf("IAMPolicies() = %s, want %s", got, want)
+	}
+}
+
+type fakeCloudService struct {
+	validationErr error
+	created       bool
+}
+
+func (s *fakeCloudService) ValidateNamespaceExportSink(ctx context.Context, req *cloudservicev1.ValidateNamespaceExportSinkRequest, opts ...grpc.CallOption) (*cloudservicev1.ValidateNamespaceExportSinkResponse, error) {
+	return &cloudservicev1.ValidateNamespaceExportSinkResponse{}, s.validationErr
+}
+
+func (s *fakeCloudService) CreateNamespaceExportSink(ctx context.Context, req *cloudservicev1.CreateNamespaceExportSinkRequest, opts ...grpc.CallOption) (*cloudservicev1.CreateNamespaceExportSinkResponse, error) {
+	s.created = true
+	return &cloudservicev1.CreateNamespaceExportSinkResponse{AsyncOperation: &operationv1.AsyncOperation{Id: "op"}}, nil
+}
+
+func (s *fakeCloudService) GetAsyncOperation(ctx context.Context, req *cloudservicev1.GetAsyncOperationRequest, opts ...grpc.CallOption) (*cloudservicev1.GetAsyncOperationResponse, error) {
+	return &cloudservicev1.GetAsyncOperationResponse{AsyncOperation: &operationv1.AsyncOperation{
+		Id:    req.GetAsyncOperationId(),
+		State: operationv1.AsyncOperation_STATE_FULFILLED,
+	}}, nil
+}
+
+func TestCreateExportSink(t *testing.T) {
+	spec, err := GCS{Bucket: "history", Region: "us-central1", ServiceAccount: "temporal-sink", ProjectID: "my-project"}.ExportSinkSpec("history")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake := &fakeCloudService{validationErr: status.Error(codes.InvalidArgument, "permission denied on bucket history")}
+	err = CreateExportSink(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), "ns.a1b2c", spec, Options{})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Reason != "permission denied on bucket history" || fake.created {
+		t.Fatalf("CreateExportSink() error = %v, want a validation error and no sink created", err)
+	}
+
+	// Temporal Cloud is unavailable: the sink was not validated.
+	fake = &fakeCloudService{validationErr: status.Error(codes.Unavailable, "connection refused")}
+	err = CreateExportSink(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), "ns.a1b2c", spec, Options{})
+	if err == nil || errors.As(err, &validationErr) || status.Code(errors.Unwrap(err)) != codes.Unavailable || fake.created {
+		t.Fatalf("CreateExportSink() error = %v, want the unavailable error and no sink created", err)
+	}
+
+	fake = &fakeCloudService{}
+	if err := CreateExportSink(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), "ns.a1b2c", spec, Options{}); err != nil || !fake.created {
+		t.Fatalf("CreateExportSink() error = %v, want the sink created", err)
+	}
+
+	// The sink is validated and created, but its creation fails.
+	client := cloudservicemock.NewMockCloudServiceClient(gomock.NewController(t))
+	gomock.InOrder(
+		client.EXPECT().
+			ValidateNamespaceExportSink(gomock.Any(), cloudservicemock.EqualProto(&cloudservicev1.ValidateNamespaceExportSinkRequest{Namespace: "ns.a1b2c", Spec: spec})).
+			Return(&cloudservicev1.ValidateNamespaceExportSinkResponse{}, nil),
+		client.EXPECT().
+			CreateNamespaceExportSink(gomock.Any(), cloudservicemock.EqualProto(&cloudservicev1.CreateNamespaceExportSinkRequest{Namespace: "ns.a1b2c", Spec: spec})).
+			Return(&cloudservicev1.CreateNamespaceExportSinkResponse{AsyncOperation: &operationv1.AsyncOperation{Id: "op"}}, nil),
+		client.EXPECT().
+			GetAsyncOperation(gomock.Any(), cloudservicemock.EqualProto(&cloudservicev1.GetAsyncOperationRequest{AsyncOperationId: "op"})).
+			Return(&cloudservicev1.GetAsyncOperationResponse{AsyncOperation: &operationv1.AsyncOperation{
+				Id:            "op",
+				State:         operationv1.AsyncOperation_STATE_FAILED,
+				FailureReason: "bucket not found",
+			}}, nil),
+	)
+	err = CreateExportSink(context.Background(), client, "ns.a1b2c", spec, Options{})
+	if err == nil || errors.As(err, &validationErr) || !strings.Contains(err.Error(), `failed to create export sink "history": async operation "op" did not fulfill: state=STATE_FAILED reason="bucket not found"`) {
+		t.Errorf("CreateExportSink() error = %v, want the failed creation", err)
+	}
+}
+
+func TestCreateAuditLogSink(t *testing.T) {
+	kinesis, err := Kinesis{StreamARN: "arn:aws:kinesis:us-west-2:123456789012:stream/audit", Role: "temporal"}.AuditLogSinkSpec("audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubsub, err := PubSub{Topic: "projects/my-project/topics/audit", ServiceAccount: "temporal-sink"}.AuditLogSinkSpec("audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := cloudservicemock.NewMockCloudServiceClient(gomock.NewController(t))
+	gomock.InOrder(
+		// The Kinesis sink fails its validation, and is not created.
+		client.EXPECT().
+			ValidateAccountAuditLogSink(gomock.Any(), cloudservicemock.EqualProto(&cloudservicev1.ValidateAccountAuditLogSinkRequest{Spec: kinesis})).
+			Return(nil, status.Error(codes.InvalidArgument, "access denied on stream audit")),
+		// The Pub/Sub sink is validated and created.
+		client.EXPECT().
+			ValidateAccountAuditLogSink(gomock.Any(), cloudservicemock.EqualProto(&cloudservicev1.ValidateAccountAuditLogSinkRequest{Spec: pubsub})).
+			Return(&cloudservicev1.ValidateAccountAuditLogSinkResponse{}, nil),
+		client.EXPECT().
+			CreateAccountAuditLogSink(gomock.Any(), cloudservicemock.EqualProto(&cloudservicev1.CreateAccountAuditLogSinkRequest{Spec: pubsub, AsyncOperationId: "create-audit"})).
+			Return(&cloudservicev1.CreateAccountAuditLogSinkResponse{AsyncOperation: &operationv1.AsyncOperation{Id: "create-audit"}}, nil),
+		client.EXPECT().
+			GetAsyncOperation(gomock.Any(), cloudservicemock.EqualProto(&cloudservicev1.GetAsyncOperationRequest{AsyncOperationId: "create-audit"})).
+			Return(&cloudservicev1.GetAsyncOperationResponse{AsyncOperation: &operationv1.AsyncOperation{
+				Id:    "create-audit",
+				State: operationv1.AsyncOperation_STATE_FULFILLED,
+			}}, nil),
+		// The Kinesis sink cannot be validated, Temporal Cloud rejecting the credentials.
+		client.EXPECT().
+			ValidateAccountAuditLogSink(gomock.Any(), cloudservicemock.EqualProto(&cloudservicev1.ValidateAccountAuditLogSinkRequest{Spec: kinesis})).
+			Return(nil, status.Error(codes.Unauthenticated, "invalid api key")),
+	)
+
+	err = CreateAuditLogSink(context.Background(), client, kinesis, Options{})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Sink != "audit" || validationErr.Reason != "access denied on stream audit" {
+		t.Fatalf("CreateAuditLogSink() error = %v, want a validation error", err)
+	}
+	if err := CreateAuditLogSink(context.Background(), client, pubsub, Options{AsyncOperationID: "create-audit"}); err != nil {
+		t.Fatalf("CreateAuditLogSink() error = %v, want the sink created", err)
+	}
+	err = CreateAuditLogSink(context.Background(), client, kinesis, Options{})
+	if err == nil || errors.As(err, &validationErr) || status.Code(errors.Unwrap(err)) != codes.Unauthenticated {
+		t.Errorf("CreateAuditLogSink() error = %v, want the unauthenticated error", err)
+	}
+}
@@ -0,0 +1,152 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	accountv1 "go.temporal.io/cloud-sdk/api/account/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	connectivityrulev1 "go.temporal.io/cloud-sdk/api/connectivityrule/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	nexusv1 "go.temporal.io/cloud-sdk/api/nexus/v1"
+	"go.temporal.io/cloud-sdk/internal/paging"
+)
+
+// Export takes a snapshot of the account the client is connected to and writes it to the given directory.
+func Export(ctx context.Context, client cloudservicev1.CloudServiceClient, dir string) (*Snapshot, error) {
+	s, err := Take(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.WriteDir(dir); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Take reads every exportable resource of the account the client is connected to.
+func Take(ctx context.Context, client cloudservicev1.CloudServiceClient) (*Snapshot, error) {
+	s := &Snapshot{
+		Manifest: Manifest{
+			FormatVersion: FormatVersion,
+			CreatedTime:   time.Now().UTC(),
+		},
+		NamespaceExportSinks: map[string][]*namespacev1.ExportSink{},
+		UserGroupMembers:     map[string][]*identityv1.UserGroupMember{},
+	}
+
+	accountResp, err := client.GetAccount(ctx, &cloudservicev1.GetAccountRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	s.Account = accountResp.GetAccount()
+	s.Manifest.AccountID = s.Account.GetId()
+
+	s.Namespaces, err = paging.All(ctx, func(ctx context.Context, pageToken string) ([]*namespacev1.Namespace, string, error) {
+		resp, err := client.GetNamespaces(ctx, &cloudservicev1.GetNamespacesRequest{PageToken: pageToken})
+		return resp.GetNamespaces(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespaces: %w", err)
+	}
+	for _, ns := range s.Namespaces {
+		name := ns.GetNamespace()
+		sinks, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*namespacev1.ExportSink, string, error) {
+			resp, err := client.GetNamespaceExportSinks(ctx, &cloudservicev1.GetNamespaceExportSinksRequest{
+				Namespace: name,
+				PageToken: pageToken,
+			})
+			return resp.GetSinks(), resp.GetNextPageToken(), err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get export sinks of namespace %q: %w", name, err)
+		}
+		if len(sinks) > 0 {
+			s.NamespaceExportSinks[name] = sinks
+		}
+	}
+
+	s.Users, err = paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.User, string, error) {
+		resp, err := client.GetUsers(ctx, &cloudservicev1.GetUsersRequest{PageToken: pageToken})
+		return resp.GetUsers(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	s.UserGroups, err = paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.UserGroup, string, error) {
+		resp, err := client.GetUserGroups(ctx, &cloudservicev1.GetUserGroupsRequest{PageToken: pageToken})
+		return resp.GetGroups(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+	for _, group := range s.UserGroups {
+		groupID := group.GetId()
+		members, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.UserGroupMember, string, error) {
+			resp, err := client.GetUserGroupMembers(ctx, &cloudservicev1.GetUserGroupMembersRequest{
+				GroupId:   groupID,
+				PageToken: pageToken,
+			})
+			return resp.GetMembers(), resp.GetNextPageToken(), err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get members of user group %q: %w", groupID, err)
+		}
+		if len(members) > 0 {
+			s.UserGroupMembers[groupID] = members
+		}
+	}
+
+	s.ServiceAccounts, err = paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.ServiceAccount, string, error) {
+		resp, err := client.GetServiceAccounts(ctx, &cloudservicev1.GetServiceAccountsRequest{PageToken: pageToken})
+		return resp.GetServiceAccount(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service accounts: %w", err)
+	}
+
+	s.APIKeys, err = paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.ApiKey, string, error) {
+		resp, err := client.GetApiKeys(ctx, &cloudservicev1.GetApiKeysRequest{PageToken: pageToken})
+		return resp.GetApiKeys(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+
+	s.CustomRoles, err = paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.CustomRole, string, error) {
+		resp, err := client.GetCustomRoles(ctx, &cloudservicev1.GetCustomRolesRequest{PageToken: pageToken})
+		return resp.GetCustomRoles(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom roles: %w", err)
+	}
+
+	s.NexusEndpoints, err = paging.All(ctx, func(ctx context.Context, pageToken string) ([]*nexusv1.Endpoint, string, error) {
+		resp, err := client.GetNexusEndpoints(ctx, &cloudservicev1.GetNexusEndpointsRequest{PageToken: pageToken})
+		return resp.GetEndpoints(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nexus endpoints: %w", err)
+	}
+
+	s.ConnectivityRules, err = paging.All(ctx, func(ctx context.Context, pageToken string) ([]*connectivityrulev1.ConnectivityRule, string, error) {
+		resp, err := client.GetConnectivityRules(ctx, &cloudservicev1.GetConnectivityRulesRequest{PageToken: pageToken})
+		return resp.GetConnectivityRules(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connectivity rules: %w", err)
+	}
+
+	s.AuditLogSinks, err = paging.All(ctx, func(ctx context.Context, pageToken string) ([]*accountv1.AuditLogSink, string, error) {
+		resp, err := client.GetAccountAuditLogSinks(ctx, &cloudservicev1.GetAccountAuditLogSinksRequest{PageToken: pageToken})
+		return resp.GetSinks(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit log sinks: %w", err)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,561 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	nexusv1 "go.temporal.io/cloud-sdk/api/nexus/v1"
+	operationv1 "go.temporal.io/cloud-sdk/api/operation/v1"
+	resourcev1 "go.temporal.io/cloud-sdk/api/resource/v1"
+	"go.temporal.io/cloud-sdk/internal/asyncop"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// The kinds of resources reported in an import result.
+const (
+	KindAccount          = "account"
+	KindNamespace        = "namespace"
+	KindExportSink       = "namespace_export_sink"
+	KindUser             = "user"
+	KindUserGroup        = "user_group"
+	KindUserGroupMember  = "user_group_member"
+	KindServiceAccount   = "service_account"
+	KindAPIKey           = "api_key"
+	KindCustomRole       = "custom_role"
+	KindNexusEndpoint    = "nexus_endpoint"
+	KindConnectivityRule = "connectivity_rule"
+	KindAuditLogSink     = "audit_log_sink"
+)
+
+// namespaceResourceType is the resource type of the custom role permissions that apply to namespaces.
+const namespaceResourceType = "namespace"
+
+type (
+	// ImportOptions configure how a snapshot is imported into an account.
+	ImportOptions struct {
+		// NamespaceName returns the name to give in the target account to a namespace of the snapshot.
+		// It receives the namespace name from the namespace spec, without the account suffix.
+		// If not provided, namespaces keep their name.
+		NamespaceName func(name string) string
+
+		// The interval between two checks of an async operation.
+		// If not provided, the interval suggested by the server is used.
+		PollInterval time.Duration
+	}
+
+	// IDMapping maps the ids of the resources of a snapshot to the ids of the resources created from them.
+	IDMapping struct {
+		Namespaces        map[string]string
+		Users             map[string]string
+		UserGroups        map[string]string
+		ServiceAccounts   map[string]string
+		CustomRoles       map[string]string
+		NexusEndpoints    map[string]string
+		ConnectivityRules map[string]string
+	}
+
+	// SkippedResource is a resource of a snapshot that was not imported.
+	SkippedResource struct {
+		// The kind of the resource, one of the Kind constants.
+		Kind string
+		// The id of the resource in the snapshot.
+		ID string
+		// Why the resource was not imported.
+		Reason string
+	}
+
+	// ImportResult reports what an import created.
+	ImportResult struct {
+		IDs     IDMapping
+		Skipped []SkippedResource
+	}
+
+	importer struct {
+		client  cloudservicev1.CloudServiceClient
+		options ImportOptions
+		result  *ImportResult
+	}
+)
+
+// Import reads the snapshot in the given directory and recreates its resources in the account the client is connected to.
+// See Apply for details.
+func Import(
+	ctx context.Context,
+	client cloudservicev1.CloudServiceClient,
+	dir string,
+	options ImportOptions,
+) (*ImportResult, error) {
+	s, err := ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	return Apply(ctx, client, s, options)
+}
+
+// Apply recreates the resources of a snapshot in the account the client is connected to.
+//
+// Resources are created in dependency order, and the ids they reference are remapped to the ids of the
+// resources created in the target account: the namespace accesses and custom roles of users, groups and
+// service accounts, the connectivity rules of namespaces, the namespaces custom roles grant permissions on,
+// and the target and allowed namespaces of Nexus endpoints.
+// Every create operation is awaited before moving on.
+//
+// The account spec and API keys are never imported, API keys since their secret cannot be carried over.
+// Deleted resources, resources referencing a resource that was not imported, and members of groups
+// that are not managed by Temporal Cloud are skipped and reported in the result.
+// Users that already exist in the target account are reused.
+//
+// On error, the result reports what was created before the failure.
+func Apply(
+	ctx context.Context,
+	client cloudservicev1.CloudServiceClient,
+	s *Snapshot,
+	options ImportOptions,
+) (*ImportResult, error) {
+	i := &importer{
+		client:  client,
+		options: options,
+		result: &ImportResult{
+			IDs: IDMapping{
+				Namespaces:        map[string]string{},
+				Users:             map[string]string{},
+				UserGroups:        map[string]string{},
+				ServiceAccounts:   map[string]string{},
+				CustomRoles:       map[string]string{},
+				NexusEndpoints:    map[string]string{},
+				ConnectivityRules: map[string]string{},
+			},
+		},
+	}
+
+	if s.Account != nil {
+		i.skip(KindAccount, s.Account.GetId(), "account settings are not imported")
+	}
+	for _, key := range s.APIKeys {
+		i.skip(KindAPIKey, key.GetId(), "api key secrets cannot be exported")
+	}
+
+	steps := []func(context.Context, *Snapshot) error{
+		i.importConnectivityRules,
+		i.importNamespaces,
+		i.importExportSinks,
+		i.importCustomRoles,
+		i.importUsers,
+		i.importUserGroups,
+		i.importServiceAccounts,
+		i.importNexusEndpoints,
+		i.importAuditLogSinks,
+	}
+	for _, step := range steps {
+		if err := step(ctx, s); err != nil {
+			return i.result, err
+		}
+	}
+	return i.result, nil
+}
+
+func (i *importer) skip(kind, id, reason string) {
+	i.result.Skipped = append(i.result.Skipped, SkippedResource{Kind: kind, ID: id, Reason: reason})
+}
+
+// skipDeleted reports and returns true if the resource is deleted, or being deleted.
+func (i *importer) skipDeleted(kind, id string, state resourcev1.ResourceState) bool {
+	switch state {
+	case resourcev1.ResourceState_RESOURCE_STATE_DELETED,
+		resourcev1.ResourceState_RESOURCE_STATE_DELETING:
+		i.skip(kind, id, fmt.Sprintf("resource is in state %s", state))
+		return true
+	}
+	return false
+}
+
+func (i *importer) wait(ctx context.Context, op *operationv1.AsyncOperation) error {
+	_, err := asyncop.Wait(ctx, i.client, op.GetId(), i.options.PollInterval)
+	return err
+}
+
+func (i *importer) importCustomRoles(ctx context.Context, s *Snapshot) error {
+	for _, role := range s.CustomRoles {
+		if i.skipDeleted(KindCustomRole, role.GetId(), role.GetState()) {
+			continue
+		}
+		if role.GetSpec() == nil {
+			return fmt.Errorf("custom role %q has no spec", role.GetId())
+		}
+		spec := proto.Clone(role.GetSpec()).(*identityv1.CustomRoleSpec)
+		if err := i.result.IDs.remapCustomRoleSpec(spec); err != nil {
+			i.skip(KindCustomRole, role.GetId(), err.Error())
+			continue
+		}
+		resp, err := i.client.CreateCustomRole(ctx, &cloudservicev1.CreateCustomRoleRequest{
+			Spec: spec,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create custom role %q: %w", role.GetId(), err)
+		}
+		if err := i.wait(ctx, resp.GetAsyncOperation()); err != nil {
+			return fmt.Errorf("failed to create custom role %q: %w", role.GetId(), err)
+		}
+		i.result.IDs.CustomRoles[role.GetId()] = resp.GetRoleId()
+	}
+	return nil
+}
+
+func (i *importer) importConnectivityRules(ctx context.Context, s *Snapshot) error {
+	for _, rule := range s.ConnectivityRules {
+		if i.skipDeleted(KindConnectivityRule, rule.GetId(), rule.GetState()) {
+			continue
+		}
+		if rule.GetSpec() == nil {
+			return fmt.Errorf("connectivity rule %q has no spec", rule.GetId())
+		}
+		resp, err := i.client.CreateConnectivityRule(ctx, &cloudservicev1.CreateConnectivityRuleRequest{
+			Spec: rule.GetSpec(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create connectivity rule %q: %w", rule.GetId(), err)
+		}
+		if err := i.wait(ctx, resp.GetAsyncOperation()); err != nil {
+			return fmt.Errorf("failed to create connectivity rule %q: %w", rule.GetId(), err)
+		}
+		i.result.IDs.ConnectivityRules[rule.GetId()] = resp.GetConnectivityRuleId()
+	}
+	return nil
+}
+
+func (i *importer) importNamespaces(ctx context.Context, s *Snapshot) error {
+	for _, ns := range s.Namespaces {
+		id := ns.GetNamespace()
+		if i.skipDeleted(KindNamespace, id, ns.GetState()) {
+			continue
+		}
+		if ns.GetSpec() == nil {
+			return fmt.Errorf("namespace %q has no spec", id)
+		}
+		spec := proto.Clone(ns.GetSpec()).(*namespacev1.NamespaceSpec)
+		if i.options.NamespaceName != nil {
+			spec.Name = i.options.NamespaceName(spec.GetName())
+		}
+		ruleIDs, err := remapAll(i.result.IDs.ConnectivityRules, spec.GetConnectivityRuleIds(), KindConnectivityRule)
+		if err != nil {
+			i.skip(KindNamespace, id, err.Error())
+			continue
+		}
+		spec.ConnectivityRuleIds = ruleIDs
+
+		resp, err := i.client.CreateNamespace(ctx, &cloudservicev1.CreateNamespaceRequest{
+			Spec: spec,
+			Tags: ns.GetTags(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create namespace %q: %w", id, err)
+		}
+		if err := i.wait(ctx, resp.GetAsyncOperation()); err != nil {
+			return fmt.Errorf("failed to create namespace %q: %w", id, err)
+		}
+		i.result.IDs.Namespaces[id] = resp.GetNamespace()
+	}
+	return nil
+}
+
+func (i *importer) importExportSinks(ctx context.Context, s *Snapshot) error {
+	for _, ns := range slices.Sorted(maps.Keys(s.NamespaceExportSinks)) {
+		for _, sink := range s.NamespaceExportSinks[ns] {
+			id := ns + "/" + sink.GetName()
+			if i.skipDeleted(KindExportSink, id, sink.GetState()) {
+				continue
+			}
+			if sink.GetSpec() == nil {
+				return fmt.Errorf("export sink %q has no spec", id)
+			}
+			target, ok := i.result.IDs.Namespaces[ns]
+			if !ok {
+				i.skip(KindExportSink, id, fmt.Sprintf("%s %q was not imported", KindNamespace, ns))
+				continue
+			}
+			resp, err := i.client.CreateNamespaceExportSink(ctx, &cloudservicev1.CreateNamespaceExportSinkRequest{
+				Namespace: target,
+				Spec:      sink.GetSpec(),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create export sink %q: %w", id, err)
+			}
+			if err := i.wait(ctx, resp.GetAsyncOperation()); err != nil {
+				return fmt.Errorf("failed to create export sink %q: %w", id, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (i *importer) importUsers(ctx context.Context, s *Snapshot) error {
+	for _, user := range s.Users {
+		if i.skipDeleted(KindUser, user.GetId(), user.GetState()) {
+			continue
+		}
+		if user.GetSpec() == nil {
+			return fmt.Errorf("user %q has no spec", user.GetId())
+		}
+		access, err := i.result.IDs.remapAccess(user.GetSpec().GetAccess())
+		if err != nil {
+			i.skip(KindUser, user.GetId(), err.Error())
+			continue
+		}
+		resp, err := i.client.CreateUser(ctx, &cloudservicev1.CreateUserRequest{
+			Spec: &identityv1.UserSpec{
+				Email:  user.GetSpec().GetEmail(),
+				Access: access,
+			},
+		})
+		if status.Code(err) == codes.AlreadyExists {
+			// the user, most likely the owner of the api key used for the import, already exists: reuse it.
+			existing, err := i.client.GetUsers(ctx, &cloudservicev1.GetUsersRequest{
+				Email: user.GetSpec().GetEmail(),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to look up existing user %q: %w", user.GetId(), err)
+			}
+			if len(existing.GetUsers()) == 0 {
+				return fmt.Errorf("user %q reported as existing but not found by email %q", user.GetId(), user.GetSpec().GetEmail())
+			}
+			i.result.IDs.Users[user.GetId()] = existing.GetUsers()[0].GetId()
+			i.skip(KindUser, user.GetId(), "user already exists, its access was left unchanged")
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to create user %q: %w", user.GetId(), err)
+		}
+		if err := i.wait(ctx, resp.GetAsyncOperation()); err != nil {
+			return fmt.Errorf("failed to create user %q: %w", user.GetId(), err)
+		}
+		i.result.IDs.Users[user.GetId()] = resp.GetUserId()
+	}
+	return nil
+}
+
+func (i *importer) importUserGroups(ctx context.Context, s *Snapshot) error {
+	for _, group := range s.UserGroups {
+		if i.skipDeleted(KindUserGroup, group.GetId(), group.GetState()) {
+			continue
+		}
+		if group.GetSpec() == nil {
+			return fmt.Errorf("user group %q has no spec", group.GetId())
+		}
+		access, err := i.result.IDs.remapAccess(group.GetSpec().GetAccess())
+		if err != nil {
+			i.skip(KindUserGroup, group.GetId(), err.Error())
+			continue
+		}
+		spec := proto.Clone(group.GetSpec()).(*identityv1.UserGroupSpec)
+		spec.Access = access
+		resp, err := i.client.CreateUserGroup(ctx, &cloudservicev1.CreateUserGroupRequest{
+			Spec: spec,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create user group %q: %w", group.GetId(), err)
+		}
+		if err := i.wait(ctx, resp.GetAsyncOperation()); err != nil {
+			return fmt.Errorf("failed to create user group %q: %w", group.GetId(), err)
+		}
+		groupID := resp.GetGroupId()
+		i.result.IDs.UserGroups[group.GetId()] = groupID
+
+		for _, member := range s.UserGroupMembers[group.GetId()] {
+			memberID := group.GetId() + "/" + member.GetMemberId().GetUserId()
+			if spec.GetCloudGroup() == nil {
+				// the members of google and scim groups are managed by the identity provider.
+				i.skip(KindUserGroupMember, memberID, "members of the group are managed by an identity provider")
+				continue
+			}
+			userID, ok := i.result.IDs.Users[member.GetMemberId().GetUserId()]
+			if !ok {
+				i.skip(KindUserGroupMember, memberID, fmt.Sprintf("%s %q was not imported", KindUser, member.GetMemberId().GetUserId()))
+				continue
+			}
+			resp, err := i.client.AddUserGroupMember(ctx, &cloudservicev1.AddUserGroupMemberRequest{
+				GroupId: groupID,
+				MemberId: &identityv1.UserGroupMemberId{
+					MemberType: &identityv1.UserGroupMemberId_UserId{UserId: userID},
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to add member %q: %w", memberID, err)
+			}
+			if err := i.wait(ctx, resp.GetAsyncOperation()); err != nil {
+				return fmt.Errorf("failed to add member %q: %w", memberID, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (i *importer) importServiceAccounts(ctx context.Context, s *Snapshot) error {
+	for _, sa := range s.ServiceAccounts {
+		if i.skipDeleted(KindServiceAccount, sa.GetId(), sa.GetState()) {
+			continue
+		}
+		if sa.GetSpec() == nil {
+			return fmt.Errorf("service account %q has no spec", sa.GetId())
+		}
+		spec := proto.Clone(sa.GetSpec()).(*identityv1.ServiceAccountSpec)
+		access, err := i.result.IDs.remapAccess(spec.GetAccess())
+		if err != nil {
+			i.skip(KindServiceAccount, sa.GetId(), err.Error())
+			continue
+		}
+		spec.Access = access
+		if scoped := spec.GetNamespaceScopedAccess(); scoped != nil {
+			ns, ok := i.result.IDs.Namespaces[scoped.GetNamespace()]
+			if !ok {
+				i.skip(KindServiceAccount, sa.GetId(), fmt.Sprintf("%s %q was not imported", KindNamespace, scoped.GetNamespace()))
+				continue
+			}
+			scoped.Namespace = ns
+		}
+		resp, err := i.client.CreateServiceAccount(ctx, &cloudservicev1.CreateServiceAccountRequest{
+			Spec: spec,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create service account %q: %w", sa.GetId(), err)
+		}
+		if err := i.wait(ctx, resp.GetAsyncOperation()); err != nil {
+			return fmt.Errorf("failed to create service account %q: %w", sa.GetId(), err)
+		}
+		i.result.IDs.ServiceAccounts[sa.GetId()] = resp.GetServiceAccountId()
+	}
+	return nil
+}
+
+func (i *importer) importNexusEndpoints(ctx context.Context, s *Snapshot) error {
+	for _, endpoint := range s.NexusEndpoints {
+		if i.skipDeleted(KindNexusEndpoint, endpoint.GetId(), endpoint.GetState()) {
+			continue
+		}
+		if endpoint.GetSpec() == nil {
+			return fmt.Errorf("nexus endpoint %q has no spec", endpoint.GetId())
+		}
+		spec := proto.Clone(endpoint.GetSpec()).(*nexusv1.EndpointSpec)
+		if err := i.result.IDs.remapEndpointSpec(spec); err != nil {
+			i.skip(KindNexusEndpoint, endpoint.GetId(), err.Error())
+			continue
+		}
+		resp, err := i.client.CreateNexusEndpoint(ctx, &cloudservicev1.CreateNexusEndpointRequest{
+			Spec: spec,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create nexus endpoint %q: %w", endpoint.GetId(), err)
+		}
+		if err := i.wait(ctx, resp.GetAsyncOperation()); err != nil {
+			return fmt.Errorf("failed to create nexus endpoint %q: %w", endpoint.GetId(), err)
+		}
+		i.result.IDs.NexusEndpoints[endpoint.GetId()] = resp.GetEndpointId()
+	}
+	return nil
+}
+
+func (i *importer) importAuditLogSinks(ctx context.Context, s *Snapshot) error {
+	for _, sink := range s.AuditLogSinks {
+		if i.skipDeleted(KindAuditLogSink, sink.GetName(), sink.GetState()) {
+			continue
+		}
+		if sink.GetSpec() == nil {
+			return fmt.Errorf("audit log sink %q has no spec", sink.GetName())
+		}
+		resp, err := i.client.CreateAccountAuditLogSink(ctx, &cloudservicev1.CreateAccountAuditLogSinkRequest{
+			Spec: sink.GetSpec(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create audit log sink %q: %w", sink.GetName(), err)
+		}
+		if err := i.wait(ctx, resp.GetAsyncOperation()); err != nil {
+			return fmt.Errorf("failed to create audit log sink %q: %w", sink.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// remapAccess returns a copy of the access with its namespaces and custom roles remapped.
+func (m *IDMapping) remapAccess(access *identityv1.Access) (*identityv1.Access, error) {
+	if access == nil {
+		return nil, nil
+	}
+	out := proto.Clone(access).(*identityv1.Access)
+	if accountAccess := out.GetAccountAccess(); accountAccess != nil {
+		roles, err := remapAll(m.CustomRoles, accountAccess.GetCustomRoles(), KindCustomRole)
+		if err != nil {
+			return nil, err
+		}
+		accountAccess.CustomRoles = roles
+	}
+	if len(out.GetNamespaceAccesses()) > 0 {
+		accesses := make(map[string]*identityv1.NamespaceAccess, len(out.GetNamespaceAccesses()))
+		for ns, nsAccess := range out.GetNamespaceAccesses() {
+			target, ok := m.Namespaces[ns]
+			if !ok {
+				return nil, fmt.Errorf("%s %q was not imported", KindNamespace, ns)
+			}
+			accesses[target] = nsAccess
+		}
+		out.NamespaceAccesses = accesses
+	}
+	return out, nil
+}
+
+// remapCustomRoleSpec remaps, in place, the namespaces the permissions of a custom role spec apply to.
+func (m *IDMapping) remapCustomRoleSpec(spec *identityv1.CustomRoleSpec) error {
+	for _, permission := range spec.GetPermissions() {
+		resources := permission.GetResources()
+		if resources.GetResourceType() != namespaceResourceType {
+			continue
+		}
+		ids, err := remapAll(m.Namespaces, resources.GetResourceIds(), KindNamespace)
+		if err != nil {
+			return err
+		}
+		resources.ResourceIds = ids
+	}
+	return nil
+}
+
+// remapEndpointSpec remaps, in place, the target and allowed namespaces of an endpoint spec.
+func (m *IDMapping) remapEndpointSpec(spec *nexusv1.EndpointSpec) error {
+	if worker := spec.GetTargetSpec().GetWorkerTargetSpec(); worker != nil {
+		target, ok := m.Namespaces[worker.GetNamespaceId()]
+		if !ok {
+			return fmt.Errorf("%s %q was not imported", KindNamespace, worker.GetNamespaceId())
+		}
+		worker.NamespaceId = target
+	}
+	for _, policy := range spec.GetPolicySpecs() {
+		if allowed := policy.GetAllowedCloudNamespacePolicySpec(); allowed != nil {
+			target, ok := m.Namespaces[allowed.GetNamespaceId()]
+			if !ok {
+				return fmt.Errorf("%s %q was not imported", KindNamespace, allowed.GetNamespaceId())
+			}
+			allowed.NamespaceId = target
+		}
+	}
+	return nil
+}
+
+func remapAll(mapping map[string]string, ids []string, kind string) ([]string, error) {
+	if len(ids) == 0 {
+		return ids, nil
+	}
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		target, ok := mapping[id]
+		if !ok {
+			return nil, fmt.Errorf("%s %q was not imported", kind, id)
+		}
+		out = append(out, target)
+	}
+	return out, nil
+}
@@ -0,0 +1,356 @@
+// Package snapshot exports the resources of a Temporal Cloud account into a directory of protojson files,
+// and imports such a directory into another account.
+//
+// WARNING: The package is currently experimental.
+//
+// A snapshot directory has the following layout, every file holding a single protojson encoded message:
+//
+//	manifest.json
+//	account.json
+//	namespaces/<namespace>.json
+//	namespace_export_sinks/<namespace>/<sink>.json
+//	users/<user id>.json
+//	user_groups/<group id>.json
+//	user_group_members/<group id>/<user id>.json
+//	service_accounts/<service account id>.json
+//	api_keys/<api key id>.json
+//	custom_roles/<role id>.json
+//	nexus_endpoints/<endpoint id>.json
+//	connectivity_rules/<connectivity rule id>.json
+//	audit_log_sinks/<sink>.json
+//
+// API keys are exported without their secret, which the cloud operations API never returns.
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	accountv1 "go.temporal.io/cloud-sdk/api/account/v1"
+	connectivityrulev1 "go.temporal.io/cloud-sdk/api/connectivityrule/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	nexusv1 "go.temporal.io/cloud-sdk/api/nexus/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// FormatVersion is the version of the snapshot directory layout written by this package.
+	FormatVersion = 1
+
+	manifestFile = "manifest.json"
+	accountFile  = "account.json"
+
+	namespacesDir           = "namespaces"
+	namespaceExportSinksDir = "namespace_export_sinks"
+	usersDir                = "users"
+	userGroupsDir           = "user_groups"
+	userGroupMembersDir     = "user_group_members"
+	serviceAccountsDir      = "service_accounts"
+	apiKeysDir              = "api_keys"
+	customRolesDir          = "custom_roles"
+	nexusEndpointsDir       = "nexus_endpoints"
+	connectivityRulesDir    = "connectivity_rules"
+	auditLogSinksDir        = "audit_log_sinks"
+
+	fileExtension = ".json"
+)
+
+// kindDirs are the directories of the resources of a snapshot.
+var kindDirs = []string{
+	namespacesDir,
+	namespaceExportSinksDir,
+	usersDir,
+	userGroupsDir,
+	userGroupMembersDir,
+	serviceAccountsDir,
+	apiKeysDir,
+	customRolesDir,
+	nexusEndpointsDir,
+	connectivityRulesDir,
+	auditLogSinksDir,
+}
+
+var (
+	marshalOptions = protojson.MarshalOptions{
+		Multiline: true,
+		Indent:    "  ",
+	}
+	// unknown fields are discarded so that snapshots written by a newer API version can still be read.
+	unmarshalOptions = protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	}
+)
+
+type (
+	// Manifest describes a snapshot.
+	Manifest struct {
+		// The version of the snapshot directory layout.
+		FormatVersion int `json:"format_version"`
+		// The id of the account the snapshot was taken from.
+		AccountID string `json:"account_id"`
+		// The time the snapshot was taken at.
+		CreatedTime time.Time `json:"created_time"`
+	}
+
+	// Snapshot holds the resources of a Temporal Cloud account.
+	Snapshot struct {
+		Manifest Manifest
+
+		Account              *accountv1.Account
+		Namespaces           []*namespacev1.Namespace
+		NamespaceExportSinks map[string][]*namespacev1.ExportSink // keyed by namespace
+		Users                []*identityv1.User
+		UserGroups           []*identityv1.UserGroup
+		UserGroupMembers     map[string][]*identityv1.UserGroupMember // keyed by group id
+		ServiceAccounts      []*identityv1.ServiceAccount
+		APIKeys              []*identityv1.ApiKey
+		CustomRoles          []*identityv1.CustomRole
+		NexusEndpoints       []*nexusv1.Endpoint
+		ConnectivityRules    []*connectivityrulev1.ConnectivityRule
+		AuditLogSinks        []*accountv1.AuditLogSink
+	}
+)
+
+// WriteDir writes the snapshot to the given directory, creating it if needed.
+// The resources of a snapshot previously written to the directory are removed first, so that ReadDir does not
+// read back the resources deleted since. Other files of the directory are left unchanged.
+func (s *Snapshot) WriteDir(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	if err := os.Remove(filepath.Join(dir, accountFile)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove %s: %w", accountFile, err)
+	}
+	for _, kindDir := range kindDirs {
+		if err := os.RemoveAll(filepath.Join(dir, kindDir)); err != nil {
+			return fmt.Errorf("failed to remove directory %s: %w", kindDir, err)
+		}
+	}
+
+	manifest, err := json.MarshalIndent(s.Manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, manifestFile), manifest, 0o644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if s.Account != nil {
+		if err := writeMessage(filepath.Join(dir, accountFile), s.Account); err != nil {
+			return err
+		}
+	}
+
+	if err := writeMessages(filepath.Join(dir, namespacesDir), s.Namespaces, (*namespacev1.Namespace).GetNamespace); err != nil {
+		return err
+	}
+	for ns, sinks := range s.NamespaceExportSinks {
+		if err := writeMessages(filepath.Join(dir, namespaceExportSinksDir, fileName(ns)), sinks, (*namespacev1.ExportSink).GetName); err != nil {
+			return err
+		}
+	}
+	if err := writeMessages(filepath.Join(dir, usersDir), s.Users, (*identityv1.User).GetId); err != nil {
+		return err
+	}
+	if err := writeMessages(filepath.Join(dir, userGroupsDir), s.UserGroups, (*identityv1.UserGroup).GetId); err != nil {
+		return err
+	}
+	for groupID, members := range s.UserGroupMembers {
+		if err := writeMessages(filepath.Join(dir, userGroupMembersDir, fileName(groupID)), members, memberUserID); err != nil {
+			return err
+		}
+	}
+	if err := writeMessages(filepath.Join(dir, serviceAccountsDir), s.ServiceAccounts, (*identityv1.ServiceAccount).GetId); err != nil {
+		return err
+	}
+	if err := writeMessages(filepath.Join(dir, apiKeysDir), s.APIKeys, (*identityv1.ApiKey).GetId); err != nil {
+		return err
+	}
+	if err := writeMessages(filepath.Join(dir, customRolesDir), s.CustomRoles, (*identityv1.CustomRole).GetId); err != nil {
+		return err
+	}
+	if err := writeMessages(filepath.Join(dir, nexusEndpointsDir), s.NexusEndpoints, (*nexusv1.Endpoint).GetId); err != nil {
+		return err
+	}
+	if err := writeMessages(filepath.Join(dir, connectivityRulesDir), s.ConnectivityRules, (*connectivityrulev1.ConnectivityRule).GetId); err != nil {
+		return err
+	}
+	return writeMessages(filepath.Join(dir, auditLogSinksDir), s.AuditLogSinks, (*accountv1.AuditLogSink).GetName)
+}
+
+// ReadDir reads a snapshot previously written by WriteDir.
+func ReadDir(dir string) (*Snapshot, error) {
+	s := &Snapshot{
+		NamespaceExportSinks: map[string][]*namespacev1.ExportSink{},
+		UserGroupMembers:     map[string][]*identityv1.UserGroupMember{},
+	}
+
+	manifest, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	if err := json.Unmarshal(manifest, &s.Manifest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal manifest: %w", err)
+	}
+	if s.Manifest.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot format version %d, at most %d is supported",
+			s.Manifest.FormatVersion, FormatVersion)
+	}
+
+	s.Account = &accountv1.Account{}
+	if err := readMessage(filepath.Join(dir, accountFile), s.Account); errors.Is(err, fs.ErrNotExist) {
+		s.Account = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if s.Namespaces, err = readMessages[*namespacev1.Namespace](filepath.Join(dir, namespacesDir)); err != nil {
+		return nil, err
+	}
+	sinkNamespaces, err := subDirs(filepath.Join(dir, namespaceExportSinksDir))
+	if err != nil {
+		return nil, err
+	}
+	for _, ns := range sinkNamespaces {
+		if s.NamespaceExportSinks[ns], err = readMessages[*namespacev1.ExportSink](filepath.Join(dir, namespaceExportSinksDir, fileName(ns))); err != nil {
+			return nil, err
+		}
+	}
+	if s.Users, err = readMessages[*identityv1.User](filepath.Join(dir, usersDir)); err != nil {
+		return nil, err
+	}
+	if s.UserGroups, err = readMessages[*identityv1.UserGroup](filepath.Join(dir, userGroupsDir)); err != nil {
+		return nil, err
+	}
+	memberGroups, err := subDirs(filepath.Join(dir, userGroupMembersDir))
+	if err != nil {
+		return nil, err
+	}
+	for _, groupID := range memberGroups {
+		if s.UserGroupMembers[groupID], err = readMessages[*identityv1.UserGroupMember](filepath.Join(dir, userGroupMembersDir, fileName(groupID))); err != nil {
+			return nil, err
+		}
+	}
+	if s.ServiceAccounts, err = readMessages[*identityv1.ServiceAccount](filepath.Join(dir, serviceAccountsDir)); err != nil {
+		return nil, err
+	}
+	if s.APIKeys, err = readMessages[*identityv1.ApiKey](filepath.Join(dir, apiKeysDir)); err != nil {
+		return nil, err
+	}
+	if s.CustomRoles, err = readMessages[*identityv1.CustomRole](filepath.Join(dir, customRolesDir)); err != nil {
+		return nil, err
+	}
+	if s.NexusEndpoints, err = readMessages[*nexusv1.Endpoint](filepath.Join(dir, nexusEndpointsDir)); err != nil {
+		return nil, err
+	}
+	if s.ConnectivityRules, err = readMessages[*connectivityrulev1.ConnectivityRule](filepath.Join(dir, connectivityRulesDir)); err != nil {
+		return nil, err
+	}
+	if s.AuditLogSinks, err = readMessages[*accountv1.AuditLogSink](filepath.Join(dir, auditLogSinksDir)); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func memberUserID(m *identityv1.UserGroupMember) string {
+	return m.GetMemberId().GetUserId()
+}
+
+// fileName escapes a resource identifier so that it can safely be used as a file or directory name.
+func fileName(id string) string {
+	return url.PathEscape(id)
+}
+
+func writeMessage(path string, msg proto.Message) error {
+	b, err := marshalOptions.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func writeMessages[M proto.Message](dir string, msgs []M, id func(M) string) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	for _, msg := range msgs {
+		if err := writeMessage(filepath.Join(dir, fileName(id(msg))+fileExtension), msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readMessage(path string, msg proto.Message) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := unmarshalOptions.Unmarshal(b, msg); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+	return nil
+}
+
+// readMessages reads every message file of a directory, in file name order.
+// A missing directory is treated as an empty one.
+func readMessages[M proto.Message](dir string) ([]M, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+	var msgs []M
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), fileExtension) {
+			continue
+		}
+		var msg M
+		msg = msg.ProtoReflect().Type().New().Interface().(M)
+		if err := readMessage(filepath.Join(dir, entry.Name()), msg); err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// subDirs returns the unescaped names of the sub-directories of dir, in order.
+// A missing directory is treated as an empty one.
+func subDirs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		name, err := url.PathUnescape(entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("invalid directory name %s: %w", entry.Name(), err)
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
@@ -0,0 +1,397 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	accountv1 "go.temporal.io/cloud-sdk/api/account/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	connectivityrulev1 "go.temporal.io/cloud-sdk/api/connectivityrule/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	nexusv1 "go.temporal.io/cloud-sdk/api/nexus/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/cloudservicemock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeCloudService serves a fixed account and records the resources created through it.
+// Its methods back a mock of the cloud service, which fails the test on any other call.
+type fakeCloudService struct {
+	namespaces []*namespacev1.Namespace
+	users      []*identityv1.User
+	groups     []*identityv1.UserGroup
+	members    map[string][]*identityv1.UserGroupMember
+	endpoints  []*nexusv1.Endpoint
+	rules      []*connectivityrulev1.ConnectivityRule
+	roles      []*identityv1.CustomRole
+
+	// whether CreateUser reports the user as already existing
+	userExists bool
+
+	createdNamespaces []*cloudservicev1.CreateNamespaceRequest
+	createdUsers      []*cloudservicev1.CreateUserRequest
+	createdMembers    []*cloudservicev1.AddUserGroupMemberRequest
+	createdEndpoints  []*cloudservicev1.CreateNexusEndpointRequest
+	createdRoles      []*cloudservicev1.CreateCustomRoleRequest
+}
+
+func (f *fakeCloudService) GetAccount(context.Context, *cloudservicev1.GetAccountRequest, ...grpc.CallOption) (*cloudservicev1.GetAccountResponse, error) {
+	return &cloudservicev1.GetAccountResponse{Account: &accountv1.Account{Id: "src"}}, nil
+}
+
+func (f *fakeCloudService) GetNamespaces(_ context.Context, req *cloudservicev1.GetNamespacesRequest, _ ...grpc.CallOption) (*cloudservicev1.GetNamespacesResponse, error) {
+	// serve one namespace per page to exercise pagination.
+	var page int
+	fmt.Sscan(req.GetPageToken(), &page)
+	resp := &cloudservicev1.GetNamespacesResponse{Namespaces: f.namespaces[page : page+1]}
+	if page+1 < len(f.namespaces) {
+		resp.NextPageToken = fmt.Sprint(page + 1)
+	}
+	return resp, nil
+}
+
+func (f *fakeCloudService) GetNamespaceExportSinks(context.Context, *cloudservicev1.GetNamespaceExportSinksRequest, ...grpc.CallOption) (*cloudservicev1.GetNamespaceExportSinksResponse, error) {
+	return &cloudservicev1.GetNamespaceExportSinksResponse{}, nil
+}
+
+func (f *fakeCloudService) GetUsers(context.Context, *cloudservicev1.GetUsersRequest, ...grpc.CallOption) (*cloudservicev1.GetUsersResponse, error) {
+	return &cloudservicev1.GetUsersResponse{Users: f.users}, nil
+}
+
+func (f *fakeCloudService) GetUserGroups(context.Context, *cloudservicev1.GetUserGroupsRequest, ...grpc.CallOption) (*cloudservicev1.GetUserGroupsResponse, error) {
+	return &cloudservicev1.GetUserGroupsResponse{Groups: f.groups}, nil
+}
+
+func (f *fakeCloudService) GetUserGroupMembers(_ context.Context, req *cloudservicev1.GetUserGroupMembersRequest, _ ...grpc.CallOption) (*cloudservicev1.GetUserGroupMembersResponse, error) {
+	return &cloudservicev1.GetUserGroupMembersResponse{Members: f.members[req.GetGroupId()]}, nil
+}
+
+func (f *fakeCloudService) GetServiceAccounts(context.Context, *cloudservicev1.GetServiceAccountsRequest, ...grpc.CallOption) (*cloudservicev1.GetServiceAccountsResponse, error) {
+	return &cloudservicev1.GetServiceAccountsResponse{}, nil
+}
+
+func (f *fakeCloudService) GetApiKeys(context.Context, *cloudservicev1.GetApiKeysRequest, ...grpc.CallOption) (*cloudservicev1.GetApiKeysResponse, error) {
+	return &cloudservicev1.GetApiKeysResponse{ApiKeys: []*identityv1.ApiKey{{Id: "key-1"}}}, nil
+}
+
+func (f *fakeCloudService) GetCustomRoles(context.Context, *cloudservicev1.GetCustomRolesRequest, ...grpc.CallOption) (*cloudservicev1.GetCustomRolesResponse, error) {
+	return &cloudservicev1.GetCustomRolesResponse{CustomRoles: f.roles}, nil
+}
+
+func (f *fakeCloudService) GetNexusEndpoints(context.Context, *cloudservicev1.GetNexusEndpointsRequest, ...grpc.CallOption) (*cloudservicev1.GetNexusEndpointsResponse, error) {
+	return &cloudservicev1.GetNexusEndpointsResponse{Endpoints: f.endpoints}, nil
+}
+
+func (f *fakeCloudService) GetConnectivityRules(context.Context, *cloudservicev1.GetConnectivityRulesRequest, ...grpc.CallOption) (*cloudservicev1.GetConnectivityRulesResponse, error) {
+	return &cloudservicev1.GetConnectivityRulesResponse{ConnectivityRules: f.rules}, nil
+}
+
+func (f *fakeCloudService) GetAccountAuditLogSinks(context.Context, *cloudservicev1.GetAccountAuditLogSinksRequest, ...grpc.CallOption) (*cloudservicev1.GetAccountAuditLogSinksResponse, error) {
+	return &cloudservicev1.GetAccountAuditLogSinksResponse{}, nil
+}
+
+func (f *fakeCloudService) CreateConnectivityRule(_ context.Context, req *cloudservicev1.CreateConnectivityRuleRequest, _ ...grpc.CallOption) (*cloudservicev1.CreateConnectivityRuleResponse, error) {
+	return &cloudservicev1.CreateConnectivityRuleResponse{ConnectivityRuleId: "new-rule"}, nil
+}
+
+func (f *fakeCloudService) CreateCustomRole(_ context.Context, req *cloudservicev1.CreateCustomRoleRequest, _ ...grpc.CallOption) (*cloudservicev1.CreateCustomRoleResponse, error) {
+	f.createdRoles = append(f.createdRoles, req)
+	return &cloudservicev1.CreateCustomRoleResponse{RoleId: "new-role"}, nil
+}
+
+func (f *fakeCloudService) CreateNamespace(_ context.Context, req *cloudservicev1.CreateNamespaceRequest, _ ...grpc.CallOption) (*cloudservicev1.CreateNamespaceResponse, error) {
+	f.createdNamespaces = append(f.createdNamespaces, req)
+	return &cloudservicev1.CreateNamespaceResponse{Namespace: req.GetSpec().GetName() + ".dst"}, nil
+}
+
+func (f *fakeCloudService) CreateUser(_ context.Context, req *cloudservicev1.CreateUserRequest, _ ...grpc.CallOption) (*cloudservicev1.CreateUserResponse, error) {
+	if f.userExists {
+		return nil, status.Error(codes.AlreadyExists, "user already exists")
+	}
+	f.createdUsers = append(f.createdUsers, req)
+	return &cloudservicev1.CreateUserResponse{UserId: "new-" + req.GetSpec().GetEmail()}, nil
+}
+
+func (f *fakeCloudService) CreateUserGroup(context.Context, *cloudservicev1.CreateUserGroupRequest, ...grpc.CallOption) (*cloudservicev1.CreateUserGroupResponse, error) {
+	return &cloudservicev1.CreateUserGroupResponse{GroupId: "new-group"}, nil
+}
+
+func (f *fakeCloudService) AddUserGroupMember(_ context.Context, req *cloudservicev1.AddUserGroupMemberRequest, _ ...grpc.CallOption) (*cloudservicev1.AddUserGroupMemberResponse, error) {
+	f.createdMembers = append(f.createdMembers, req)
+	return &cloudservicev1.AddUserGroupMemberResponse{}, nil
+}
+
+func (f *fakeCloudService) CreateNexusEndpoint(_ context.Context, req *cloudservicev1.CreateNexusEndpointRequest, _ ...grpc.CallOption) (*cloudservicev1.CreateNexusEndpointResponse, error) {
+	f.createdEndpoints = append(f.createdEndpoints, req)
+	return &cloudservicev1.CreateNexusEndpointResponse{EndpointId: "new-endpoint"}, nil
+}
+
+func newFakeCloudService() *fakeCloudService {
+	return &fakeCloudService{
+		namespaces: []*namespacev1.Namespace{
+			{
+				Namespace: "caller.src",
+				Spec:      &namespacev1.NamespaceSpec{Name: "caller", ConnectivityRuleIds: []string{"rule-1"}},
+				Tags:      map[string]string{"team": "payments"},
+			},
+			{
+				Namespace: "handler.src",
+				Spec:      &namespacev1.NamespaceSpec{Name: "handler"},
+			},
+		},
+		users: []*identityv1.User{
+			{
+				Id: "user-1",
+				Spec: &identityv1.UserSpec{
+					Email: "a@example.com",
+					Access: &identityv1.Access{
+						NamespaceAccesses: map[string]*identityv1.NamespaceAccess{
+							"caller.src": {Permission: identityv1.NamespaceAccess_PERMISSION_WRITE},
+						},
+					},
+				},
+			},
+		},
+		groups: []*identityv1.UserGroup{
+			{
+				Id: "group-1",
+				Spec: &identityv1.UserGroupSpec{
+					DisplayName: "ops",
+					GroupType:   &identityv1.UserGroupSpec_CloudGroup{CloudGroup: &identityv1.CloudGroupSpec{}},
+				},
+			},
+		},
+		members: map[string][]*identityv1.UserGroupMember{
+			"group-1": {{MemberId: &identityv1.UserGroupMemberId{MemberType: &identityv1.UserGroupMemberId_UserId{UserId: "user-1"}}}},
+		},
+		endpoints: []*nexusv1.Endpoint{
+			{
+				Id: "endpoint-1",
+				Spec: &nexusv1.EndpointSpec{
+					Name: "payments",
+					TargetSpec: &nexusv1.EndpointTargetSpec{Variant: &nexusv1.EndpointTargetSpec_WorkerTargetSpec{
+						WorkerTargetSpec: &nexusv1.WorkerTargetSpec{NamespaceId: "handler.src", TaskQueue: "tq"},
+					}},
+					PolicySpecs: []*nexusv1.EndpointPolicySpec{{Variant: &nexusv1.EndpointPolicySpec_AllowedCloudNamespacePolicySpec{
+						AllowedCloudNamespacePolicySpec: &nexusv1.AllowedCloudNamespacePolicySpec{NamespaceId: "caller.src"},
+					}}},
+				},
+			},
+		},
+		rules: []*connectivityrulev1.ConnectivityRule{
+			{Id: "rule-1", Spec: &connectivityrulev1.ConnectivityRuleSpec{}},
+		},
+		roles: []*identityv1.CustomRole{
+			customRole("role-1", "caller.src"),
+		},
+	}
+}
+
+func customRole(id, namespace string) *identityv1.CustomRole {
+	return &identityv1.CustomRole{
+		Id: id,
+		Spec: &identityv1.CustomRoleSpec{
+			Name: id,
+			Permissions: []*identityv1.CustomRoleSpec_Permission{{
+				Resources: &identityv1.CustomRoleSpec_Resources{ResourceType: "namespace", ResourceIds: []string{namespace}},
+				Actions:   []string{"GetNamespace"},
+			}},
+		},
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	src := newFakeCloudService()
+
+	dir := t.TempDir()
+	exported, err := Export(ctx, cloudservicemock.NewFakeCloudServiceClient(t, src), dir)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	read, err := ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if read.Manifest.AccountID != "src" || read.Manifest.FormatVersion != FormatVersion {
+		t.Errorf("ReadDir() manifest = %+v", read.Manifest)
+	}
+	if len(read.Namespaces) != 2 || len(read.Users) != 1 || len(read.UserGroupMembers["group-1"]) != 1 || len(read.APIKeys) != 1 {
+		t.Fatalf("ReadDir() did not read back every resource: %+v", read)
+	}
+	for i, ns := range exported.Namespaces {
+		if !proto.Equal(ns, read.Namespaces[i]) {
+			t.Errorf("ReadDir() namespace = %v, want %v", read.Namespaces[i], ns)
+		}
+	}
+
+	dst := newFakeCloudService()
+	result, err := Import(ctx, cloudservicemock.NewFakeCloudServiceClient(t, dst), dir, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	t.Run("Namespaces", func(t *testing.T) {
+		if got := result.IDs.Namespaces["caller.src"]; got != "caller.dst" {
+			t.Errorf("Import() namespace mapping = %q, want %q", got, "caller.dst")
+		}
+		req := dst.createdNamespaces[0]
+		if got := req.GetSpec().GetConnectivityRuleIds(); len(got) != 1 || got[0] != "new-rule" {
+			t.Errorf("Import() connectivity rule ids = %v, want [new-rule]", got)
+		}
+		if req.GetTags()["team"] != "payments" {
+			t.Errorf("Import() tags = %v", req.GetTags())
+		}
+	})
+
+	t.Run("Users", func(t *testing.T) {
+		access := dst.createdUsers[0].GetSpec().GetAccess().GetNamespaceAccesses()
+		if _, ok := access["caller.dst"]; !ok || len(access) != 1 {
+			t.Errorf("Import() user namespace accesses = %v, want caller.dst", access)
+		}
+		if len(dst.createdMembers) != 1 || dst.createdMembers[0].GetMemberId().GetUserId() != "new-a@example.com" {
+			t.Errorf("Import() group members = %v", dst.createdMembers)
+		}
+	})
+
+	t.Run("CustomRoles", func(t *testing.T) {
+		if len(dst.createdRoles) != 1 {
+			t.Fatalf("Import() created %d custom roles, want 1", len(dst.createdRoles))
+		}
+		if got := dst.createdRoles[0].GetSpec().GetPermissions()[0].GetResources().GetResourceIds(); len(got) != 1 || got[0] != "caller.dst" {
+			t.Errorf("Import() custom role resource ids = %v, want [caller.dst]", got)
+		}
+		if got := result.IDs.CustomRoles["role-1"]; got != "new-role" {
+			t.Errorf("Import() custom role mapping = %q, want new-role", got)
+		}
+	})
+
+	t.Run("NexusEndpoints", func(t *testing.T) {
+		spec := dst.createdEndpoints[0].GetSpec()
+		if got := spec.GetTargetSpec().GetWorkerTargetSpec().GetNamespaceId(); got != "handler.dst" {
+			t.Errorf("Import() target namespace = %q, want handler.dst", got)
+		}
+		if got := spec.GetPolicySpecs()[0].GetAllowedCloudNamespacePolicySpec().GetNamespaceId(); got != "caller.dst" {
+			t.Errorf("Import() allowed namespace = %q, want caller.dst", got)
+		}
+	})
+
+	t.Run("Skipped", func(t *testing.T) {
+		kinds := map[string]bool{}
+		for _, s := range result.Skipped {
+			kinds[s.Kind] = true
+		}
+		if !kinds[KindAccount] || !kinds[KindAPIKey] || len(result.Skipped) != 2 {
+			t.Errorf("Import() skipped = %+v, want the account and the api key", result.Skipped)
+		}
+	})
+}
+
+func TestImportExistingUserNotFound(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	if _, err := Export(ctx, cloudservicemock.NewFakeCloudServiceClient(t, newFakeCloudService()), dir); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	// the user is reported as existing, but the lookup by email does not find it.
+	dst := newFakeCloudService()
+	dst.userExists, dst.users = true, nil
+	_, err := Import(ctx, cloudservicemock.NewFakeCloudServiceClient(t, dst), dir, ImportOptions{})
+	if err == nil || !strings.Contains(err.Error(), `user "user-1" reported as existing but not found by email "a@example.com"`) ||
+		strings.Contains(err.Error(), "%!w") {
+		t.Errorf("Import() error = %v, want the user not found by email", err)
+	}
+}
+
+func TestImportCustomRoleOfMissingNamespace(t *testing.T) {
+	ctx := context.Background()
+	src := newFakeCloudService()
+	src.roles = append(src.roles, customRole("role-2", "other.src"))
+	dir := t.TempDir()
+	if _, err := Export(ctx, cloudservicemock.NewFakeCloudServiceClient(t, src), dir); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	dst := newFakeCloudService()
+	result, err := Import(ctx, cloudservicemock.NewFakeCloudServiceClient(t, dst), dir, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if len(dst.createdRoles) != 1 {
+		t.Errorf("Import() created %d custom roles, want the one on an imported namespace", len(dst.createdRoles))
+	}
+	want := SkippedResource{Kind: KindCustomRole, ID: "role-2", Reason: `namespace "other.src" was not imported`}
+	if !slices.Contains(result.Skipped, want) {
+		t.Errorf("Import() skipped = %+v, want %+v", result.Skipped, want)
+	}
+}
+
+func TestImportWithoutSpec(t *testing.T) {
+	ctx := context.Background()
+	// a hand edited snapshot may lack the spec of a resource.
+	for _, tc := range []struct {
+		name    string
+		edit    func(*fakeCloudService)
+		wantErr string
+	}{
+		{"Namespace", func(f *fakeCloudService) { f.namespaces[1].Spec = nil }, `namespace "handler.src" has no spec`},
+		{"User", func(f *fakeCloudService) { f.users[0].Spec = nil }, `user "user-1" has no spec`},
+		{"CustomRole", func(f *fakeCloudService) { f.roles[0].Spec = nil }, `custom role "role-1" has no spec`},
+		{"ConnectivityRule", func(f *fakeCloudService) { f.rules[0].Spec = nil }, `connectivity rule "rule-1" has no spec`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			src := newFakeCloudService()
+			tc.edit(src)
+			dir := t.TempDir()
+			if _, err := Export(ctx, cloudservicemock.NewFakeCloudServiceClient(t, src), dir); err != nil {
+				t.Fatalf("Export() error = %v", err)
+			}
+			dst := newFakeCloudService()
+			_, err := Import(ctx, cloudservicemock.NewFakeCloudServiceClient(t, dst), dir, ImportOptions{})
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Import() error = %v, want %s", err, tc.wantErr)
+			}
+			if len(dst.createdUsers) != 0 {
+				t.Errorf("Import() created users %v after a resource without spec", dst.createdUsers)
+			}
+		})
+	}
+}
+
+func TestWriteDirRemovesStaleResources(t *testing.T) {
+	dir := t.TempDir()
+	old := &Snapshot{
+		Account: &accountv1.Account{Id: "src"},
+		Users:   []*identityv1.User{{Id: "user-1"}, {Id: "user-2"}},
+	}
+	if err := old.WriteDir(dir); err != nil {
+		t.Fatalf("WriteDir() error = %v", err)
+	}
+	notes := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(notes, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// user-2 and the account were deleted since the previous snapshot.
+	if err := (&Snapshot{Users: []*identityv1.User{{Id: "user-1"}}}).WriteDir(dir); err != nil {
+		t.Fatalf("WriteDir() error = %v", err)
+	}
+	read, err := ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if read.Account != nil || len(read.Users) != 1 || read.Users[0].GetId() != "user-1" {
+		t.Errorf("ReadDir() account = %v, users = %v, want only user-1", read.Account, read.Users)
+	}
+	if _, err := os.Stat(notes); err != nil {
+		t.Errorf("WriteDir() removed a file that is not a resource: %v", err)
+	}
+}
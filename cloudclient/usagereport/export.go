@@ -0,0 +1,61 @@
+package usagereport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// csvHeader is the header of the CSV export, one column per field of Row.
+var csvHeader = []string{"day", "namespace", "record_type", "value", "unit", "incomplete"}
+
+// WriteCSV writes the rows of the report as CSV, with a header line.
+// Days are formatted as YYYY-MM-DD.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("failed to write the CSV header: %w", err)
+	}
+	for _, row := range r.Rows {
+		err := cw.Write([]string{
+			row.Day.Format(time.DateOnly),
+			row.Namespace,
+			row.RecordType,
+			strconv.FormatFloat(row.Value, 'f', -1, 64),
+			row.Unit,
+			strconv.FormatBool(row.Incomplete),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write the CSV row: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write the CSV rows: %w", err)
+	}
+	return nil
+}
+
+// WriteJSON writes the report as an indented JSON object.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r); err != nil {
+		return fmt.Errorf("failed to write the JSON report: %w", err)
+	}
+	return nil
+}
+
+// WriteJSONLines writes one JSON object per row, the format most data pipelines load rows from.
+func (r *Report) WriteJSONLines(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, row := range r.Rows {
+		if err := enc.Encode(row); err != nil {
+			return fmt.Errorf("failed to write the JSON row: %w", err)
+		}
+	}
+	return nil
+}
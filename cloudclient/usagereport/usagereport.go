@@ -0,0 +1,174 @@
+// Package usagereport pages through the usage of a Temporal Cloud account over a time range and pivots it by
+// namespace, record type and day, into flat rows ready to be exported for chargeback.
+//
+// WARNING: The package is currently experimental.
+//
+// Storage is reported by the cloud operations API in byte-seconds, it is converted to GB-hours in the rows.
+// The usage of a day can still change while its summary is marked incomplete, the rows of such days are flagged.
+package usagereport
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	usagev1 "go.temporal.io/cloud-sdk/api/usage/v1"
+	"go.temporal.io/cloud-sdk/internal/paging"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// UnitCount is the unit of the rows counting actions.
+	UnitCount = "count"
+	// UnitGBHours is the unit of the rows measuring storage.
+	UnitGBHours = "gb_hours"
+
+	bytesPerGB     = 1e9
+	secondsPerHour = 3600
+)
+
+type (
+	// Report is the usage of an account over a time range.
+	Report struct {
+		// The start of the time range, inclusive.
+		Start time.Time `json:"start"`
+		// The end of the time range, exclusive.
+		End time.Time `json:"end"`
+		// The days whose usage is not fully available yet, in chronological order.
+		IncompleteDays []time.Time `json:"incomplete_days,omitempty"`
+		// The usage by day, namespace and record type, in that order.
+		Rows []Row `json:"rows"`
+	}
+
+	// Row is the usage of a namespace for a record type and a day.
+	// Its fields are all scalars, so that it can be written as is to columnar formats such as Parquet.
+	Row struct {
+		// The start of the UTC day.
+		Day time.Time `json:"day" parquet:"day,timestamp(millisecond)"`
+		// The namespace, empty for usage that is not grouped by namespace.
+		Namespace string `json:"namespace" parquet:"namespace"`
+		// The record type, such as "actions", "active_storage" or "retained_storage".
+		RecordType string `json:"record_type" parquet:"record_type"`
+		// The usage, in Unit.
+		Value float64 `json:"value" parquet:"value"`
+		// The unit of Value, UnitCount or UnitGBHours, or the record unit for units not known to this package.
+		Unit string `json:"unit" parquet:"unit"`
+		// Whether the usage of the day can still change.
+		Incomplete bool `json:"incomplete" parquet:"incomplete"`
+	}
+
+	rowKey struct {
+		day        time.Time
+		namespace  string
+		recordType string
+		unit       string
+	}
+)
+
+// Fetch pages through the usage between start, inclusive, and end, exclusive, and aggregates it.
+// The cloud operations API requires both times to be at midnight UTC and within the last 90 days.
+func Fetch(ctx context.Context, client cloudservicev1.CloudServiceClient, start, end time.Time) (*Report, error) {
+	summaries, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*usagev1.Summary, string, error) {
+		resp, err := client.GetUsage(ctx, &cloudservicev1.GetUsageRequest{
+			StartTimeInclusive: timestamppb.New(start),
+			EndTimeExclusive:   timestamppb.New(end),
+			PageToken:          pageToken,
+		})
+		return resp.GetSummaries(), resp.GetNextPageToken(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the usage: %w", err)
+	}
+	report := Aggregate(summaries)
+	report.Start, report.End = start.UTC(), end.UTC()
+	return report, nil
+}
+
+// Aggregate pivots summaries by day, namespace and record type, summing the records that share them.
+// The time range of the report is the one covered by the summaries.
+func Aggregate(summaries []*usagev1.Summary) *Report {
+	report := &Report{}
+	rows := map[rowKey]*Row{}
+	incompleteDays := map[time.Time]bool{}
+	for _, s := range summaries {
+		day := s.GetStartTime().AsTime().UTC().Truncate(24 * time.Hour)
+		if report.Start.IsZero() || day.Before(report.Start) {
+			report.Start = day
+		}
+		if end := s.GetEndTime().AsTime().UTC(); end.After(report.End) {
+			report.End = end
+		}
+		if s.GetIncomplete() {
+			incompleteDays[day] = true
+		}
+
+		for _, group := range s.GetRecordGroups() {
+			namespace := groupNamespace(group)
+			for _, r := range group.GetRecords() {
+				value, unit := convert(r)
+				key := rowKey{day: day, namespace: namespace, recordType: recordType(r.GetType()), unit: unit}
+				row, ok := rows[key]
+				if !ok {
+					row = &Row{Day: day, Namespace: namespace, RecordType: key.recordType, Unit: unit}
+					rows[key] = row
+				}
+				row.Value += value
+			}
+		}
+	}
+
+	for _, row := range rows {
+		row.Incomplete = incompleteDays[row.Day]
+		report.Rows = append(report.Rows, *row)
+	}
+	slices.SortFunc(report.Rows, func(a, b Row) int {
+		return cmp.Or(
+			a.Day.Compare(b.Day),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.RecordType, b.RecordType),
+			cmp.Compare(a.Unit, b.Unit),
+		)
+	})
+	for day := range incompleteDays {
+		report.IncompleteDays = append(report.IncompleteDays, day)
+	}
+	slices.SortFunc(report.IncompleteDays, time.Time.Compare)
+	return report
+}
+
+// Incomplete reports whether the usage of any day of the report can still change.
+func (r *Report) Incomplete() bool {
+	return len(r.IncompleteDays) > 0
+}
+
+// GBHours converts byte-seconds to GB-hours.
+func GBHours(byteSeconds float64) float64 {
+	return byteSeconds / bytesPerGB / secondsPerHour
+}
+
+func convert(r *usagev1.Record) (float64, string) {
+	switch r.GetUnit() {
+	case usagev1.RecordUnit_RECORD_UNIT_NUMBER:
+		return r.GetValue(), UnitCount
+	case usagev1.RecordUnit_RECORD_UNIT_BYTE_SECONDS:
+		return GBHours(r.GetValue()), UnitGBHours
+	}
+	return r.GetValue(), strings.ToLower(strings.TrimPrefix(r.GetUnit().String(), "RECORD_UNIT_"))
+}
+
+func recordType(t usagev1.RecordType) string {
+	return strings.ToLower(strings.TrimPrefix(t.String(), "RECORD_TYPE_"))
+}
+
+func groupNamespace(group *usagev1.RecordGroup) string {
+	for _, g := range group.GetGroupBys() {
+		if g.GetKey() == usagev1.GroupByKey_GROUP_BY_KEY_NAMESPACE {
+			return g.GetValue()
+		}
+	}
+	return ""
+}
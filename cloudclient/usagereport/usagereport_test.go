@@ -0,0 +1,109 @@
+package usagereport
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	usagev1 "go.temporal.io/cloud-sdk/api/usage/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/cloudservicemock"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var day1 = time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+type fakeCloudService struct {
+	pages []*cloudservicev1.GetUsageResponse
+	reqs  []*cloudservicev1.GetUsageRequest
+}
+
+func (s *fakeCloudService) GetUsage(ctx context.Context, req *cloudservicev1.GetUsageRequest, opts ...grpc.CallOption) (*cloudservicev1.GetUsageResponse, error) {
+	s.reqs = append(s.reqs, req)
+	return s.pages[len(s.reqs)-1], nil
+}
+
+func summary(day time.Time, incomplete bool, groups ...*usagev1.RecordGroup) *usagev1.Summary {
+	return &usagev1.Summary{
+		StartTime:    timestamppb.New(day),
+		EndTime:      timestamppb.New(day.Add(24 * time.Hour)),
+		RecordGroups: groups,
+		Incomplete:   incomplete,
+	}
+}
+
+func group(namespace string, records ...*usagev1.Record) *usagev1.RecordGroup {
+	return &usagev1.RecordGroup{
+		GroupBys: []*usagev1.GroupBy{{Key: usagev1.GroupByKey_GROUP_BY_KEY_NAMESPACE, Value: namespace}},
+		Records:  records,
+	}
+}
+
+func actions(n float64) *usagev1.Record {
+	return &usagev1.Record{Type: usagev1.RecordType_RECORD_TYPE_ACTIONS, Unit: usagev1.RecordUnit_RECORD_UNIT_NUMBER, Value: n}
+}
+
+func storage(byteSeconds float64) *usagev1.Record {
+	return &usagev1.Record{Type: usagev1.RecordType_RECORD_TYPE_ACTIVE_STORAGE, Unit: usagev1.RecordUnit_RECORD_UNIT_BYTE_SECONDS, Value: byteSeconds}
+}
+
+func TestFetch(t *testing.T) {
+	day2 := day1.Add(24 * time.Hour)
+	fake := &fakeCloudService{pages: []*cloudservicev1.GetUsageResponse{
+		{
+			Summaries:     []*usagev1.Summary{summary(day1, false, group("prod.a1b2c", actions(100), storage(7.2e12)))},
+			NextPageToken: "2",
+		},
+		{
+			Summaries: []*usagev1.Summary{
+				// the same day and namespace can span pages
+				summary(day1, false, group("prod.a1b2c", actions(50))),
+				summary(day2, true, group("dev.a1b2c", actions(1))),
+			},
+		},
+	}}
+
+	report, err := Fetch(context.Background(), cloudservicemock.NewFakeCloudServiceClient(t, fake), day1, day2.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(fake.reqs) != 2 || fake.reqs[1].GetPageToken() != "2" {
+		t.Errorf("Fetch() requests = %v, want two pages", fake.reqs)
+	}
+
+	want := []Row{
+		{Day: day1, Namespace: "prod.a1b2c", RecordType: "actions", Value: 150, Unit: UnitCount},
+		{Day: day1, Namespace: "prod.a1b2c", RecordType: "active_storage", Value: 2, Unit: UnitGBHours},
+		{Day: day2, Namespace: "dev.a1b2c", RecordType: "actions", Value: 1, Unit: UnitCount, Incomplete: true},
+	}
+	if len(report.Rows) != len(want) {
+		t.Fatalf("Fetch() rows = %+v, want %+v", report.Rows, want)
+	}
+	for i := range want {
+		if report.Rows[i] != want[i] {
+			t.Errorf("Fetch() row %d = %+v, want %+v", i, report.Rows[i], want[i])
+		}
+	}
+	if !report.Incomplete() || len(report.IncompleteDays) != 1 || !report.IncompleteDays[0].Equal(day2) {
+		t.Errorf("Fetch() incomplete days = %v, want %v", report.IncompleteDays, day2)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	report := Aggregate([]*usagev1.Summary{summary(day1, true, group("prod.a1b2c", actions(3), storage(3.6e12)))})
+	var out bytes.Buffer
+	if err := report.WriteCSV(&out); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	want := strings.Join([]string{
+		"day,namespace,record_type,value,unit,incomplete",
+		"2026-10-01,prod.a1b2c,actions,3,count,true",
+		"2026-10-01,prod.a1b2c,active_storage,1,gb_hours,true",
+	}, "\n") + "\n"
+	if out.String() != want {
+		t.Errorf("WriteCSV() = %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,176 @@
+package cloudclient
+
+import (
+	"context"
+	"fmt"
+
+	cloudservice "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identity "go.temporal.io/cloud-sdk/api/identity/v1"
+	"go.temporal.io/cloud-sdk/internal/paging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type (
+	// UserGroupClient to manage the user groups of the account.
+	UserGroupClient interface {
+		// Get returns the user group with the given id.
+		Get(ctx context.Context, id string) (*identity.UserGroup, error)
+		// GetByDisplayName returns the user group with the given display name.
+		// An error matching ErrNotFound is returned if there is no such group.
+		GetByDisplayName(ctx context.Context, displayName string) (*identity.UserGroup, error)
+		// List returns every user group of the account.
+		List(ctx context.Context) ([]*identity.UserGroup, error)
+		// Create starts the creation of a user group and returns its id.
+		Create(ctx context.Context, spec *identity.UserGroupSpec) (string, *AsyncOperation, error)
+		// Update replaces the spec of a user group with group.Spec.
+		// The update is rejected if the group changed since it was retrieved, see group.ResourceVersion.
+		Update(ctx context.Context, group *identity.UserGroup) (*AsyncOperation, error)
+		// SetNamespaceAccess sets the access of a user group to a namespace, a nil access removes it.
+		// If resourceVersion is empty, the latest version of the group is updated.
+		SetNamespaceAccess(ctx context.Context, id string, namespace string, access *identity.NamespaceAccess, resourceVersion string) (*AsyncOperation, error)
+		// Delete deletes a user group.
+		// If resourceVersion is empty, the latest version of the group is deleted.
+		Delete(ctx context.Context, id string, resourceVersion string) (*AsyncOperation, error)
+		// ListMembers returns every member of a user group.
+		ListMembers(ctx context.Context, id string) ([]*identity.UserGroupMember, error)
+		// AddMember adds a user to a user group managed by Temporal Cloud.
+		AddMember(ctx context.Context, id string, userID string) (*AsyncOperation, error)
+		// RemoveMember removes a user from a user group managed by Temporal Cloud.
+		RemoveMember(ctx context.Context, id string, userID string) (*AsyncOperation, error)
+	}
+
+	userGroupClient struct {
+		client cloudservice.CloudServiceClient
+	}
+)
+
+func (c userGroupClient) Get(ctx context.Context, id string) (*identity.UserGroup, error) {
+	resp, err := c.client.GetUserGroup(ctx, &cloudservice.GetUserGroupRequest{
+		GroupId: id,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return resp.GetGroup(), nil
+}
+
+func (c userGroupClient) GetByDisplayName(ctx context.Context, displayName string) (*identity.UserGroup, error) {
+	resp, err := c.client.GetUserGroups(ctx, &cloudservice.GetUserGroupsRequest{
+		DisplayName: displayName,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	if len(resp.GetGroups()) == 0 {
+		return nil, toAPIError(status.Error(codes.NotFound, fmt.Sprintf("user group %q not found", displayName)))
+	}
+	return resp.GetGroups()[0], nil
+}
+
+func (c userGroupClient) List(ctx context.Context) ([]*identity.UserGroup, error) {
+	groups, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identity.UserGroup, string, error) {
+		resp, err := c.client.GetUserGroups(ctx, &cloudservice.GetUserGroupsRequest{PageToken: pageToken})
+		return resp.GetGroups(), resp.GetNextPageToken(), err
+	})
+	return groups, toAPIError(err)
+}
+
+func (c userGroupClient) Create(ctx context.Context, spec *identity.UserGroupSpec) (string, *AsyncOperation, error) {
+	resp, err := c.client.CreateUserGroup(ctx, &cloudservice.CreateUserGroupRequest{
+		Spec: spec,
+	})
+	if err != nil {
+		return "", nil, toAPIError(err)
+	}
+	return resp.GetGroupId(), newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c userGroupClient) Update(ctx context.Context, group *identity.UserGroup) (*AsyncOperation, error) {
+	resp, err := c.client.UpdateUserGroup(ctx, &cloudservice.UpdateUserGroupRequest{
+		GroupId:         group.GetId(),
+		Spec:            group.GetSpec(),
+		ResourceVersion: group.GetResourceVersion(),
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c userGroupClient) SetNamespaceAccess(ctx context.Context, id string, namespace string, access *identity.NamespaceAccess, resourceVersion string) (*AsyncOperation, error) {
+	if resourceVersion == "" {
+		group, err := c.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		resourceVersion = group.GetResourceVersion()
+	}
+	resp, err := c.client.SetUserGroupNamespaceAccess(ctx, &cloudservice.SetUserGroupNamespaceAccessRequest{
+		Namespace:       namespace,
+		GroupId:         id,
+		Access:          access,
+		ResourceVersion: resourceVersion,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c userGroupClient) Delete(ctx context.Context, id string, resourceVersion string) (*AsyncOperation, error) {
+	if resourceVersion == "" {
+		group, err := c.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		resourceVersion = group.GetResourceVersion()
+	}
+	resp, err := c.client.DeleteUserGroup(ctx, &cloudservice.DeleteUserGroupRequest{
+		GroupId:         id,
+		ResourceVersion: resourceVersion,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c userGroupClient) ListMembers(ctx context.Context, id string) ([]*identity.UserGroupMember, error) {
+	members, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identity.UserGroupMember, string, error) {
+		resp, err := c.client.GetUserGroupMembers(ctx, &cloudservice.GetUserGroupMembersRequest{
+			GroupId:   id,
+			PageToken: pageToken,
+		})
+		return resp.GetMembers(), resp.GetNextPageToken(), err
+	})
+	return members, toAPIError(err)
+}
+
+func (c userGroupClient) AddMember(ctx context.Context, id string, userID string) (*AsyncOperation, error) {
+	resp, err := c.client.AddUserGroupMember(ctx, &cloudservice.AddUserGroupMemberRequest{
+		GroupId:  id,
+		MemberId: userMemberID(userID),
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c userGroupClient) RemoveMember(ctx context.Context, id string, userID string) (*AsyncOperation, error) {
+	resp, err := c.client.RemoveUserGroupMember(ctx, &cloudservice.RemoveUserGroupMemberRequest{
+		GroupId:  id,
+		MemberId: userMemberID(userID),
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func userMemberID(userID string) *identity.UserGroupMemberId {
+	return &identity.UserGroupMemberId{
+		MemberType: &identity.UserGroupMemberId_UserId{UserId: userID},
+	}
+}
@@ -0,0 +1,131 @@
+package cloudclient
+
+import (
+	"context"
+	"fmt"
+
+	cloudservice "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identity "go.temporal.io/cloud-sdk/api/identity/v1"
+	"go.temporal.io/cloud-sdk/internal/paging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type (
+	// UserClient to manage the users of the account.
+	UserClient interface {
+		// Get returns the user with the given id.
+		Get(ctx context.Context, id string) (*identity.User, error)
+		// GetByEmail returns the user with the given email address.
+		// An error matching ErrNotFound is returned if there is no such user.
+		GetByEmail(ctx context.Context, email string) (*identity.User, error)
+		// List returns every user of the account.
+		List(ctx context.Context) ([]*identity.User, error)
+		// Create starts the creation, and invitation, of a user and returns its id.
+		Create(ctx context.Context, spec *identity.UserSpec) (string, *AsyncOperation, error)
+		// Update replaces the spec of a user with user.Spec.
+		// The update is rejected if the user changed since it was retrieved, see user.ResourceVersion.
+		Update(ctx context.Context, user *identity.User) (*AsyncOperation, error)
+		// SetNamespaceAccess sets the access of a user to a namespace, a nil access removes it.
+		// If resourceVersion is empty, the latest version of the user is updated.
+		SetNamespaceAccess(ctx context.Context, id string, namespace string, access *identity.NamespaceAccess, resourceVersion string) (*AsyncOperation, error)
+		// Delete deletes a user.
+		// If resourceVersion is empty, the latest version of the user is deleted.
+		Delete(ctx context.Context, id string, resourceVersion string) (*AsyncOperation, error)
+	}
+
+	userClient struct {
+		client cloudservice.CloudServiceClient
+	}
+)
+
+func (c userClient) Get(ctx context.Context, id string) (*identity.User, error) {
+	resp, err := c.client.GetUser(ctx, &cloudservice.GetUserRequest{
+		UserId: id,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return resp.GetUser(), nil
+}
+
+func (c userClient) GetByEmail(ctx context.Context, email string) (*identity.User, error) {
+	resp, err := c.client.GetUsers(ctx, &cloudservice.GetUsersRequest{
+		Email: email,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	if len(resp.GetUsers()) == 0 {
+		return nil, toAPIError(status.Error(codes.NotFound, fmt.Sprintf("user with email %q not found", email)))
+	}
+	return resp.GetUsers()[0], nil
+}
+
+func (c userClient) List(ctx context.Context) ([]*identity.User, error) {
+	users, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*identity.User, string, error) {
+		resp, err := c.client.GetUsers(ctx, &cloudservice.GetUsersRequest{PageToken: pageToken})
+		return resp.GetUsers(), resp.GetNextPageToken(), err
+	})
+	return users, toAPIError(err)
+}
+
+func (c userClient) Create(ctx context.Context, spec *identity.UserSpec) (string, *AsyncOperation, error) {
+	resp, err := c.client.CreateUser(ctx, &cloudservice.CreateUserRequest{
+		Spec: spec,
+	})
+	if err != nil {
+		return "", nil, toAPIError(err)
+	}
+	return resp.GetUserId(), newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c userClient) Update(ctx context.Context, user *identity.User) (*AsyncOperation, error) {
+	resp, err := c.client.UpdateUser(ctx, &cloudservice.UpdateUserRequest{
+		UserId:          user.GetId(),
+		Spec:            user.GetSpec(),
+		ResourceVersion: user.GetResourceVersion(),
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c userClient) SetNamespaceAccess(ctx context.Context, id string, namespace string, access *identity.NamespaceAccess, resourceVersion string) (*AsyncOperation, error) {
+	if resourceVersion == "" {
+		user, err := c.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		resourceVersion = user.GetResourceVersion()
+	}
+	resp, err := c.client.SetUserNamespaceAccess(ctx, &cloudservice.SetUserNamespaceAccessRequest{
+		Namespace:       namespace,
+		UserId:          id,
+		Access:          access,
+		ResourceVersion: resourceVersion,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
+
+func (c userClient) Delete(ctx context.Context, id string, resourceVersion string) (*AsyncOperation, error) {
+	if resourceVersion == "" {
+		user, err := c.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		resourceVersion = user.GetResourceVersion()
+	}
+	resp, err := c.client.DeleteUser(ctx, &cloudservice.DeleteUserRequest{
+		UserId:          id,
+		ResourceVersion: resourceVersion,
+	})
+	if err != nil {
+		return nil, toAPIError(err)
+	}
+	return newAsyncOperation(c.client, resp.GetAsyncOperation()), nil
+}
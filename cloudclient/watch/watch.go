@@ -0,0 +1,437 @@
+// Package watch polls the list operations of the cloud operations API and emits an event whenever a resource is
+// added, modified or deleted.
+//
+// WARNING: The package is currently experimental.
+//
+// A single watcher serves every consumer of a process, so that the resources of an account are listed once per
+// poll interval, no matter how many consumers are interested in them.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	nexusv1 "go.temporal.io/cloud-sdk/api/nexus/v1"
+	resourcev1 "go.temporal.io/cloud-sdk/api/resource/v1"
+	"go.temporal.io/cloud-sdk/internal/paging"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultPollInterval is the interval between two polls, used when none is provided.
+	DefaultPollInterval = 30 * time.Second
+)
+
+// The kinds of resources that can be watched.
+const (
+	KindNamespace      Kind = "namespace"
+	KindUser           Kind = "user"
+	KindUserGroup      Kind = "user_group"
+	KindServiceAccount Kind = "service_account"
+	KindAPIKey         Kind = "api_key"
+	KindNexusEndpoint  Kind = "nexus_endpoint"
+)
+
+// The types of events emitted by a watcher.
+const (
+	// The resource was not known to the watcher.
+	EventAdded EventType = iota + 1
+	// The resource version or the state of the resource changed.
+	// Also emitted for every known resource on resync.
+	EventModified
+	// The resource is no longer listed, or reached the deleted state.
+	EventDeleted
+)
+
+type (
+	// Kind is a kind of resource.
+	Kind string
+
+	// EventType is the type of change an event reports.
+	EventType int
+
+	// Event reports a change of a resource.
+	Event struct {
+		Type EventType
+		Kind Kind
+		// The id of the resource, the namespace name for namespaces.
+		ID              string
+		ResourceVersion string
+		State           resourcev1.ResourceState
+		// The resource as last listed: one of *namespacev1.Namespace, *identityv1.User, *identityv1.UserGroup,
+		// *identityv1.ServiceAccount, *identityv1.ApiKey or *nexusv1.Endpoint, depending on the kind.
+		Resource proto.Message
+		// Whether the event was emitted by a resync, rather than by a change of the resource.
+		Resync bool
+	}
+
+	// Handler is called for every event, one event at a time.
+	Handler func(Event)
+
+	// Options to configure a watcher.
+	Options struct {
+		// The kinds of resources to watch.
+		// If not provided, every kind is watched.
+		Kinds []Kind
+
+		// The interval between two polls.
+		// If not provided, DefaultPollInterval is used.
+		PollInterval time.Duration
+
+		// The interval between two resyncs, on which a modified event is emitted for every known resource.
+		// If not provided, the watcher never resyncs.
+		ResyncInterval time.Duration
+
+		// The watermark to resume from, as returned by Watcher.Watermark.
+		// Only the changes since the watermark was taken are emitted on the first poll.
+		// If not provided, an added event is emitted for every resource on the first poll.
+		Watermark Watermark
+
+		// Called when a poll fails. The watcher carries on with the next poll.
+		// If not provided, errors are ignored.
+		OnError func(Kind, error)
+	}
+
+	// Watermark records the resource version and state of every resource known to a watcher.
+	// It can be serialized as JSON to resume a watcher across restarts.
+	Watermark map[Kind]map[string]Version
+
+	// Version of a resource recorded by a watermark.
+	Version struct {
+		ResourceVersion string                   `json:"resource_version"`
+		State           resourcev1.ResourceState `json:"state"`
+	}
+
+	// Watcher polls the cloud operations API for changes.
+	Watcher struct {
+		client  cloudservicev1.CloudServiceClient
+		options Options
+
+		mu               sync.Mutex
+		watermark        Watermark
+		known            map[Kind]map[string]Event // the last event emitted for every resource
+		subscribers      map[int]Handler
+		nextSubscriberID int
+	}
+
+	resource struct {
+		id      string
+		version string
+		state   resourcev1.ResourceState
+		msg     proto.Message
+	}
+)
+
+// AllKinds returns every kind of resource that can be watched.
+func AllKinds() []Kind {
+	return []Kind{KindNamespace, KindUser, KindUserGroup, KindServiceAccount, KindAPIKey, KindNexusEndpoint}
+}
+
+func (t EventType) String() string {
+	switch t {
+	case EventAdded:
+		return "Added"
+	case EventModified:
+		return "Modified"
+	case EventDeleted:
+		return "Deleted"
+	}
+	return fmt.Sprintf("EventType(%d)", int(t))
+}
+
+// New creates a watcher. The watcher does not poll until Run is called.
+func New(client cloudservicev1.CloudServiceClient, options Options) *Watcher {
+	if options.PollInterval <= 0 {
+		options.PollInterval = DefaultPollInterval
+	}
+	if len(options.Kinds) == 0 {
+		options.Kinds = AllKinds()
+	}
+	w := &Watcher{
+		client:      client,
+		options:     options,
+		watermark:   Watermark{},
+		known:       map[Kind]map[string]Event{},
+		subscribers: map[int]Handler{},
+	}
+	for kind, versions := range options.Watermark {
+		w.watermark[kind] = maps.Clone(versions)
+	}
+	return w
+}
+
+// Subscribe registers a handler called for every event emitted after the subscription.
+// Handlers are called one at a time, from the goroutine running Run, and should return quickly.
+// Subscribe before calling Run to receive an event for every resource; later subscribers are only told
+// about subsequent changes, and about every resource on resync.
+// The returned function unsubscribes the handler.
+func (w *Watcher) Subscribe(handler Handler) (unsubscribe func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	id := w.nextSubscriberID
+	w.nextSubscriberID++
+	w.subscribers[id] = handler
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.subscribers, id)
+	}
+}
+
+// SubscribeChan registers a channel receiving every event emitted after the subscription, see Subscribe.
+// Events are delivered in order: a full channel blocks the watcher until it is drained, or until unsubscribed.
+// The returned function unsubscribes and closes the channel.
+func (w *Watcher) SubscribeChan(buffer int) (events <-chan Event, unsubscribe func()) {
+	ch := make(chan Event, buffer)
+	done := make(chan struct{})
+	var sendMu sync.Mutex
+	remove := w.Subscribe(func(e Event) {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		select {
+		case <-done:
+		default:
+			select {
+			case ch <- e:
+			case <-done:
+			}
+		}
+	})
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			remove()
+			close(done)
+			// wait for an in-flight send to give up before closing the channel.
+			sendMu.Lock()
+			defer sendMu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Run polls until the context is done, emitting events to the subscribers.
+// A watcher must only be run once at a time. Run always returns the context error.
+func (w *Watcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.options.PollInterval)
+	defer ticker.Stop()
+
+	lastResync := time.Now()
+	for {
+		w.poll(ctx)
+		if w.options.ResyncInterval > 0 && time.Since(lastResync) >= w.options.ResyncInterval {
+			w.resync()
+			lastResync = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Watermark returns the versions of the resources known to the watcher, as of the last event emitted.
+func (w *Watcher) Watermark() Watermark {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	out := make(Watermark, len(w.watermark))
+	for kind, versions := range w.watermark {
+		out[kind] = maps.Clone(versions)
+	}
+	return out
+}
+
+// poll lists every watched kind once and emits the differences with what is known.
+func (w *Watcher) poll(ctx context.Context) {
+	for _, kind := range w.options.Kinds {
+		if ctx.Err() != nil {
+			return
+		}
+		resources, err := w.list(ctx, kind)
+		if err != nil {
+			if w.options.OnError != nil {
+				w.options.OnError(kind, fmt.Errorf("failed to list %s: %w", kind, err))
+			}
+			continue
+		}
+		w.emit(w.diff(kind, resources))
+	}
+}
+
+// diff records the listed resources of a kind and returns the events to emit, in id order.
+func (w *Watcher) diff(kind Kind, resources []resource) []Event {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	versions := w.watermark[kind]
+	if versions == nil {
+		versions = map[string]Version{}
+		w.watermark[kind] = versions
+	}
+	known := w.known[kind]
+	if known == nil {
+		known = map[string]Event{}
+		w.known[kind] = known
+	}
+
+	var events []Event
+	listed := make(map[string]bool, len(resources))
+	for _, r := range resources {
+		listed[r.id] = true
+		e := Event{
+			Kind:            kind,
+			ID:              r.id,
+			ResourceVersion: r.version,
+			State:           r.state,
+			Resource:        r.msg,
+		}
+		previous, seen := versions[r.id]
+		switch {
+		case r.state == resourcev1.ResourceState_RESOURCE_STATE_DELETED:
+			if seen {
+				e.Type = EventDeleted
+				events = append(events, e)
+				delete(versions, r.id)
+			}
+			delete(known, r.id)
+			continue
+		case !seen:
+			e.Type = EventAdded
+			events = append(events, e)
+		case previous.ResourceVersion != r.version || previous.State != r.state:
+			e.Type = EventModified
+			events = append(events, e)
+		}
+		versions[r.id] = Version{ResourceVersion: r.version, State: r.state}
+		known[r.id] = e
+	}
+	for id, version := range versions {
+		if listed[id] {
+			continue
+		}
+		e, ok := known[id]
+		if !ok {
+			// the resource was deleted while the watcher was not running: only the watermark knows about it.
+			e = Event{Kind: kind, ID: id, ResourceVersion: version.ResourceVersion, State: version.State}
+		}
+		e.Type = EventDeleted
+		events = append(events, e)
+		delete(versions, id)
+		delete(known, id)
+	}
+
+	slices.SortStableFunc(events, func(a, b Event) int {
+		switch {
+		case a.ID < b.ID:
+			return -1
+		case a.ID > b.ID:
+			return 1
+		}
+		return 0
+	})
+	return events
+}
+
+// resync emits a modified event for every known resource.
+func (w *Watcher) resync() {
+	w.mu.Lock()
+	var events []Event
+	for _, kind := range w.options.Kinds {
+		for _, id := range slices.Sorted(maps.Keys(w.known[kind])) {
+			e := w.known[kind][id]
+			e.Type = EventModified
+			e.Resync = true
+			events = append(events, e)
+		}
+	}
+	w.mu.Unlock()
+
+	w.emit(events)
+}
+
+// emit calls every subscriber with the events, in order.
+func (w *Watcher) emit(events []Event) {
+	if len(events) == 0 {
+		return
+	}
+	w.mu.Lock()
+	handlers := make([]Handler, 0, len(w.subscribers))
+	for _, id := range slices.Sorted(maps.Keys(w.subscribers)) {
+		handlers = append(handlers, w.subscribers[id])
+	}
+	w.mu.Unlock()
+
+	for _, e := range events {
+		for _, handler := range handlers {
+			handler(e)
+		}
+	}
+}
+
+func (w *Watcher) list(ctx context.Context, kind Kind) ([]resource, error) {
+	switch kind {
+	case KindNamespace:
+		return listAll(ctx, func(ctx context.Context, pageToken string) ([]*namespacev1.Namespace, string, error) {
+			resp, err := w.client.GetNamespaces(ctx, &cloudservicev1.GetNamespacesRequest{PageToken: pageToken})
+			return resp.GetNamespaces(), resp.GetNextPageToken(), err
+		}, (*namespacev1.Namespace).GetNamespace)
+	case KindUser:
+		return listAll(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.User, string, error) {
+			resp, err := w.client.GetUsers(ctx, &cloudservicev1.GetUsersRequest{PageToken: pageToken})
+			return resp.GetUsers(), resp.GetNextPageToken(), err
+		}, (*identityv1.User).GetId)
+	case KindUserGroup:
+		return listAll(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.UserGroup, string, error) {
+			resp, err := w.client.GetUserGroups(ctx, &cloudservicev1.GetUserGroupsRequest{PageToken: pageToken})
+			return resp.GetGroups(), resp.GetNextPageToken(), err
+		}, (*identityv1.UserGroup).GetId)
+	case KindServiceAccount:
+		return listAll(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.ServiceAccount, string, error) {
+			resp, err := w.client.GetServiceAccounts(ctx, &cloudservicev1.GetServiceAccountsRequest{PageToken: pageToken})
+			return resp.GetServiceAccount(), resp.GetNextPageToken(), err
+		}, (*identityv1.ServiceAccount).GetId)
+	case KindAPIKey:
+		return listAll(ctx, func(ctx context.Context, pageToken string) ([]*identityv1.ApiKey, string, error) {
+			resp, err := w.client.GetApiKeys(ctx, &cloudservicev1.GetApiKeysRequest{PageToken: pageToken})
+			return resp.GetApiKeys(), resp.GetNextPageToken(), err
+		}, (*identityv1.ApiKey).GetId)
+	case KindNexusEndpoint:
+		return listAll(ctx, func(ctx context.Context, pageToken string) ([]*nexusv1.Endpoint, string, error) {
+			resp, err := w.client.GetNexusEndpoints(ctx, &cloudservicev1.GetNexusEndpointsRequest{PageToken: pageToken})
+			return resp.GetEndpoints(), resp.GetNextPageToken(), err
+		}, (*nexusv1.Endpoint).GetId)
+	}
+	return nil, fmt.Errorf("unsupported kind %q", kind)
+}
+
+type versionedResource interface {
+	proto.Message
+	GetResourceVersion() string
+	GetState() resourcev1.ResourceState
+}
+
+func listAll[R versionedResource](ctx context.Context, fetch paging.FetchFunc[R], id func(R) string) ([]resource, error) {
+	items, err := paging.All(ctx, fetch)
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]resource, 0, len(items))
+	for _, item := range items {
+		resources = append(resources, resource{
+			id:      id(item),
+			version: item.GetResourceVersion(),
+			state:   item.GetState(),
+			msg:     item,
+		})
+	}
+	return resources, nil
+}
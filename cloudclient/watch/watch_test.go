@@ -0,0 +1,146 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	resourcev1 "go.temporal.io/cloud-sdk/api/resource/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/cloudservicemock"
+	"google.golang.org/grpc"
+)
+
+type fakeCloudService struct {
+	namespaces []*namespacev1.Namespace
+}
+
+func (f *fakeCloudService) GetNamespaces(context.Context, *cloudservicev1.GetNamespacesRequest, ...grpc.CallOption) (*cloudservicev1.GetNamespacesResponse, error) {
+	return &cloudservicev1.GetNamespacesResponse{Namespaces: f.namespaces}, nil
+}
+
+func namespace(name, version string, state resourcev1.ResourceState) *namespacev1.Namespace {
+	return &namespacev1.Namespace{Namespace: name, ResourceVersion: version, State: state}
+}
+
+func collect(w *Watcher) (events *[]Event, unsubscribe func()) {
+	events = &[]Event{}
+	return events, w.Subscribe(func(e Event) {
+		*events = append(*events, e)
+	})
+}
+
+func TestWatcher(t *testing.T) {
+	ctx := context.Background()
+	active := resourcev1.ResourceState_RESOURCE_STATE_ACTIVE
+
+	fake := &fakeCloudService{namespaces: []*namespacev1.Namespace{
+		namespace("a", "1", active),
+		namespace("b", "1", active),
+	}}
+	w := New(cloudservicemock.NewFakeCloudServiceClient(t, fake), Options{Kinds: []Kind{KindNamespace}})
+	events, _ := collect(w)
+
+	t.Run("Added", func(t *testing.T) {
+		w.poll(ctx)
+		if len(*events) != 2 || (*events)[0].Type != EventAdded || (*events)[0].ID != "a" || (*events)[1].ID != "b" {
+			t.Fatalf("poll() events = %+v, want a and b added", *events)
+		}
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		*events = nil
+		w.poll(ctx)
+		if len(*events) != 0 {
+			t.Fatalf("poll() events = %+v, want none", *events)
+		}
+	})
+
+	t.Run("Modified And Deleted", func(t *testing.T) {
+		*events = nil
+		fake.namespaces = []*namespacev1.Namespace{
+			namespace("a", "2", resourcev1.ResourceState_RESOURCE_STATE_UPDATING),
+		}
+		w.poll(ctx)
+		if len(*events) != 2 {
+			t.Fatalf("poll() events = %+v, want 2", *events)
+		}
+		if e := (*events)[0]; e.Type != EventModified || e.ID != "a" || e.State != resourcev1.ResourceState_RESOURCE_STATE_UPDATING {
+			t.Errorf("poll() event = %+v, want a modified", e)
+		}
+		if e := (*events)[1]; e.Type != EventDeleted || e.ID != "b" || e.Resource == nil {
+			t.Errorf("poll() event = %+v, want b deleted with its last known resource", e)
+		}
+	})
+
+	t.Run("Deleted State", func(t *testing.T) {
+		*events = nil
+		fake.namespaces = []*namespacev1.Namespace{
+			namespace("a", "3", resourcev1.ResourceState_RESOURCE_STATE_DELETED),
+		}
+		w.poll(ctx)
+		if len(*events) != 1 || (*events)[0].Type != EventDeleted {
+			t.Fatalf("poll() events = %+v, want a deleted", *events)
+		}
+		*events = nil
+		w.poll(ctx)
+		if len(*events) != 0 {
+			t.Fatalf("poll() events = %+v, want none once deleted", *events)
+		}
+	})
+
+	t.Run("Resync", func(t *testing.T) {
+		fake.namespaces = []*namespacev1.Namespace{namespace("c", "1", active)}
+		w.poll(ctx)
+		*events = nil
+		w.resync()
+		if len(*events) != 1 || (*events)[0].Type != EventModified || !(*events)[0].Resync {
+			t.Fatalf("resync() events = %+v, want c modified by resync", *events)
+		}
+	})
+
+	t.Run("Resume From Watermark", func(t *testing.T) {
+		b, err := json.Marshal(w.Watermark())
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		var watermark Watermark
+		if err := json.Unmarshal(b, &watermark); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+
+		fake.namespaces = []*namespacev1.Namespace{
+			namespace("d", "1", active),
+		}
+		resumed := New(cloudservicemock.NewFakeCloudServiceClient(t, fake), Options{Kinds: []Kind{KindNamespace}, Watermark: watermark})
+		resumedEvents, _ := collect(resumed)
+		resumed.poll(ctx)
+		if len(*resumedEvents) != 2 ||
+			(*resumedEvents)[0].ID != "c" || (*resumedEvents)[0].Type != EventDeleted ||
+			(*resumedEvents)[1].ID != "d" || (*resumedEvents)[1].Type != EventAdded {
+			t.Fatalf("poll() events = %+v, want c deleted and d added", *resumedEvents)
+		}
+	})
+}
+
+func TestSubscribeChan(t *testing.T) {
+	fake := &fakeCloudService{namespaces: []*namespacev1.Namespace{
+		namespace("a", "1", resourcev1.ResourceState_RESOURCE_STATE_ACTIVE),
+	}}
+	w := New(cloudservicemock.NewFakeCloudServiceClient(t, fake), Options{Kinds: []Kind{KindNamespace}})
+	events, unsubscribe := w.SubscribeChan(0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	e := <-events
+	if e.Type != EventAdded || e.ID != "a" {
+		t.Errorf("SubscribeChan() event = %+v, want a added", e)
+	}
+	unsubscribe()
+	if _, ok := <-events; ok {
+		t.Errorf("SubscribeChan() channel not closed after unsubscribe")
+	}
+}
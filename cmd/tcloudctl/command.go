@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"go.temporal.io/cloud-sdk/cloudclient"
+	"go.temporal.io/cloud-sdk/cloudclient/auditlogs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	verbGet    = "get"
+	verbList   = "list"
+	verbCreate = "create"
+	verbApply  = "apply"
+	verbDelete = "delete"
+	verbWait   = "wait"
+
+	waitPollInterval = 5 * time.Second
+)
+
+type (
+	// command is a verb run against a resource, along with its flags.
+	command struct {
+		resource *resource
+		verb     string
+		stdin    io.Reader
+		stdout   io.Writer
+		client   *cloudclient.Client
+
+		connection connection
+		output     string
+		file       string
+		wait       bool
+		timeout    time.Duration
+		namespace  string
+		start      timeFlag
+		end        timeFlag
+		filter     *auditlogs.Query
+		id         string
+	}
+
+	// mutation is the result of create, apply and delete.
+	mutation struct {
+		ID               string `json:"id,omitempty" yaml:"id,omitempty"`
+		AsyncOperationID string `json:"asyncOperationId,omitempty" yaml:"asyncOperationId,omitempty"`
+		// Token is only set when creating an API key, it cannot be retrieved later on.
+		Token string `json:"token,omitempty" yaml:"token,omitempty"`
+	}
+
+	// timeFlag is a flag.Value parsing RFC 3339 timestamps.
+	timeFlag struct {
+		time.Time
+	}
+)
+
+func (f *timeFlag) String() string {
+	if f.IsZero() {
+		return ""
+	}
+	return f.Format(time.RFC3339)
+}
+
+func (f *timeFlag) Set(value string) error {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return fmt.Errorf("expected an RFC 3339 time such as 2025-01-02T15:04:05Z: %w", err)
+	}
+	f.Time = t
+	return nil
+}
+
+func (c *command) flagSet(stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(c.resource.name+" "+c.verb, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: tcloudctl %s %s [flags]%s\n\nFlags:\n", c.resource.name, c.verb, c.argsUsage())
+		fs.PrintDefaults()
+	}
+
+	c.connection.register(fs)
+	fs.StringVar(&c.output, "o", "table", "output format, one of table, json or yaml")
+	switch c.verb {
+	case verbCreate, verbApply:
+		fs.StringVar(&c.file, "f", "", "JSON or YAML file to read the resource from, - for the standard input")
+	}
+	switch c.verb {
+	case verbCreate, verbApply, verbDelete:
+		fs.BoolVar(&c.wait, "wait", false, "wait for the async operation to complete")
+	}
+	switch c.verb {
+	case verbCreate, verbApply, verbDelete, verbWait:
+		fs.DurationVar(&c.timeout, "timeout", 10*time.Minute, "maximum time to wait for")
+	}
+	if c.resource.namespaced {
+		fs.StringVar(&c.namespace, "namespace", "", "namespace of the resource (required)")
+	}
+	if c.resource.timeRange && c.verb == verbList {
+		fs.Var(&c.start, "start", "start of the time range, inclusive, in RFC 3339 (default 24 hours before -end)")
+		fs.Var(&c.end, "end", "end of the time range, exclusive, in RFC 3339 (default now)")
+	}
+	if c.resource.filtered && c.verb == verbList {
+		fs.Func("filter", `query to filter with, such as 'operation =~ "Delete.*" and principal.type == "user"'`, func(value string) error {
+			var err error
+			c.filter, err = auditlogs.ParseQuery(value)
+			return err
+		})
+	}
+	return fs
+}
+
+func (c *command) argsUsage() string {
+	switch c.verb {
+	case verbGet, verbDelete, verbWait:
+		return " <id>"
+	}
+	return ""
+}
+
+func (c *command) checkArgs(args []string) error {
+	switch c.output {
+	case outputTable, outputJSON, outputYAML:
+	default:
+		return fmt.Errorf("unknown output format %q", c.output)
+	}
+	if c.resource.namespaced && c.namespace == "" {
+		return errors.New("-namespace is required")
+	}
+	switch c.verb {
+	case verbGet, verbDelete, verbWait:
+		if len(args) != 1 {
+			return fmt.Errorf("%s expects exactly one id", c.verb)
+		}
+		c.id = args[0]
+		return nil
+	case verbCreate, verbApply:
+		if c.file == "" {
+			return fmt.Errorf("%s expects a file, set -f", c.verb)
+		}
+	}
+	if len(args) != 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(args, " "))
+	}
+	return nil
+}
+
+func (c *command) run(ctx context.Context) error {
+	switch c.verb {
+	case verbGet:
+		m, err := c.resource.get(ctx, c, c.id)
+		if err != nil {
+			return err
+		}
+		return c.print([]proto.Message{m}, false)
+	case verbList:
+		if c.resource.timeRange {
+			if c.end.IsZero() {
+				c.end.Time = time.Now()
+			}
+			if c.start.IsZero() {
+				c.start.Time = c.end.Add(-24 * time.Hour)
+			}
+		}
+		ms, err := c.resource.list(ctx, c)
+		if err != nil {
+			return err
+		}
+		return c.print(ms, true)
+	case verbCreate, verbApply:
+		data, err := c.readFile()
+		if err != nil {
+			return err
+		}
+		mutate := c.resource.create
+		if c.verb == verbApply {
+			mutate = c.resource.apply
+		}
+		result, err := mutate(ctx, c, data)
+		if err != nil {
+			return err
+		}
+		return c.completeMutation(ctx, result)
+	case verbDelete:
+		result, err := c.resource.delete(ctx, c, c.id)
+		if err != nil {
+			return err
+		}
+		return c.completeMutation(ctx, result)
+	case verbWait:
+		ctx, cancel := context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+		wait := c.resource.wait
+		if wait == nil {
+			wait = c.waitSettled
+		}
+		m, err := wait(ctx, c, c.id)
+		if err != nil {
+			return err
+		}
+		if m == nil {
+			fmt.Fprintf(c.stdout, "%s %q deleted\n", c.resource.singular, c.id)
+			return nil
+		}
+		return c.print([]proto.Message{m}, false)
+	}
+	return fmt.Errorf("unknown verb %q", c.verb)
+}
+
+func (c *command) readFile() ([]byte, error) {
+	if c.file == "-" {
+		return io.ReadAll(c.stdin)
+	}
+	return os.ReadFile(c.file)
+}
+
+func (c *command) completeMutation(ctx context.Context, result mutation) error {
+	if c.wait && result.AsyncOperationID != "" {
+		ctx, cancel := context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+		if _, err := c.client.AsyncOperations().Wait(ctx, result.AsyncOperationID); err != nil {
+			return err
+		}
+	}
+	return c.printMutation(result)
+}
+
+// waitSettled polls a resource until it is no longer activating, updating or deleting.
+// It returns a nil message once the resource is gone.
+func (c *command) waitSettled(ctx context.Context, _ *command, id string) (proto.Message, error) {
+	for {
+		m, err := c.resource.get(ctx, c, id)
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		settled := c.resource.settled
+		if settled == nil {
+			settled = resourceSettled
+		}
+		done, err := settled(m)
+		if done || err != nil {
+			return m, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%s %q did not settle: %w", c.resource.singular, id, ctx.Err())
+		case <-time.After(waitPollInterval):
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"flag"
+	"io/fs"
+
+	"go.temporal.io/cloud-sdk/cloudclient"
+)
+
+// connection holds the flags to connect to the cloud operations API, they take precedence over the profile.
+type connection struct {
+	configFile    string
+	profile       string
+	apiKey        string
+	hostPort      string
+	apiVersion    string
+	allowInsecure bool
+	tlsCAFile     string
+	tlsServerName string
+}
+
+func (c *connection) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.configFile, "config", "", "path of the config file (default $TEMPORAL_CLOUD_CONFIG_FILE or temporalcloud/config.yaml in the user config directory)")
+	fs.StringVar(&c.profile, "profile", "", "profile of the config file to connect with (default $TEMPORAL_CLOUD_PROFILE or the default profile)")
+	fs.StringVar(&c.apiKey, "api-key", "", "API key to authenticate with (default $TEMPORAL_CLOUD_API_KEY or the profile API key)")
+	fs.StringVar(&c.hostPort, "host-port", "", "host:port of the cloud operations API")
+	fs.StringVar(&c.apiVersion, "api-version", "", "version of the cloud operations API (default "+cloudclient.DefaultAPIVersion()+")")
+	fs.BoolVar(&c.allowInsecure, "insecure", false, "connect without TLS, for testing purposes only")
+	fs.StringVar(&c.tlsCAFile, "tls-ca-file", "", "PEM file of the certificate authorities to verify the server with")
+	fs.StringVar(&c.tlsServerName, "tls-server-name", "", "server name to verify the server certificate with")
+}
+
+func (c *connection) options() (cloudclient.Options, error) {
+	cfg, err := c.loadConfig()
+	if err != nil {
+		return cloudclient.Options{}, err
+	}
+	options, err := cfg.Options(c.profile)
+	if err != nil {
+		return cloudclient.Options{}, err
+	}
+
+	if c.apiKey != "" {
+		options.APIKey, options.APIKeyReader = c.apiKey, nil
+	}
+	if c.hostPort != "" {
+		options.HostPort = c.hostPort
+	}
+	if c.apiVersion != "" {
+		options.APIVersion = c.apiVersion
+	}
+	if c.allowInsecure {
+		options.AllowInsecure = true
+	}
+	// the TLS flags override the fields of the TLS config of the profile, not the whole config.
+	if c.tlsCAFile != "" || c.tlsServerName != "" {
+		if options.TLSConfig == nil {
+			options.TLSConfig = &tls.Config{}
+		} else {
+			options.TLSConfig = options.TLSConfig.Clone()
+		}
+		if c.tlsCAFile != "" {
+			caOptions, err := cloudclient.Profile{CAFile: c.tlsCAFile}.Options()
+			if err != nil {
+				return cloudclient.Options{}, err
+			}
+			options.TLSConfig.RootCAs = caOptions.TLSConfig.RootCAs
+		}
+		if c.tlsServerName != "" {
+			options.TLSConfig.ServerName = c.tlsServerName
+		}
+	}
+	if options.UserAgent == "" {
+		options.UserAgent = "tcloudctl"
+	}
+	if options.APIKey == "" && options.APIKeyReader == nil {
+		return cloudclient.Options{}, errors.New("no API key, set -api-key, $TEMPORAL_CLOUD_API_KEY or the api_key source of the profile")
+	}
+	return options, nil
+}
+
+func (c *connection) newClient() (*cloudclient.Client, error) {
+	options, err := c.options()
+	if err != nil {
+		return nil, err
+	}
+	return cloudclient.New(options)
+}
+
+// loadConfig reads the config file, a missing file is only an error if it was explicitly given.
+func (c *connection) loadConfig() (*cloudclient.Config, error) {
+	path := c.configFile
+	if path == "" {
+		var err error
+		if path, err = cloudclient.DefaultConfigFile(); err != nil {
+			return &cloudclient.Config{}, nil
+		}
+	}
+	cfg, err := cloudclient.LoadConfig(path)
+	if errors.Is(err, fs.ErrNotExist) && c.configFile == "" {
+		return &cloudclient.Config{}, nil
+	}
+	return cfg, err
+}
@@ -0,0 +1,107 @@
+// Command tcloudctl manages the resources of a Temporal Cloud account from the command line.
+//
+// Usage:
+//
+//	tcloudctl <resource> <verb> [flags] [id]
+//
+// The verbs are:
+//
+//	get      print a resource
+//	list     print every resource
+//	create   create a resource from the spec in the file given by -f
+//	apply    create or update a resource from the file given by -f
+//	delete   delete a resource
+//	wait     wait until a resource, or an async operation, settles
+//
+// The files given by -f are in JSON or YAML, "-" reads from the standard input.
+// The output format is selected with -o, one of table, json or yaml.
+//
+// The connection options are read from a profile of the config file shared with cloudclient.NewFromProfile,
+// selected with -profile. The API key is read from the -api-key flag, the TEMPORAL_CLOUD_API_KEY environment
+// variable or the API key source of the profile, in that order. Run `tcloudctl help` for the list of resources.
+//
+// The config file is given by -config, $TEMPORAL_CLOUD_CONFIG_FILE, or defaults to cloudclient.DefaultConfigFile,
+// temporalcloud/config.yaml in the user config directory. tcloudctl has no config file or environment variables of its own:
+// the TEMPORAL_CLOUD_* variables documented by cloudclient.Config.Options apply, and the flags override them.
+//
+// WARNING: The command is currently experimental.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tcloudctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+		printUsage(stderr)
+		return flag.ErrHelp
+	}
+	r, ok := lookupResource(args[0])
+	if !ok {
+		printUsage(stderr)
+		return fmt.Errorf("unknown resource %q", args[0])
+	}
+	if len(args) == 1 {
+		fmt.Fprintf(stderr, "Usage: tcloudctl %s <%s> [flags] [id]\n", r.name, joinVerbs(r.verbs()))
+		return flag.ErrHelp
+	}
+	verb := args[1]
+	if !r.supports(verb) {
+		return fmt.Errorf("%s does not support %q, use one of: %s", r.name, verb, joinVerbs(r.verbs()))
+	}
+
+	cmd := &command{
+		resource: r,
+		verb:     verb,
+		stdin:    stdin,
+		stdout:   stdout,
+	}
+	fs := cmd.flagSet(stderr)
+	if err := fs.Parse(args[2:]); err != nil {
+		return err
+	}
+	if err := cmd.checkArgs(fs.Args()); err != nil {
+		fs.Usage()
+		return err
+	}
+
+	client, err := cmd.connection.newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	cmd.client = client
+
+	return cmd.run(ctx)
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: tcloudctl <resource> <verb> [flags] [id]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Resources:")
+	for _, r := range resources {
+		fmt.Fprintf(w, "  %-20s %s\n", r.name, joinVerbs(r.verbs()))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run `tcloudctl <resource> <verb> -h` for the flags of a verb.")
+}
@@ -0,0 +1,396 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	auditlogv1 "go.temporal.io/cloud-sdk/api/auditlog/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	operationv1 "go.temporal.io/cloud-sdk/api/operation/v1"
+	resourcev1 "go.temporal.io/cloud-sdk/api/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeCloudService struct {
+	cloudservicev1.UnimplementedCloudServiceServer
+
+	createdUser      *cloudservicev1.CreateUserRequest
+	updatedUser      *cloudservicev1.UpdateUserRequest
+	createdNamespace *cloudservicev1.CreateNamespaceRequest
+	updatedNamespace *cloudservicev1.UpdateNamespaceRequest
+	createdGroup     *cloudservicev1.CreateUserGroupRequest
+	updatedGroup     *cloudservicev1.UpdateUserGroupRequest
+}
+
+func (s *fakeCloudService) GetNamespaces(ctx context.Context, req *cloudservicev1.GetNamespacesRequest) (*cloudservicev1.GetNamespacesResponse, error) {
+	if req.GetPageToken() == "" {
+		return &cloudservicev1.GetNamespacesResponse{
+			Namespaces: []*namespacev1.Namespace{{
+				Namespace: "prod.a1b2c",
+				State:     resourcev1.ResourceState_RESOURCE_STATE_ACTIVE,
+				Spec:      &namespacev1.NamespaceSpec{Name: "prod", Regions: []string{"aws-us-east-1"}},
+			}},
+			NextPageToken: "2",
+		}, nil
+	}
+	return &cloudservicev1.GetNamespacesResponse{
+		Namespaces: []*namespacev1.Namespace{{
+			Namespace: "staging.a1b2c",
+			State:     resourcev1.ResourceState_RESOURCE_STATE_UPDATING,
+		}},
+	}, nil
+}
+
+func (s *fakeCloudService) GetNamespace(ctx context.Context, req *cloudservicev1.GetNamespaceRequest) (*cloudservicev1.GetNamespaceResponse, error) {
+	return &cloudservicev1.GetNamespaceResponse{
+		Namespace: &namespacev1.Namespace{
+			Namespace:       req.GetNamespace(),
+			ResourceVersion: "rv1",
+			State:           resourcev1.ResourceState_RESOURCE_STATE_ACTIVE,
+		},
+	}, nil
+}
+
+func (s *fakeCloudService) CreateNamespace(ctx context.Context, req *cloudservicev1.CreateNamespaceRequest) (*cloudservicev1.CreateNamespaceResponse, error) {
+	s.createdNamespace = req
+	return &cloudservicev1.CreateNamespaceResponse{
+		Namespace:      req.GetSpec().GetName() + ".a1b2c",
+		AsyncOperation: &operationv1.AsyncOperation{Id: "op4"},
+	}, nil
+}
+
+func (s *fakeCloudService) UpdateNamespace(ctx context.Context, req *cloudservicev1.UpdateNamespaceRequest) (*cloudservicev1.UpdateNamespaceResponse, error) {
+	s.updatedNamespace = req
+	return &cloudservicev1.UpdateNamespaceResponse{
+		AsyncOperation: &operationv1.AsyncOperation{Id: "op5"},
+	}, nil
+}
+
+func (s *fakeCloudService) GetUsers(ctx context.Context, req *cloudservicev1.GetUsersRequest) (*cloudservicev1.GetUsersResponse, error) {
+	if req.GetEmail() == "existing@example.com" {
+		return &cloudservicev1.GetUsersResponse{
+			Users: []*identityv1.User{{Id: "u1", ResourceVersion: "rv1", Spec: &identityv1.UserSpec{Email: req.GetEmail()}}},
+		}, nil
+	}
+	return &cloudservicev1.GetUsersResponse{}, nil
+}
+
+func (s *fakeCloudService) CreateUser(ctx context.Context, req *cloudservicev1.CreateUserRequest) (*cloudservicev1.CreateUserResponse, error) {
+	s.createdUser = req
+	return &cloudservicev1.CreateUserResponse{
+		UserId:         "u2",
+		AsyncOperation: &operationv1.AsyncOperation{Id: "op1"},
+	}, nil
+}
+
+func (s *fakeCloudService) UpdateUser(ctx context.Context, req *cloudservicev1.UpdateUserRequest) (*cloudservicev1.UpdateUserResponse, error) {
+	s.updatedUser = req
+	return &cloudservicev1.UpdateUserResponse{
+		AsyncOperation: &operationv1.AsyncOperation{Id: "op2"},
+	}, nil
+}
+
+func (s *fakeCloudService) GetUserGroups(ctx context.Context, req *cloudservicev1.GetUserGroupsRequest) (*cloudservicev1.GetUserGroupsResponse, error) {
+	if req.GetDisplayName() == "developers" {
+		return &cloudservicev1.GetUserGroupsResponse{
+			Groups: []*identityv1.UserGroup{{Id: "g1", ResourceVersion: "rv1", Spec: &identityv1.UserGroupSpec{DisplayName: req.GetDisplayName()}}},
+		}, nil
+	}
+	return &cloudservicev1.GetUserGroupsResponse{}, nil
+}
+
+func (s *fakeCloudService) CreateUserGroup(ctx context.Context, req *cloudservicev1.CreateUserGroupRequest) (*cloudservicev1.CreateUserGroupResponse, error) {
+	s.createdGroup = req
+	return &cloudservicev1.CreateUserGroupResponse{
+		GroupId:        "g2",
+		AsyncOperation: &operationv1.AsyncOperation{Id: "op6"},
+	}, nil
+}
+
+func (s *fakeCloudService) UpdateUserGroup(ctx context.Context, req *cloudservicev1.UpdateUserGroupRequest) (*cloudservicev1.UpdateUserGroupResponse, error) {
+	s.updatedGroup = req
+	return &cloudservicev1.UpdateUserGroupResponse{
+		AsyncOperation: &operationv1.AsyncOperation{Id: "op7"},
+	}, nil
+}
+
+func (s *fakeCloudService) CreateApiKey(ctx context.Context, req *cloudservicev1.CreateApiKeyRequest) (*cloudservicev1.CreateApiKeyResponse, error) {
+	return &cloudservicev1.CreateApiKeyResponse{
+		KeyId:          "k1",
+		Token:          "secret",
+		AsyncOperation: &operationv1.AsyncOperation{Id: "op3"},
+	}, nil
+}
+
+func (s *fakeCloudService) GetAuditLogs(ctx context.Context, req *cloudservicev1.GetAuditLogsRequest) (*cloudservicev1.GetAuditLogsResponse, error) {
+	return &cloudservicev1.GetAuditLogsResponse{
+		Logs: []*auditlogv1.LogRecord{
+			{LogId: "l1", Operation: "CreateNamespace", Principal: &auditlogv1.Principal{Type: "user"}},
+			{LogId: "l2", Operation: "DeleteNamespace", Principal: &auditlogv1.Principal{Type: "service_account"}},
+		},
+	}, nil
+}
+
+func (s *fakeCloudService) GetAsyncOperation(ctx context.Context, req *cloudservicev1.GetAsyncOperationRequest) (*cloudservicev1.GetAsyncOperationResponse, error) {
+	if req.GetAsyncOperationId() != "op3" {
+		return nil, status.Error(codes.NotFound, "async operation not found")
+	}
+	return &cloudservicev1.GetAsyncOperationResponse{
+		AsyncOperation: &operationv1.AsyncOperation{
+			Id:    req.GetAsyncOperationId(),
+			State: operationv1.AsyncOperation_STATE_FULFILLED,
+		},
+	}, nil
+}
+
+func startFakeCloudService(t *testing.T) (*fakeCloudService, string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	fake := &fakeCloudService{}
+	server := grpc.NewServer()
+	cloudservicev1.RegisterCloudServiceServer(server, fake)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return fake, listener.Addr().String()
+}
+
+func TestRun(t *testing.T) {
+	fake, addr := startFakeCloudService(t)
+	t.Setenv("TEMPORAL_CLOUD_API_KEY", "test-key")
+	t.Setenv("TEMPORAL_CLOUD_CONFIG_FILE", "")
+	t.Setenv("TEMPORAL_CLOUD_PROFILE", "")
+
+	// tcloudctl runs the command with the connection flags inserted after the resource and the verb.
+	tcloudctl := func(t *testing.T, stdin string, args ...string) (string, error) {
+		t.Helper()
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		t.Setenv("HOME", t.TempDir())
+		var stdout, stderr bytes.Buffer
+		args = append(args[:2:2], append([]string{"-host-port", addr, "-insecure"}, args[2:]...)...)
+		err := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr)
+		return stdout.String(), err
+	}
+
+	t.Run("Missing Config File", func(t *testing.T) {
+		_, err := tcloudctl(t, "", "namespaces", "list", "-config", t.TempDir()+"/missing.yaml")
+		if err == nil || !strings.Contains(err.Error(), "config file") {
+			t.Errorf("run() error = %v, want an error for the missing config file", err)
+		}
+	})
+
+	t.Run("Missing Profile", func(t *testing.T) {
+		config := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(config, []byte("profiles:\n  staging:\n    api_key:\n      env: STAGING_KEY\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		_, err := tcloudctl(t, "", "namespaces", "list", "-config", config, "-profile", "production")
+		if err == nil || !strings.Contains(err.Error(), `profile "production" not found`) {
+			t.Errorf("run() error = %v, want an error for the missing profile", err)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		out, err := tcloudctl(t, "", "ns", "list")
+		if err != nil {
+			t.Fatalf("run() error = %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		if len(lines) != 3 || !strings.HasPrefix(lines[0], "NAME") {
+			t.Fatalf("run() output = %q, want a header and two rows", out)
+		}
+		if fields := strings.Fields(lines[1]); fields[0] != "prod.a1b2c" || fields[1] != "ACTIVE" || fields[2] != "aws-us-east-1" {
+			t.Errorf("run() row = %q", lines[1])
+		}
+	})
+
+	t.Run("Get JSON", func(t *testing.T) {
+		out, err := tcloudctl(t, "", "namespaces", "get", "-o", "json", "prod.a1b2c")
+		if err != nil {
+			t.Fatalf("run() error = %v", err)
+		}
+		var ns map[string]any
+		if err := json.Unmarshal([]byte(out), &ns); err != nil {
+			t.Fatalf("run() output is not JSON: %v\n%s", err, out)
+		}
+		if ns["namespace"] != "prod.a1b2c" || ns["state"] != "RESOURCE_STATE_ACTIVE" {
+			t.Errorf("run() output = %v", ns)
+		}
+	})
+
+	t.Run("Get YAML", func(t *testing.T) {
+		out, err := tcloudctl(t, "", "namespaces", "get", "-o", "yaml", "prod.a1b2c")
+		if err != nil {
+			t.Fatalf("run() error = %v", err)
+		}
+		if !strings.Contains(out, "namespace: prod.a1b2c\n") {
+			t.Errorf("run() output = %q", out)
+		}
+	})
+
+	t.Run("Apply Creates", func(t *testing.T) {
+		out, err := tcloudctl(t, "spec:\n  email: new@example.com\n", "users", "apply", "-f", "-")
+		if err != nil {
+			t.Fatalf("run() error = %v", err)
+		}
+		if fake.createdUser.GetSpec().GetEmail() != "new@example.com" {
+			t.Errorf("apply created %v", fake.createdUser)
+		}
+		if want := `user "u2" applied, async operation "op1"`; !strings.Contains(out, want) {
+			t.Errorf("run() output = %q, want %q", out, want)
+		}
+	})
+
+	t.Run("Apply Updates", func(t *testing.T) {
+		_, err := tcloudctl(t, `{"spec": {"email": "existing@example.com"}}`, "users", "apply", "-f", "-")
+		if err != nil {
+			t.Fatalf("run() error = %v", err)
+		}
+		if fake.updatedUser.GetUserId() != "u1" || fake.updatedUser.GetResourceVersion() != "rv1" {
+			t.Errorf("apply updated %v", fake.updatedUser)
+		}
+	})
+
+	t.Run("Apply Namespace By Spec Name", func(t *testing.T) {
+		_, err := tcloudctl(t, "spec:\n  name: prod\n  regions: [aws-us-east-1]\n  retentionDays: 30\n", "namespaces", "apply", "-f", "-")
+		if err != nil {
+			t.Fatalf("run() error = %v", err)
+		}
+		if fake.createdNamespace != nil || fake.updatedNamespace.GetNamespace() != "prod.a1b2c" || fake.updatedNamespace.GetResourceVersion() != "rv1" {
+			t.Errorf("apply created %v, updated %v, want prod.a1b2c updated", fake.createdNamespace, fake.updatedNamespace)
+		}
+
+		_, err = tcloudctl(t, "spec:\n  name: dev\n  regions: [aws-us-east-1]\n", "namespaces", "apply", "-f", "-")
+		if err != nil {
+			t.Fatalf("run() error = %v", err)
+		}
+		if fake.createdNamespace.GetSpec().GetName() != "dev" {
+			t.Errorf("apply created %v, want dev created", fake.createdNamespace)
+		}
+	})
+
+	t.Run("Apply Group By Display Name", func(t *testing.T) {
+		_, err := tcloudctl(t, "spec:\n  displayName: developers\n", "groups", "apply", "-f", "-")
+		if err != nil {
+			t.Fatalf("run() error = %v", err)
+		}
+		if fake.createdGroup != nil || fake.updatedGroup.GetGroupId() != "g1" || fake.updatedGroup.GetResourceVersion() != "rv1" {
+			t.Errorf("apply created %v, updated %v, want g1 updated", fake.createdGroup, fake.updatedGroup)
+		}
+
+		_, err = tcloudctl(t, "spec:\n  displayName: operators\n", "groups", "apply", "-f", "-")
+		if err != nil {
+			t.Fatalf("run() error = %v", err)
+		}
+		if fake.createdGroup.GetSpec().GetDisplayName() != "operators" {
+			t.Errorf("apply created %v, want operators created", fake.createdGroup)
+		}
+	})
+
+	t.Run("Create Wait", func(t *testing.T) {
+		out, err := tcloudctl(t, "displayName: ci\nownerId: sa1\n", "api-keys", "create", "-f", "-", "-wait", "-o", "json")
+		if err != nil {
+			t.Fatalf("run() error = %v", err)
+		}
+		var result mutation
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("run() output is not JSON: %v\n%s", err, out)
+		}
+		if result != (mutation{ID: "k1", AsyncOperationID: "op3", Token: "secret"}) {
+			t.Errorf("run() output = %+v", result)
+		}
+	})
+
+	t.Run("List Filter", func(t *testing.T) {
+		out, err := tcloudctl(t, "", "audit-logs", "list", "-filter", `operation =~ "Delete.*"`)
+		if err != nil {
+			t.Fatalf("run() error = %v", err)
+		}
+		if !strings.Contains(out, "l2") || strings.Contains(out, "l1") {
+			t.Errorf("run() output = %q, want only the log l2", out)
+		}
+	})
+
+	t.Run("Unsupported Verb", func(t *testing.T) {
+		_, err := tcloudctl(t, "", "usage", "delete")
+		if err == nil || !strings.Contains(err.Error(), "does not support") {
+			t.Errorf("run() error = %v, want an unsupported verb error", err)
+		}
+	})
+}
+
+// writeCAFile writes a self-signed CA certificate in a PEM file and returns its path.
+func writeCAFile(t *testing.T) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestConnectionTLS(t *testing.T) {
+	t.Setenv("TEMPORAL_CLOUD_API_KEY", "test-key")
+	t.Setenv("TEMPORAL_CLOUD_PROFILE", "")
+	t.Setenv("TEMPORAL_CLOUD_CA_FILE", "")
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	profile := "profiles:\n  default:\n    ca_file: " + writeCAFile(t) + "\n    tls_server_name: profile.example\n"
+	if err := os.WriteFile(config, []byte(profile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the server name is overridden, the CA of the profile is kept.
+	c := connection{configFile: config, tlsServerName: "flag.example"}
+	options, err := c.options()
+	if err != nil {
+		t.Fatalf("options() error = %v", err)
+	}
+	if options.TLSConfig.ServerName != "flag.example" || options.TLSConfig.RootCAs == nil {
+		t.Errorf("options() TLS config = %+v, want the flag server name and the profile CA", options.TLSConfig)
+	}
+
+	// Only the CA is overridden, the server name of the profile is kept.
+	c = connection{configFile: config, tlsCAFile: writeCAFile(t)}
+	if options, err = c.options(); err != nil {
+		t.Fatalf("options() error = %v", err)
+	}
+	if options.TLSConfig.ServerName != "profile.example" || options.TLSConfig.RootCAs == nil {
+		t.Errorf("options() TLS config = %+v, want the profile server name and the flag CA", options.TLSConfig)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// print writes the messages in the output format, list prints an array, even of one message, in json and yaml.
+func (c *command) print(ms []proto.Message, list bool) error {
+	if c.output == outputTable {
+		w := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, strings.Join(c.resource.columns, "\t"))
+		for _, m := range ms {
+			for _, row := range c.resource.rows(m) {
+				fmt.Fprintln(w, strings.Join(row, "\t"))
+			}
+		}
+		return w.Flush()
+	}
+
+	values := make([]any, len(ms))
+	for i, m := range ms {
+		v, err := toValue(m)
+		if err != nil {
+			return err
+		}
+		values[i] = v
+	}
+	if list {
+		return c.encode(values)
+	}
+	return c.encode(values[0])
+}
+
+func (c *command) printMutation(result mutation) error {
+	if c.output != outputTable {
+		return c.encode(result)
+	}
+	past := map[string]string{verbCreate: "created", verbApply: "applied", verbDelete: "deleted"}[c.verb]
+	if c.verb == verbDelete && !c.wait {
+		past = "deleting"
+	}
+	fmt.Fprintf(c.stdout, "%s %q %s", c.resource.singular, result.ID, past)
+	if result.AsyncOperationID != "" {
+		fmt.Fprintf(c.stdout, ", async operation %q", result.AsyncOperationID)
+	}
+	fmt.Fprintln(c.stdout)
+	if result.Token != "" {
+		fmt.Fprintf(c.stdout, "token: %s\n", result.Token)
+	}
+	return nil
+}
+
+func (c *command) encode(v any) error {
+	if c.output == outputYAML {
+		enc := yaml.NewEncoder(c.stdout)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+	enc := json.NewEncoder(c.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// toValue converts a message to its protojson representation as maps and slices,
+// so that it can be encoded in json and yaml alike.
+func toValue(m proto.Message) (any, error) {
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %T: %w", m, err)
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// unmarshal parses a JSON or YAML document into m, with the protojson field names.
+func unmarshal(data []byte, m proto.Message) error {
+	var v any
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("failed to parse the file: %w", err)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to parse the file: %w", err)
+	}
+	if err := protojson.Unmarshal(data, m); err != nil {
+		return fmt.Errorf("failed to parse the file as %s: %w", m.ProtoReflect().Descriptor().FullName(), err)
+	}
+	return nil
+}
+
+func formatTime(t *timestamppb.Timestamp) string {
+	if t == nil {
+		return ""
+	}
+	return t.AsTime().UTC().Format(time.RFC3339)
+}
+
+// formatEnum trims the prefix of an enum value name, RESOURCE_STATE_ACTIVE becomes ACTIVE.
+func formatEnum(name string, prefix string) string {
+	return strings.TrimPrefix(name, prefix)
+}
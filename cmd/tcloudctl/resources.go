@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	resourcev1 "go.temporal.io/cloud-sdk/api/resource/v1"
+	"go.temporal.io/cloud-sdk/cloudclient"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type (
+	// resource describes how to run the verbs against a kind of resource, a nil function means the verb is not supported.
+	resource struct {
+		name     string
+		aliases  []string
+		singular string
+		// namespaced resources require the -namespace flag.
+		namespaced bool
+		// timeRange resources are listed over the time range given by the -start and -end flags.
+		timeRange bool
+		// filtered resources are listed through the audit log query given by the -filter flag.
+		filtered bool
+
+		columns []string
+		// row returns the table row of a message, multiRow the rows when a message spans several.
+		row      func(m proto.Message) []string
+		multiRow func(m proto.Message) [][]string
+
+		get    func(ctx context.Context, c *command, id string) (proto.Message, error)
+		list   func(ctx context.Context, c *command) ([]proto.Message, error)
+		create func(ctx context.Context, c *command, data []byte) (mutation, error)
+		apply  func(ctx context.Context, c *command, data []byte) (mutation, error)
+		delete func(ctx context.Context, c *command, id string) (mutation, error)
+		// wait defaults to polling get until settled reports the resource is done.
+		wait func(ctx context.Context, c *command, id string) (proto.Message, error)
+		// settled defaults to resourceSettled.
+		settled func(m proto.Message) (bool, error)
+	}
+
+	resourceWithState interface {
+		GetState() resourcev1.ResourceState
+	}
+)
+
+var resources = []*resource{
+	namespacesResource,
+	usersResource,
+	userGroupsResource,
+	serviceAccountsResource,
+	apiKeysResource,
+	customRolesResource,
+	nexusEndpointsResource,
+	connectivityRulesResource,
+	exportSinksResource,
+	auditLogSinksResource,
+	usageResource,
+	billingReportsResource,
+	auditLogsResource,
+	asyncOperationsResource,
+}
+
+func lookupResource(name string) (*resource, bool) {
+	for _, r := range resources {
+		if r.name == name {
+			return r, true
+		}
+		for _, alias := range r.aliases {
+			if alias == name {
+				return r, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (r *resource) verbs() []string {
+	var verbs []string
+	for _, v := range []struct {
+		verb      string
+		supported bool
+	}{
+		{verbGet, r.get != nil},
+		{verbList, r.list != nil},
+		{verbCreate, r.create != nil},
+		{verbApply, r.apply != nil},
+		{verbDelete, r.delete != nil},
+		{verbWait, r.wait != nil || r.get != nil},
+	} {
+		if v.supported {
+			verbs = append(verbs, v.verb)
+		}
+	}
+	return verbs
+}
+
+func (r *resource) supports(verb string) bool {
+	for _, v := range r.verbs() {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *resource) rows(m proto.Message) [][]string {
+	if r.multiRow != nil {
+		return r.multiRow(m)
+	}
+	return [][]string{r.row(m)}
+}
+
+func joinVerbs(verbs []string) string {
+	return strings.Join(verbs, "|")
+}
+
+// resourceSettled reports whether a resource is no longer activating, updating or deleting,
+// and returns an error if the resource ended up in a failed state.
+func resourceSettled(m proto.Message) (bool, error) {
+	r, ok := m.(resourceWithState)
+	if !ok {
+		return true, nil
+	}
+	switch state := r.GetState(); state {
+	case resourcev1.ResourceState_RESOURCE_STATE_ACTIVATING,
+		resourcev1.ResourceState_RESOURCE_STATE_UPDATING,
+		resourcev1.ResourceState_RESOURCE_STATE_DELETING:
+		return false, nil
+	case resourcev1.ResourceState_RESOURCE_STATE_ACTIVATION_FAILED,
+		resourcev1.ResourceState_RESOURCE_STATE_UPDATE_FAILED,
+		resourcev1.ResourceState_RESOURCE_STATE_DELETE_FAILED:
+		return true, fmt.Errorf("resource is in state %s", formatState(state))
+	}
+	return true, nil
+}
+
+func formatState(state resourcev1.ResourceState) string {
+	return formatEnum(state.String(), "RESOURCE_STATE_")
+}
+
+func isNotFound(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
+
+func operationID(op *cloudclient.AsyncOperation) string {
+	if op == nil {
+		return ""
+	}
+	return op.ID()
+}
+
+// applyByID creates the resource if id is empty or not found, and updates the current resource otherwise.
+func applyByID[T proto.Message](
+	ctx context.Context,
+	id string,
+	get func(ctx context.Context, id string) (T, error),
+	create func() (mutation, error),
+	update func(current T) (mutation, error),
+) (mutation, error) {
+	if id == "" {
+		return create()
+	}
+	current, err := get(ctx, id)
+	if isNotFound(err) {
+		return create()
+	}
+	if err != nil {
+		return mutation{}, err
+	}
+	return update(current)
+}
+
+// typed adapts a function of a concrete message to the proto.Message functions of a resource.
+func typed[T proto.Message, R any](f func(T) R) func(proto.Message) R {
+	return func(m proto.Message) R {
+		return f(m.(T))
+	}
+}
+
+func getter[T proto.Message](f func(ctx context.Context, c *command, id string) (T, error)) func(context.Context, *command, string) (proto.Message, error) {
+	return func(ctx context.Context, c *command, id string) (proto.Message, error) {
+		m, err := f(ctx, c, id)
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+}
+
+func lister[T proto.Message](f func(ctx context.Context, c *command) ([]T, error)) func(context.Context, *command) ([]proto.Message, error) {
+	return func(ctx context.Context, c *command) ([]proto.Message, error) {
+		items, err := f(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		ms := make([]proto.Message, len(items))
+		for i, item := range items {
+			ms[i] = item
+		}
+		return ms, nil
+	}
+}
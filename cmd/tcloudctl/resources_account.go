@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	accountv1 "go.temporal.io/cloud-sdk/api/account/v1"
+	auditlogv1 "go.temporal.io/cloud-sdk/api/auditlog/v1"
+	billingv1 "go.temporal.io/cloud-sdk/api/billing/v1"
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	operationv1 "go.temporal.io/cloud-sdk/api/operation/v1"
+	usagev1 "go.temporal.io/cloud-sdk/api/usage/v1"
+	"go.temporal.io/cloud-sdk/internal/paging"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var auditLogSinksResource = &resource{
+	name:     "audit-log-sinks",
+	aliases:  []string{"audit-log-sink"},
+	singular: "audit log sink",
+	columns:  []string{"NAME", "TYPE", "ENABLED", "HEALTH", "STATE", "LAST SUCCEEDED"},
+	row: typed(func(s *accountv1.AuditLogSink) []string {
+		sinkType := "kinesis"
+		if s.GetSpec().GetPubSubSink() != nil {
+			sinkType = "pubsub"
+		}
+		return []string{
+			s.GetName(),
+			sinkType,
+			strconv.FormatBool(s.GetSpec().GetEnabled()),
+			formatEnum(s.GetHealth().String(), "HEALTH_"),
+			formatState(s.GetState()),
+			formatTime(s.GetLastSucceededTime()),
+		}
+	}),
+	get: getter(getAuditLogSink),
+	list: lister(func(ctx context.Context, c *command) ([]*accountv1.AuditLogSink, error) {
+		return paging.All(ctx, func(ctx context.Context, pageToken string) ([]*accountv1.AuditLogSink, string, error) {
+			resp, err := c.client.CloudService().GetAccountAuditLogSinks(ctx, &cloudservicev1.GetAccountAuditLogSinksRequest{
+				PageToken: pageToken,
+			})
+			return resp.GetSinks(), resp.GetNextPageToken(), err
+		})
+	}),
+	create: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		spec := &accountv1.AuditLogSinkSpec{}
+		if err := unmarshal(data, spec); err != nil {
+			return mutation{}, err
+		}
+		resp, err := c.client.CloudService().CreateAccountAuditLogSink(ctx, &cloudservicev1.CreateAccountAuditLogSinkRequest{
+			Spec: spec,
+		})
+		return mutation{ID: spec.GetName(), AsyncOperationID: resp.GetAsyncOperation().GetId()}, err
+	},
+	apply: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		spec := &accountv1.AuditLogSinkSpec{}
+		if err := unmarshal(data, spec); err != nil {
+			return mutation{}, err
+		}
+		return applyByID(ctx, spec.GetName(),
+			func(ctx context.Context, name string) (*accountv1.AuditLogSink, error) {
+				return getAuditLogSink(ctx, c, name)
+			},
+			func() (mutation, error) {
+				resp, err := c.client.CloudService().CreateAccountAuditLogSink(ctx, &cloudservicev1.CreateAccountAuditLogSinkRequest{
+					Spec: spec,
+				})
+				return mutation{ID: spec.GetName(), AsyncOperationID: resp.GetAsyncOperation().GetId()}, err
+			},
+			func(current *accountv1.AuditLogSink) (mutation, error) {
+				resp, err := c.client.CloudService().UpdateAccountAuditLogSink(ctx, &cloudservicev1.UpdateAccountAuditLogSinkRequest{
+					Spec:            spec,
+					ResourceVersion: current.GetResourceVersion(),
+				})
+				return mutation{ID: spec.GetName(), AsyncOperationID: resp.GetAsyncOperation().GetId()}, err
+			},
+		)
+	},
+	delete: func(ctx context.Context, c *command, name string) (mutation, error) {
+		current, err := getAuditLogSink(ctx, c, name)
+		if err != nil {
+			return mutation{}, err
+		}
+		resp, err := c.client.CloudService().DeleteAccountAuditLogSink(ctx, &cloudservicev1.DeleteAccountAuditLogSinkRequest{
+			Name:            name,
+			ResourceVersion: current.GetResourceVersion(),
+		})
+		return mutation{ID: name, AsyncOperationID: resp.GetAsyncOperation().GetId()}, err
+	},
+}
+
+var usageResource = &resource{
+	name:      "usage",
+	singular:  "usage",
+	timeRange: true,
+	columns:   []string{"START", "END", "GROUP", "TYPE", "VALUE", "UNIT"},
+	multiRow: typed(func(s *usagev1.Summary) [][]string {
+		var rows [][]string
+		for _, group := range s.GetRecordGroups() {
+			groupBys := make([]string, 0, len(group.GetGroupBys()))
+			for _, g := range group.GetGroupBys() {
+				groupBys = append(groupBys, formatEnum(g.GetKey().String(), "GROUP_BY_KEY_")+"="+g.GetValue())
+			}
+			for _, r := range group.GetRecords() {
+				rows = append(rows, []string{
+					formatTime(s.GetStartTime()),
+					formatTime(s.GetEndTime()),
+					strings.Join(groupBys, ","),
+					formatEnum(r.GetType().String(), "RECORD_TYPE_"),
+					strconv.FormatFloat(r.GetValue(), 'f', -1, 64),
+					formatEnum(r.GetUnit().String(), "RECORD_UNIT_"),
+				})
+			}
+		}
+		return rows
+	}),
+	list: lister(func(ctx context.Context, c *command) ([]*usagev1.Summary, error) {
+		return paging.All(ctx, func(ctx context.Context, pageToken string) ([]*usagev1.Summary, string, error) {
+			resp, err := c.client.CloudService().GetUsage(ctx, &cloudservicev1.GetUsageRequest{
+				StartTimeInclusive: timestamppb.New(c.start.Time),
+				EndTimeExclusive:   timestamppb.New(c.end.Time),
+				PageToken:          pageToken,
+			})
+			return resp.GetSummaries(), resp.GetNextPageToken(), err
+		})
+	}),
+}
+
+var billingReportsResource = &resource{
+	name:     "billing",
+	aliases:  []string{"billing-reports", "billing-report"},
+	singular: "billing report",
+	columns:  []string{"ID", "STATE", "START", "END", "REQUESTED", "DOWNLOADS"},
+	row: typed(func(r *billingv1.BillingReport) []string {
+		return []string{
+			r.GetId(),
+			formatEnum(r.GetState().String(), "BILLING_REPORT_STATE_"),
+			formatTime(r.GetSpec().GetStartTimeInclusive()),
+			formatTime(r.GetSpec().GetEndTimeExclusive()),
+			formatTime(r.GetRequestedTime()),
+			strconv.Itoa(len(r.GetDownloadInfo())),
+		}
+	}),
+	get: getter(func(ctx context.Context, c *command, id string) (*billingv1.BillingReport, error) {
+		resp, err := c.client.CloudService().GetBillingReport(ctx, &cloudservicev1.GetBillingReportRequest{
+			BillingReportId: id,
+		})
+		return resp.GetBillingReport(), err
+	}),
+	create: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		spec := &billingv1.BillingReportSpec{}
+		if err := unmarshal(data, spec); err != nil {
+			return mutation{}, err
+		}
+		resp, err := c.client.CloudService().CreateBillingReport(ctx, &cloudservicev1.CreateBillingReportRequest{
+			Spec: spec,
+		})
+		return mutation{ID: resp.GetBillingReportId(), AsyncOperationID: resp.GetAsyncOperation().GetId()}, err
+	},
+	settled: func(m proto.Message) (bool, error) {
+		r := m.(*billingv1.BillingReport)
+		switch r.GetState() {
+		case billingv1.BillingReport_BILLING_REPORT_STATE_IN_PROGRESS:
+			return false, nil
+		case billingv1.BillingReport_BILLING_REPORT_STATE_FAILED:
+			return true, fmt.Errorf("billing report %q failed", r.GetId())
+		}
+		return true, nil
+	},
+}
+
+var auditLogsResource = &resource{
+	name:      "audit-logs",
+	aliases:   []string{"audit-log"},
+	singular:  "audit log",
+	timeRange: true,
+	filtered:  true,
+	columns:   []string{"TIME", "OPERATION", "STATUS", "PRINCIPAL", "PRINCIPAL TYPE", "LOG ID"},
+	row: typed(func(l *auditlogv1.LogRecord) []string {
+		principal := l.GetPrincipal().GetName()
+		if principal == "" {
+			principal = l.GetPrincipal().GetId()
+		}
+		return []string{
+			formatTime(l.GetEmitTime()),
+			l.GetOperation(),
+			l.GetStatus(),
+			principal,
+			l.GetPrincipal().GetType(),
+			l.GetLogId(),
+		}
+	}),
+	list: lister(func(ctx context.Context, c *command) ([]*auditlogv1.LogRecord, error) {
+		logs, err := paging.All(ctx, func(ctx context.Context, pageToken string) ([]*auditlogv1.LogRecord, string, error) {
+			resp, err := c.client.CloudService().GetAuditLogs(ctx, &cloudservicev1.GetAuditLogsRequest{
+				StartTimeInclusive: timestamppb.New(c.start.Time),
+				EndTimeExclusive:   timestamppb.New(c.end.Time),
+				PageToken:          pageToken,
+			})
+			return resp.GetLogs(), resp.GetNextPageToken(), err
+		})
+		if c.filter != nil {
+			logs = slices.DeleteFunc(logs, func(l *auditlogv1.LogRecord) bool { return !c.filter.Match(l) })
+		}
+		return logs, err
+	}),
+}
+
+var asyncOperationsResource = &resource{
+	name:     "operations",
+	aliases:  []string{"operation", "ops"},
+	singular: "async operation",
+	columns:  []string{"ID", "TYPE", "STATE", "STARTED", "FINISHED", "FAILURE"},
+	row: typed(func(op *operationv1.AsyncOperation) []string {
+		return []string{
+			op.GetId(),
+			op.GetOperationType(),
+			formatEnum(op.GetState().String(), "STATE_"),
+			formatTime(op.GetStartedTime()),
+			formatTime(op.GetFinishedTime()),
+			op.GetFailureReason(),
+		}
+	}),
+	get: getter(func(ctx context.Context, c *command, id string) (*operationv1.AsyncOperation, error) {
+		return c.client.AsyncOperations().Get(ctx, id)
+	}),
+	wait: getter(func(ctx context.Context, c *command, id string) (*operationv1.AsyncOperation, error) {
+		return c.client.AsyncOperations().Wait(ctx, id)
+	}),
+}
+
+func getAuditLogSink(ctx context.Context, c *command, name string) (*accountv1.AuditLogSink, error) {
+	resp, err := c.client.CloudService().GetAccountAuditLogSink(ctx, &cloudservicev1.GetAccountAuditLogSinkRequest{
+		Name: name,
+	})
+	return resp.GetSink(), err
+}
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+)
+
+var usersResource = &resource{
+	name:     "users",
+	aliases:  []string{"user"},
+	singular: "user",
+	columns:  []string{"ID", "EMAIL", "ROLE", "STATE", "CREATED"},
+	row: typed(func(u *identityv1.User) []string {
+		return []string{
+			u.GetId(),
+			u.GetSpec().GetEmail(),
+			formatRole(u.GetSpec().GetAccess()),
+			formatState(u.GetState()),
+			formatTime(u.GetCreatedTime()),
+		}
+	}),
+	get: getter(func(ctx context.Context, c *command, id string) (*identityv1.User, error) {
+		return c.client.Users().Get(ctx, id)
+	}),
+	list: lister(func(ctx context.Context, c *command) ([]*identityv1.User, error) {
+		return c.client.Users().List(ctx)
+	}),
+	create: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		spec := &identityv1.UserSpec{}
+		if err := unmarshal(data, spec); err != nil {
+			return mutation{}, err
+		}
+		id, op, err := c.client.Users().Create(ctx, spec)
+		return mutation{ID: id, AsyncOperationID: operationID(op)}, err
+	},
+	// apply matches the user by id, or by email if the file has no id.
+	apply: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		user := &identityv1.User{}
+		if err := unmarshal(data, user); err != nil {
+			return mutation{}, err
+		}
+		get := c.client.Users().Get
+		id := user.GetId()
+		if id == "" {
+			get = func(ctx context.Context, _ string) (*identityv1.User, error) {
+				return c.client.Users().GetByEmail(ctx, user.GetSpec().GetEmail())
+			}
+			id = user.GetSpec().GetEmail()
+		}
+		return applyByID(ctx, id, get,
+			func() (mutation, error) {
+				id, op, err := c.client.Users().Create(ctx, user.GetSpec())
+				return mutation{ID: id, AsyncOperationID: operationID(op)}, err
+			},
+			func(current *identityv1.User) (mutation, error) {
+				op, err := c.client.Users().Update(ctx, &identityv1.User{
+					Id:              current.GetId(),
+					Spec:            user.GetSpec(),
+					ResourceVersion: current.GetResourceVersion(),
+				})
+				return mutation{ID: current.GetId(), AsyncOperationID: operationID(op)}, err
+			},
+		)
+	},
+	delete: func(ctx context.Context, c *command, id string) (mutation, error) {
+		op, err := c.client.Users().Delete(ctx, id, "")
+		return mutation{ID: id, AsyncOperationID: operationID(op)}, err
+	},
+}
+
+var userGroupsResource = &resource{
+	name:     "groups",
+	aliases:  []string{"group", "user-groups"},
+	singular: "group",
+	columns:  []string{"ID", "NAME", "TYPE", "ROLE", "STATE"},
+	row: typed(func(g *identityv1.UserGroup) []string {
+		return []string{
+			g.GetId(),
+			g.GetSpec().GetDisplayName(),
+			formatGroupType(g.GetSpec()),
+			formatRole(g.GetSpec().GetAccess()),
+			formatState(g.GetState()),
+		}
+	}),
+	get: getter(func(ctx context.Context, c *command, id string) (*identityv1.UserGroup, error) {
+		return c.client.UserGroups().Get(ctx, id)
+	}),
+	list: lister(func(ctx context.Context, c *command) ([]*identityv1.UserGroup, error) {
+		return c.client.UserGroups().List(ctx)
+	}),
+	create: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		spec := &identityv1.UserGroupSpec{}
+		if err := unmarshal(data, spec); err != nil {
+			return mutation{}, err
+		}
+		id, op, err := c.client.UserGroups().Create(ctx, spec)
+		return mutation{ID: id, AsyncOperationID: operationID(op)}, err
+	},
+	// apply matches the group by id, or by display name if the file has no id.
+	apply: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		group := &identityv1.UserGroup{}
+		if err := unmarshal(data, group); err != nil {
+			return mutation{}, err
+		}
+		get := c.client.UserGroups().Get
+		id := group.GetId()
+		if id == "" {
+			get = func(ctx context.Context, _ string) (*identityv1.UserGroup, error) {
+				return c.client.UserGroups().GetByDisplayName(ctx, group.GetSpec().GetDisplayName())
+			}
+			id = group.GetSpec().GetDisplayName()
+		}
+		return applyByID(ctx, id, get,
+			func() (mutation, error) {
+				id, op, err := c.client.UserGroups().Create(ctx, group.GetSpec())
+				return mutation{ID: id, AsyncOperationID: operationID(op)}, err
+			},
+			func(current *identityv1.UserGroup) (mutation, error) {
+				op, err := c.client.UserGroups().Update(ctx, &identityv1.UserGroup{
+					Id:              current.GetId(),
+					Spec:            group.GetSpec(),
+					ResourceVersion: current.GetResourceVersion(),
+				})
+				return mutation{ID: current.GetId(), AsyncOperationID: operationID(op)}, err
+			},
+		)
+	},
+	delete: func(ctx context.Context, c *command, id string) (mutation, error) {
+		op, err := c.client.UserGroups().Delete(ctx, id, "")
+		return mutation{ID: id, AsyncOperationID: operationID(op)}, err
+	},
+}
+
+var serviceAccountsResource = &resource{
+	name:     "service-accounts",
+	aliases:  []string{"service-account", "sa"},
+	singular: "service account",
+	columns:  []string{"ID", "NAME", "ROLE", "STATE", "CREATED"},
+	row: typed(func(sa *identityv1.ServiceAccount) []string {
+		role := formatRole(sa.GetSpec().GetAccess())
+		if scoped := sa.GetSpec().GetNamespaceScopedAccess(); scoped != nil {
+			role = "namespace " + scoped.GetNamespace()
+		}
+		return []string{
+			sa.GetId(),
+			sa.GetSpec().GetName(),
+			role,
+			formatState(sa.GetState()),
+			formatTime(sa.GetCreatedTime()),
+		}
+	}),
+	get: getter(func(ctx context.Context, c *command, id string) (*identityv1.ServiceAccount, error) {
+		return c.client.ServiceAccounts().Get(ctx, id)
+	}),
+	list: lister(func(ctx context.Context, c *command) ([]*identityv1.ServiceAccount, error) {
+		return c.client.ServiceAccounts().List(ctx)
+	}),
+	create: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		spec := &identityv1.ServiceAccountSpec{}
+		if err := unmarshal(data, spec); err != nil {
+			return mutation{}, err
+		}
+		id, op, err := c.client.ServiceAccounts().Create(ctx, spec)
+		return mutation{ID: id, AsyncOperationID: operationID(op)}, err
+	},
+	apply: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		sa := &identityv1.ServiceAccount{}
+		if err := unmarshal(data, sa); err != nil {
+			return mutation{}, err
+		}
+		return applyByID(ctx, sa.GetId(), c.client.ServiceAccounts().Get,
+			func() (mutation, error) {
+				id, op, err := c.client.ServiceAccounts().Create(ctx, sa.GetSpec())
+				return mutation{ID: id, AsyncOperationID: operationID(op)}, err
+			},
+			func(current *identityv1.ServiceAccount) (mutation, error) {
+				op, err := c.client.ServiceAccounts().Update(ctx, &identityv1.ServiceAccount{
+					Id:              current.GetId(),
+					Spec:            sa.GetSpec(),
+					ResourceVersion: current.GetResourceVersion(),
+				})
+				return mutation{ID: current.GetId(), AsyncOperationID: operationID(op)}, err
+			},
+		)
+	},
+	delete: func(ctx context.Context, c *command, id string) (mutation, error) {
+		op, err := c.client.ServiceAccounts().Delete(ctx, id, "")
+		return mutation{ID: id, AsyncOperationID: operationID(op)}, err
+	},
+}
+
+var apiKeysResource = &resource{
+	name:     "api-keys",
+	aliases:  []string{"api-key", "keys"},
+	singular: "API key",
+	columns:  []string{"ID", "NAME", "OWNER", "OWNER TYPE", "DISABLED", "EXPIRES", "STATE"},
+	row: typed(func(k *identityv1.ApiKey) []string {
+		return []string{
+			k.GetId(),
+			k.GetSpec().GetDisplayName(),
+			k.GetSpec().GetOwnerId(),
+			formatEnum(k.GetSpec().GetOwnerType().String(), "OWNER_TYPE_"),
+			strconv.FormatBool(k.GetSpec().GetDisabled()),
+			formatTime(k.GetSpec().GetExpiryTime()),
+			formatState(k.GetState()),
+		}
+	}),
+	get: getter(func(ctx context.Context, c *command, id string) (*identityv1.ApiKey, error) {
+		return c.client.APIKeys().Get(ctx, id)
+	}),
+	list: lister(func(ctx context.Context, c *command) ([]*identityv1.ApiKey, error) {
+		return c.client.APIKeys().List(ctx)
+	}),
+	create: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		spec := &identityv1.ApiKeySpec{}
+		if err := unmarshal(data, spec); err != nil {
+			return mutation{}, err
+		}
+		id, token, op, err := c.client.APIKeys().Create(ctx, spec)
+		return mutation{ID: id, AsyncOperationID: operationID(op), Token: token}, err
+	},
+	apply: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		key := &identityv1.ApiKey{}
+		if err := unmarshal(data, key); err != nil {
+			return mutation{}, err
+		}
+		return applyByID(ctx, key.GetId(), c.client.APIKeys().Get,
+			func() (mutation, error) {
+				id, token, op, err := c.client.APIKeys().Create(ctx, key.GetSpec())
+				return mutation{ID: id, AsyncOperationID: operationID(op), Token: token}, err
+			},
+			func(current *identityv1.ApiKey) (mutation, error) {
+				op, err := c.client.APIKeys().Update(ctx, &identityv1.ApiKey{
+					Id:              current.GetId(),
+					Spec:            key.GetSpec(),
+					ResourceVersion: current.GetResourceVersion(),
+				})
+				return mutation{ID: current.GetId(), AsyncOperationID: operationID(op)}, err
+			},
+		)
+	},
+	delete: func(ctx context.Context, c *command, id string) (mutation, error) {
+		op, err := c.client.APIKeys().Delete(ctx, id, "")
+		return mutation{ID: id, AsyncOperationID: operationID(op)}, err
+	},
+}
+
+var customRolesResource = &resource{
+	name:     "roles",
+	aliases:  []string{"role", "custom-roles"},
+	singular: "custom role",
+	columns:  []string{"ID", "NAME", "PERMISSIONS", "STATE"},
+	row: typed(func(r *identityv1.CustomRole) []string {
+		return []string{
+			r.GetId(),
+			r.GetSpec().GetName(),
+			strconv.Itoa(len(r.GetSpec().GetPermissions())),
+			formatState(r.GetState()),
+		}
+	}),
+	get: getter(func(ctx context.Context, c *command, id string) (*identityv1.CustomRole, error) {
+		return c.client.CustomRoles().Get(ctx, id)
+	}),
+	list: lister(func(ctx context.Context, c *command) ([]*identityv1.CustomRole, error) {
+		return c.client.CustomRoles().List(ctx)
+	}),
+	create: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		spec := &identityv1.CustomRoleSpec{}
+		if err := unmarshal(data, spec); err != nil {
+			return mutation{}, err
+		}
+		id, op, err := c.client.CustomRoles().Create(ctx, spec)
+		return mutation{ID: id, AsyncOperationID: operationID(op)}, err
+	},
+	apply: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		role := &identityv1.CustomRole{}
+		if err := unmarshal(data, role); err != nil {
+			return mutation{}, err
+		}
+		return applyByID(ctx, role.GetId(), c.client.CustomRoles().Get,
+			func() (mutation, error) {
+				id, op, err := c.client.CustomRoles().Create(ctx, role.GetSpec())
+				return mutation{ID: id, AsyncOperationID: operationID(op)}, err
+			},
+			func(current *identityv1.CustomRole) (mutation, error) {
+				op, err := c.client.CustomRoles().Update(ctx, &identityv1.CustomRole{
+					Id:              current.GetId(),
+					Spec:            role.GetSpec(),
+					ResourceVersion: current.GetResourceVersion(),
+				})
+				return mutation{ID: current.GetId(), AsyncOperationID: operationID(op)}, err
+			},
+		)
+	},
+	delete: func(ctx context.Context, c *command, id string) (mutation, error) {
+		op, err := c.client.CustomRoles().Delete(ctx, id, "")
+		return mutation{ID: id, AsyncOperationID: operationID(op)}, err
+	},
+}
+
+func formatRole(access *identityv1.Access) string {
+	return formatEnum(access.GetAccountAccess().GetRole().String(), "ROLE_")
+}
+
+func formatGroupType(spec *identityv1.UserGroupSpec) string {
+	switch {
+	case spec.GetGoogleGroup() != nil:
+		return "google"
+	case spec.GetScimGroup() != nil:
+		return "scim"
+	case spec.GetCloudGroup() != nil:
+		return "cloud"
+	}
+	return ""
+}
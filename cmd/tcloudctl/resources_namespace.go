@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	connectivityrulev1 "go.temporal.io/cloud-sdk/api/connectivityrule/v1"
+	namespacev1 "go.temporal.io/cloud-sdk/api/namespace/v1"
+	nexusv1 "go.temporal.io/cloud-sdk/api/nexus/v1"
+	"go.temporal.io/cloud-sdk/internal/paging"
+)
+
+var namespacesResource = &resource{
+	name:     "namespaces",
+	aliases:  []string{"namespace", "ns"},
+	singular: "namespace",
+	columns:  []string{"NAME", "STATE", "REGIONS", "ACTIVE REGION", "CREATED"},
+	row: typed(func(ns *namespacev1.Namespace) []string {
+		return []string{
+			ns.GetNamespace(),
+			formatState(ns.GetState()),
+			strings.Join(ns.GetSpec().GetRegions(), ","),
+			ns.GetActiveRegion(),
+			formatTime(ns.GetCreatedTime()),
+		}
+	}),
+	get: getter(func(ctx context.Context, c *command, name string) (*namespacev1.Namespace, error) {
+		return c.client.Namespaces().Get(ctx, name)
+	}),
+	list: lister(func(ctx context.Context, c *command) ([]*namespacev1.Namespace, error) {
+		return c.client.Namespaces().List(ctx)
+	}),
+	create: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		spec := &namespacev1.NamespaceSpec{}
+		if err := unmarshal(data, spec); err != nil {
+			return mutation{}, err
+		}
+		name, op, err := c.client.Namespaces().Create(ctx, spec, nil)
+		return mutation{ID: name, AsyncOperationID: operationID(op)}, err
+	},
+	// apply matches the namespace by name, or by the name of its spec if the file has no namespace name.
+	// It creates the namespace with its tags, but only updates the spec of an existing namespace.
+	apply: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		ns := &namespacev1.Namespace{}
+		if err := unmarshal(data, ns); err != nil {
+			return mutation{}, err
+		}
+		name := ns.GetNamespace()
+		if name == "" {
+			var err error
+			if name, err = namespaceBySpecName(ctx, c, ns.GetSpec().GetName()); err != nil {
+				return mutation{}, err
+			}
+		}
+		return applyByID(ctx, name, c.client.Namespaces().Get,
+			func() (mutation, error) {
+				name, op, err := c.client.Namespaces().Create(ctx, ns.GetSpec(), ns.GetTags())
+				return mutation{ID: name, AsyncOperationID: operationID(op)}, err
+			},
+			func(current *namespacev1.Namespace) (mutation, error) {
+				op, err := c.client.Namespaces().Update(ctx, &namespacev1.Namespace{
+					Namespace:       current.GetNamespace(),
+					Spec:            ns.GetSpec(),
+					ResourceVersion: current.GetResourceVersion(),
+				})
+				return mutation{ID: current.GetNamespace(), AsyncOperationID: operationID(op)}, err
+			},
+		)
+	},
+	delete: func(ctx context.Context, c *command, name string) (mutation, error) {
+		op, err := c.client.Namespaces().Delete(ctx, name, "")
+		return mutation{ID: name, AsyncOperationID: operationID(op)}, err
+	},
+}
+
+// namespaceBySpecName returns the name of the namespace of the account with the given spec name, i.e. without the
+// account suffix, or an empty name if there is none.
+func namespaceBySpecName(ctx context.Context, c *command, specName string) (string, error) {
+	if specName == "" {
+		return "", nil
+	}
+	namespaces, err := c.client.Namespaces().List(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, ns := range namespaces {
+		if ns.GetSpec().GetName() == specName {
+			return ns.GetNamespace(), nil
+		}
+	}
+	return "", nil
+}
+
+var nexusEndpointsResource = &resource{
+	name:     "nexus-endpoints",
+	aliases:  []string{"nexus-endpoint", "endpoints"},
+	singular: "Nexus endpoint",
+	columns:  []string{"ID", "NAME", "TARGET NAMESPACE", "TASK QUEUE", "STATE"},
+	row: typed(func(e *nexusv1.Endpoint) []string {
+		target := e.GetSpec().GetTargetSpec().GetWorkerTargetSpec()
+		return []string{
+			e.GetId(),
+			e.GetSpec().GetName(),
+			target.GetNamespaceId(),
+			target.GetTaskQueue(),
+			formatState(e.GetState()),
+		}
+	}),
+	get: getter(func(ctx context.Context, c *command, id string) (*nexusv1.Endpoint, error) {
+		return c.client.NexusEndpoints().Get(ctx, id)
+	}),
+	list: lister(func(ctx context.Context, c *command) ([]*nexusv1.Endpoint, error) {
+		return c.client.NexusEndpoints().List(ctx)
+	}),
+	create: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		spec := &nexusv1.EndpointSpec{}
+		if err := unmarshal(data, spec); err != nil {
+			return mutation{}, err
+		}
+		id, op, err := c.client.NexusEndpoints().Create(ctx, spec)
+		return mutation{ID: id, AsyncOperationID: operationID(op)}, err
+	},
+	// apply matches the endpoint by id, or by name if the file has no id.
+	apply: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		endpoint := &nexusv1.Endpoint{}
+		if err := unmarshal(data, endpoint); err != nil {
+			return mutation{}, err
+		}
+		get := c.client.NexusEndpoints().Get
+		id := endpoint.GetId()
+		if id == "" {
+			get = c.client.NexusEndpoints().GetByName
+			id = endpoint.GetSpec().GetName()
+		}
+		return applyByID(ctx, id, get,
+			func() (mutation, error) {
+				id, op, err := c.client.NexusEndpoints().Create(ctx, endpoint.GetSpec())
+				return mutation{ID: id, AsyncOperationID: operationID(op)}, err
+			},
+			func(current *nexusv1.Endpoint) (mutation, error) {
+				op, err := c.client.NexusEndpoints().Update(ctx, &nexusv1.Endpoint{
+					Id:              current.GetId(),
+					Spec:            endpoint.GetSpec(),
+					ResourceVersion: current.GetResourceVersion(),
+				})
+				return mutation{ID: current.GetId(), AsyncOperationID: operationID(op)}, err
+			},
+		)
+	},
+	delete: func(ctx context.Context, c *command, id string) (mutation, error) {
+		op, err := c.client.NexusEndpoints().Delete(ctx, id, "")
+		return mutation{ID: id, AsyncOperationID: operationID(op)}, err
+	},
+}
+
+var connectivityRulesResource = &resource{
+	name:     "connectivity-rules",
+	aliases:  []string{"connectivity-rule", "rules"},
+	singular: "connectivity rule",
+	columns:  []string{"ID", "TYPE", "CONNECTION", "REGION", "STATE"},
+	row: typed(func(r *connectivityrulev1.ConnectivityRule) []string {
+		if private := r.GetSpec().GetPrivateRule(); private != nil {
+			return []string{r.GetId(), "private", private.GetConnectionId(), private.GetRegion(), formatState(r.GetState())}
+		}
+		return []string{r.GetId(), "public", "", "", formatState(r.GetState())}
+	}),
+	get: getter(func(ctx context.Context, c *command, id string) (*connectivityrulev1.ConnectivityRule, error) {
+		return c.client.ConnectivityRules().Get(ctx, id)
+	}),
+	list: lister(func(ctx context.Context, c *command) ([]*connectivityrulev1.ConnectivityRule, error) {
+		return c.client.ConnectivityRules().List(ctx)
+	}),
+	create: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		spec := &connectivityrulev1.ConnectivityRuleSpec{}
+		if err := unmarshal(data, spec); err != nil {
+			return mutation{}, err
+		}
+		id, op, err := c.client.ConnectivityRules().Create(ctx, spec)
+		return mutation{ID: id, AsyncOperationID: operationID(op)}, err
+	},
+	delete: func(ctx context.Context, c *command, id string) (mutation, error) {
+		op, err := c.client.ConnectivityRules().Delete(ctx, id, "")
+		return mutation{ID: id, AsyncOperationID: operationID(op)}, err
+	},
+}
+
+var exportSinksResource = &resource{
+	name:       "export-sinks",
+	aliases:    []string{"export-sink"},
+	singular:   "export sink",
+	namespaced: true,
+	columns:    []string{"NAME", "TYPE", "ENABLED", "HEALTH", "STATE", "LATEST EXPORT"},
+	row: typed(func(s *namespacev1.ExportSink) []string {
+		sinkType := "s3"
+		if s.GetSpec().GetGcs() != nil {
+			sinkType = "gcs"
+		}
+		return []string{
+			s.GetName(),
+			sinkType,
+			strconv.FormatBool(s.GetSpec().GetEnabled()),
+			formatEnum(s.GetHealth().String(), "HEALTH_"),
+			formatState(s.GetState()),
+			formatTime(s.GetLatestDataExportTime()),
+		}
+	}),
+	get: getter(getExportSink),
+	list: lister(func(ctx context.Context, c *command) ([]*namespacev1.ExportSink, error) {
+		return paging.All(ctx, func(ctx context.Context, pageToken string) ([]*namespacev1.ExportSink, string, error) {
+			resp, err := c.client.CloudService().GetNamespaceExportSinks(ctx, &cloudservicev1.GetNamespaceExportSinksRequest{
+				Namespace: c.namespace,
+				PageToken: pageToken,
+			})
+			return resp.GetSinks(), resp.GetNextPageToken(), err
+		})
+	}),
+	create: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		spec := &namespacev1.ExportSinkSpec{}
+		if err := unmarshal(data, spec); err != nil {
+			return mutation{}, err
+		}
+		resp, err := c.client.CloudService().CreateNamespaceExportSink(ctx, &cloudservicev1.CreateNamespaceExportSinkRequest{
+			Namespace: c.namespace,
+			Spec:      spec,
+		})
+		return mutation{ID: spec.GetName(), AsyncOperationID: resp.GetAsyncOperation().GetId()}, err
+	},
+	apply: func(ctx context.Context, c *command, data []byte) (mutation, error) {
+		spec := &namespacev1.ExportSinkSpec{}
+		if err := unmarshal(data, spec); err != nil {
+			return mutation{}, err
+		}
+		return applyByID(ctx, spec.GetName(),
+			func(ctx context.Context, name string) (*namespacev1.ExportSink, error) {
+				return getExportSink(ctx, c, name)
+			},
+			func() (mutation, error) {
+				resp, err := c.client.CloudService().CreateNamespaceExportSink(ctx, &cloudservicev1.CreateNamespaceExportSinkRequest{
+					Namespace: c.namespace,
+					Spec:      spec,
+				})
+				return mutation{ID: spec.GetName(), AsyncOperationID: resp.GetAsyncOperation().GetId()}, err
+			},
+			func(current *namespacev1.ExportSink) (mutation, error) {
+				resp, err := c.client.CloudService().UpdateNamespaceExportSink(ctx, &cloudservicev1.UpdateNamespaceExportSinkRequest{
+					Namespace:       c.namespace,
+					Spec:            spec,
+					ResourceVersion: current.GetResourceVersion(),
+				})
+				return mutation{ID: spec.GetName(), AsyncOperationID: resp.GetAsyncOperation().GetId()}, err
+			},
+		)
+	},
+	delete: func(ctx context.Context, c *command, name string) (mutation, error) {
+		current, err := getExportSink(ctx, c, name)
+		if err != nil {
+			return mutation{}, err
+		}
+		resp, err := c.client.CloudService().DeleteNamespaceExportSink(ctx, &cloudservicev1.DeleteNamespaceExportSinkRequest{
+			Namespace:       c.namespace,
+			Name:            name,
+			ResourceVersion: current.GetResourceVersion(),
+		})
+		return mutation{ID: name, AsyncOperationID: resp.GetAsyncOperation().GetId()}, err
+	},
+}
+
+func getExportSink(ctx context.Context, c *command, name string) (*namespacev1.ExportSink, error) {
+	resp, err := c.client.CloudService().GetNamespaceExportSink(ctx, &cloudservicev1.GetNamespaceExportSinkRequest{
+		Namespace: c.namespace,
+		Name:      name,
+	})
+	return resp.GetSink(), err
+}
@@ -0,0 +1,20 @@
+// Package apikeys provides helpers to inspect the API keys of the cloud operations API.
+package apikeys
+
+import (
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+)
+
+// OwnerType returns the type of the owner of an API key, falling back to its deprecated string type.
+func OwnerType(spec *identityv1.ApiKeySpec) identityv1.OwnerType {
+	if t := spec.GetOwnerType(); t != identityv1.OwnerType_OWNER_TYPE_UNSPECIFIED {
+		return t
+	}
+	switch spec.GetOwnerTypeDeprecated() {
+	case "user":
+		return identityv1.OwnerType_OWNER_TYPE_USER
+	case "service-account":
+		return identityv1.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT
+	}
+	return identityv1.OwnerType_OWNER_TYPE_UNSPECIFIED
+}
@@ -0,0 +1,24 @@
+package apikeys
+
+import (
+	"testing"
+
+	identityv1 "go.temporal.io/cloud-sdk/api/identity/v1"
+)
+
+func TestOwnerType(t *testing.T) {
+	for _, tt := range []struct {
+		spec *identityv1.ApiKeySpec
+		want identityv1.OwnerType
+	}{
+		{&identityv1.ApiKeySpec{OwnerType: identityv1.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT, OwnerTypeDeprecated: "user"}, identityv1.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT},
+		{&identityv1.ApiKeySpec{OwnerTypeDeprecated: "user"}, identityv1.OwnerType_OWNER_TYPE_USER},
+		{&identityv1.ApiKeySpec{OwnerTypeDeprecated: "service-account"}, identityv1.OwnerType_OWNER_TYPE_SERVICE_ACCOUNT},
+		{&identityv1.ApiKeySpec{OwnerTypeDeprecated: "robot"}, identityv1.OwnerType_OWNER_TYPE_UNSPECIFIED},
+		{nil, identityv1.OwnerType_OWNER_TYPE_UNSPECIFIED},
+	} {
+		if got := OwnerType(tt.spec); got != tt.want {
+			t.Errorf("OwnerType(%v) = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
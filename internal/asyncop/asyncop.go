@@ -0,0 +1,61 @@
+// Package asyncop provides helpers to wait for the async operations returned by the cloud operations API.
+package asyncop
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	operationv1 "go.temporal.io/cloud-sdk/api/operation/v1"
+)
+
+// DefaultPollInterval is the interval between two polls of an async operation,
+// used when neither the caller nor the server suggest one.
+const DefaultPollInterval = time.Second
+
+// Wait polls the async operation with the given id until it reaches a terminal state.
+// An empty id is treated as an operation that completed synchronously and returns immediately.
+// If pollInterval is zero, the check duration suggested by the server, or DefaultPollInterval, is used.
+// An error is returned if the operation did not fulfill.
+func Wait(
+	ctx context.Context,
+	client cloudservicev1.CloudServiceClient,
+	id string,
+	pollInterval time.Duration,
+) (*operationv1.AsyncOperation, error) {
+	if id == "" {
+		return nil, nil
+	}
+	for {
+		resp, err := client.GetAsyncOperation(ctx, &cloudservicev1.GetAsyncOperationRequest{
+			AsyncOperationId: id,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get async operation %q: %w", id, err)
+		}
+		op := resp.GetAsyncOperation()
+		switch op.GetState() {
+		case operationv1.AsyncOperation_STATE_FULFILLED:
+			return op, nil
+		case operationv1.AsyncOperation_STATE_FAILED,
+			operationv1.AsyncOperation_STATE_CANCELLED,
+			operationv1.AsyncOperation_STATE_REJECTED:
+			return op, fmt.Errorf("async operation %q did not fulfill: state=%s reason=%q",
+				id, op.GetState(), op.GetFailureReason())
+		}
+
+		interval := pollInterval
+		if interval <= 0 {
+			interval = op.GetCheckDuration().AsDuration()
+		}
+		if interval <= 0 {
+			interval = DefaultPollInterval
+		}
+		select {
+		case <-ctx.Done():
+			return op, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
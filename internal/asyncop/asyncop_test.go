@@ -0,0 +1,97 @@
+package asyncop_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	cloudservicev1 "go.temporal.io/cloud-sdk/api/cloudservice/v1"
+	operationv1 "go.temporal.io/cloud-sdk/api/operation/v1"
+	"go.temporal.io/cloud-sdk/cloudclient/cloudservicemock"
+	"go.temporal.io/cloud-sdk/internal/asyncop"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// expectStates expects the operation to be polled once per state, in order.
+func expectStates(client *cloudservicemock.MockCloudServiceClient, states ...operationv1.AsyncOperation_State) {
+	var calls []any
+	for _, state := range states {
+		calls = append(calls, client.EXPECT().
+			GetAsyncOperation(gomock.Any(), cloudservicemock.EqualProto(&cloudservicev1.GetAsyncOperationRequest{AsyncOperationId: "op"})).
+			Return(&cloudservicev1.GetAsyncOperationResponse{AsyncOperation: &operationv1.AsyncOperation{
+				Id:            "op",
+				State:         state,
+				FailureReason: strings.ToLower(state.String()),
+			}}, nil))
+	}
+	gomock.InOrder(calls...)
+}
+
+func TestWait(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Empty ID", func(t *testing.T) {
+		client := cloudservicemock.NewMockCloudServiceClient(gomock.NewController(t))
+		if op, err := asyncop.Wait(ctx, client, "", time.Millisecond); op != nil || err != nil {
+			t.Errorf("Wait() = %v, %v, want nil, nil", op, err)
+		}
+	})
+
+	t.Run("Fulfilled", func(t *testing.T) {
+		client := cloudservicemock.NewMockCloudServiceClient(gomock.NewController(t))
+		expectStates(client,
+			operationv1.AsyncOperation_STATE_PENDING,
+			operationv1.AsyncOperation_STATE_IN_PROGRESS,
+			operationv1.AsyncOperation_STATE_FULFILLED,
+		)
+		op, err := asyncop.Wait(ctx, client, "op", time.Millisecond)
+		if err != nil || op.GetState() != operationv1.AsyncOperation_STATE_FULFILLED {
+			t.Errorf("Wait() = %v, %v, want a fulfilled operation", op, err)
+		}
+	})
+
+	for _, state := range []operationv1.AsyncOperation_State{
+		operationv1.AsyncOperation_STATE_FAILED,
+		operationv1.AsyncOperation_STATE_CANCELLED,
+		operationv1.AsyncOperation_STATE_REJECTED,
+	} {
+		t.Run(state.String(), func(t *testing.T) {
+			client := cloudservicemock.NewMockCloudServiceClient(gomock.NewController(t))
+			expectStates(client, operationv1.AsyncOperation_STATE_IN_PROGRESS, state)
+			op, err := asyncop.Wait(ctx, client, "op", time.Millisecond)
+			want := `async operation "op" did not fulfill: state=` + state.String() + ` reason="` + strings.ToLower(state.String()) + `"`
+			if err == nil || err.Error() != want || op.GetState() != state {
+				t.Errorf("Wait() = %v, %v, want %s", op, err, want)
+			}
+		})
+	}
+
+	t.Run("Get Error", func(t *testing.T) {
+		client := cloudservicemock.NewMockCloudServiceClient(gomock.NewController(t))
+		client.EXPECT().GetAsyncOperation(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unavailable, "unavailable"))
+		if _, err := asyncop.Wait(ctx, client, "op", time.Millisecond); status.Code(errors.Unwrap(err)) != codes.Unavailable {
+			t.Errorf("Wait() error = %v, want the Unavailable error", err)
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		client := cloudservicemock.NewMockCloudServiceClient(gomock.NewController(t))
+		client.EXPECT().GetAsyncOperation(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(context.Context, *cloudservicev1.GetAsyncOperationRequest, ...grpc.CallOption) (*cloudservicev1.GetAsyncOperationResponse, error) {
+				cancel()
+				return &cloudservicev1.GetAsyncOperationResponse{AsyncOperation: &operationv1.AsyncOperation{
+					Id:    "op",
+					State: operationv1.AsyncOperation_STATE_PENDING,
+				}}, nil
+			})
+		if _, err := asyncop.Wait(ctx, client, "op", time.Hour); !errors.Is(err, context.Canceled) {
+			t.Errorf("Wait() error = %v, want context.Canceled", err)
+		}
+	})
+}
@@ -0,0 +1,15 @@
+// Package csvsafe protects the CSV exports from the formula injection of the values chosen by the account members.
+package csvsafe
+
+import (
+	"strings"
+)
+
+// Value returns the value to write in a CSV cell, prefixed with a quote if it starts with =, +, -, @, a tab or a
+// carriage return, which make spreadsheets evaluate it as a formula.
+func Value(v string) string {
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
@@ -0,0 +1,23 @@
+package csvsafe
+
+import (
+	"testing"
+)
+
+func TestValue(t *testing.T) {
+	for v, want := range map[string]string{
+		"":                  "",
+		"alice@example.com": "alice@example.com",
+		"=HYPERLINK(\"x\")": "'=HYPERLINK(\"x\")",
+		"+1":                "'+1",
+		"-1":                "'-1",
+		"@SUM(A1)":          "'@SUM(A1)",
+		"\t=1":              "'\t=1",
+		"ci-deployer":       "ci-deployer",
+		"deployer=ci":       "deployer=ci",
+	} {
+		if got := Value(v); got != want {
+			t.Errorf("Value(%q) = %q, want %q", v, got, want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Package paging provides helpers to drain the paginated list operations of the cloud operations API.
+package paging
+
+import (
+	"context"
+	"iter"
+)
+
+// FetchFunc retrieves a single page of items.
+// It returns the items on the page and the token of the next page, which is empty once the last page was returned.
+type FetchFunc[T any] func(ctx context.Context, pageToken string) ([]T, string, error)
+
+// All calls fetch until the last page was returned and collects the items of every page.
+func All[T any](ctx context.Context, fetch FetchFunc[T]) ([]T, error) {
+	var (
+		items     []T
+		pageToken string
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		page, nextPageToken, err := fetch(ctx, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		if nextPageToken == "" {
+			return items, nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+// Seq returns an iterator over the items of every page, fetching the pages as the iteration proceeds.
+// A failed fetch yields the error with the zero value of T and ends the iteration.
+func Seq[T any](ctx context.Context, fetch FetchFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var (
+			zero      T
+			pageToken string
+		)
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			page, nextPageToken, err := fetch(ctx, pageToken)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if nextPageToken == "" {
+				return
+			}
+			pageToken = nextPageToken
+		}
+	}
+}
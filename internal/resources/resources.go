@@ -0,0 +1,11 @@
+// Package resources provides helpers to inspect the resources of the cloud operations API.
+package resources
+
+import (
+	resourcev1 "go.temporal.io/cloud-sdk/api/resource/v1"
+)
+
+// Deleted reports whether a resource is deleted, or being deleted, according to its state.
+func Deleted(state resourcev1.ResourceState) bool {
+	return state == resourcev1.ResourceState_RESOURCE_STATE_DELETING || state == resourcev1.ResourceState_RESOURCE_STATE_DELETED
+}
@@ -0,0 +1,21 @@
+package resources
+
+import (
+	"testing"
+
+	resourcev1 "go.temporal.io/cloud-sdk/api/resource/v1"
+)
+
+func TestDeleted(t *testing.T) {
+	for state, want := range map[resourcev1.ResourceState]bool{
+		resourcev1.ResourceState_RESOURCE_STATE_UNSPECIFIED: false,
+		resourcev1.ResourceState_RESOURCE_STATE_ACTIVE:      false,
+		resourcev1.ResourceState_RESOURCE_STATE_UPDATING:    false,
+		resourcev1.ResourceState_RESOURCE_STATE_DELETING:    true,
+		resourcev1.ResourceState_RESOURCE_STATE_DELETED:     true,
+	} {
+		if got := Deleted(state); got != want {
+			t.Errorf("Deleted(%v) = %v, want %v", state, got, want)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+// Package rpcerrors provides helpers to classify the errors of the cloud operations API.
+package rpcerrors
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IsTransport reports whether the error is about the call rather than the request, e.g. when the cloud service is
+// unavailable or the credentials are rejected, so that it says nothing about the resource the request is about.
+// The errors without a gRPC status are about the call too.
+func IsTransport(err error) bool {
+	if err == nil {
+		return false
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return true
+	}
+	switch st.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled, codes.ResourceExhausted, codes.Aborted, codes.Unauthenticated:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package rpcerrors
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsTransport(t *testing.T) {
+	for _, tt := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("connection reset"), true},
+		{status.Error(codes.Unavailable, "unavailable"), true},
+		{status.Error(codes.DeadlineExceeded, "deadline exceeded"), true},
+		{status.Error(codes.Unauthenticated, "invalid api key"), true},
+		{status.Error(codes.InvalidArgument, "access denied to bucket"), false},
+		{status.Error(codes.PermissionDenied, "denied"), false},
+	} {
+		if got := IsTransport(tt.err); got != tt.want {
+			t.Errorf("IsTransport(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
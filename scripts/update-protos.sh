@@ -261,6 +261,7 @@ generate_go_code() {
         log_info "[DRY RUN] Would clean generated files..."
         log_info "[DRY RUN] Would generate Go code from protos..."
         log_info "[DRY RUN] Would move generated files to correct location..."
+        log_info "[DRY RUN] Would regenerate mocks in cloudclient/cloudservicemock..."
         log_info "[DRY RUN] Would update default API version in cloudclient/options.go to $PROTO_VERSION"
         if [[ -n "$SDK_VERSION" ]]; then
             log_info "[DRY RUN] Would update SDK version in cloudclient/options.go to $SDK_VERSION"
@@ -282,6 +283,9 @@ generate_go_code() {
     log_info "Moving generated files to correct location..."
     mv -f api/temporal/api/cloud/* api && rm -rf api/temporal
     
+    log_info "Regenerating mocks..."
+    go generate ./cloudclient/cloudservicemock
+    
     log_info "Updating default API version in cloudclient/options.go to $PROTO_VERSION..."
     # Sanitize version strings for use in sed
     PROTO_VERSION_SAFE=$(sanitize_for_sed "$PROTO_VERSION")